@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -24,7 +26,11 @@ import (
 
 	"github.com/m1thrandir225/meridian/internal/messaging/application/handlers"
 	"github.com/m1thrandir225/meridian/internal/messaging/application/services"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/media"
 	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/storage"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/unfurl"
 )
 
 type Config struct {
@@ -32,10 +38,17 @@ type Config struct {
 	DatabaseURL        string
 	KafkaBrokers       []string
 	KafkaDefaultTopic  string
+	KafkaIdentityTopic string
+	ConsumerGroup      string
 	GRPCPort           string
 	IdentityGRPCURL    string
 	IntegrationGRPCURL string
 	RedisURL           string
+	MaxPinnedMessages  int
+	DispatchInterval   time.Duration
+	LinkUnfurling      bool
+	AttachmentDir      string
+	AttachmentKey      []byte
 	Environment        string
 	LogLevel           string
 }
@@ -56,6 +69,16 @@ func loadConfig() (*Config, error) {
 		return nil, fmt.Errorf("missing MESSAGING_KAFKA_DEFAULT_TOPIC")
 	}
 
+	kafkaIdentityTopic := os.Getenv("MESSAGING_KAFKA_IDENTITY_TOPIC")
+	if kafkaIdentityTopic == "" {
+		kafkaIdentityTopic = "meridian.identity.events"
+	}
+
+	consumerGroup := os.Getenv("MESSAGING_CONSUMER_GROUP")
+	if consumerGroup == "" {
+		consumerGroup = "messaging-service"
+	}
+
 	dbURL := os.Getenv("MESSAGING_DB_URL")
 	if dbURL == "" {
 		return nil, fmt.Errorf("missing MESSAGING_DB_URL")
@@ -87,6 +110,53 @@ func loadConfig() (*Config, error) {
 		fmt.Printf("WARN: MESSAGING_ENVIRONMENT is not set, using default %s\n", environment)
 	}
 
+	maxPinnedMessages := domain.DefaultMaxPinnedMessages
+	if maxPinsStr := os.Getenv("MESSAGING_MAX_PINNED_MESSAGES"); maxPinsStr != "" {
+		parsed, err := strconv.Atoi(maxPinsStr)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid MESSAGING_MAX_PINNED_MESSAGES: %q", maxPinsStr)
+		}
+		maxPinnedMessages = parsed
+	}
+
+	dispatchInterval := services.DefaultScheduledDispatchInterval
+	if intervalStr := os.Getenv("MESSAGING_SCHEDULED_DISPATCH_INTERVAL"); intervalStr != "" {
+		parsed, err := time.ParseDuration(intervalStr)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid MESSAGING_SCHEDULED_DISPATCH_INTERVAL: %q", intervalStr)
+		}
+		dispatchInterval = parsed
+	}
+
+	linkUnfurling := true
+	if unfurlStr := os.Getenv("MESSAGING_LINK_UNFURLING"); unfurlStr != "" {
+		parsed, err := strconv.ParseBool(unfurlStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MESSAGING_LINK_UNFURLING: %q", unfurlStr)
+		}
+		linkUnfurling = parsed
+	}
+
+	attachmentDir := os.Getenv("MESSAGING_ATTACHMENT_DIR")
+	if attachmentDir == "" {
+		attachmentDir = "data/attachments"
+	}
+
+	attachmentKey := []byte(os.Getenv("MESSAGING_ATTACHMENT_SIGNING_KEY"))
+	switch {
+	case len(attachmentKey) == 0 && environment == "development":
+		// A throwaway key keeps local setups working; download links break on restart
+		attachmentKey = make([]byte, 32)
+		if _, err := rand.Read(attachmentKey); err != nil {
+			return nil, fmt.Errorf("error generating attachment signing key: %w", err)
+		}
+		fmt.Println("WARN: MESSAGING_ATTACHMENT_SIGNING_KEY is not set, using an ephemeral key")
+	case len(attachmentKey) == 0:
+		return nil, fmt.Errorf("missing MESSAGING_ATTACHMENT_SIGNING_KEY")
+	case len(attachmentKey) < 32:
+		return nil, fmt.Errorf("MESSAGING_ATTACHMENT_SIGNING_KEY must be at least 32 characters")
+	}
+
 	level := os.Getenv("MESSAGING_LOG_LEVEL")
 	if level == "" {
 		level = "info"
@@ -98,10 +168,17 @@ func loadConfig() (*Config, error) {
 		DatabaseURL:        dbURL,
 		KafkaBrokers:       strings.Split(kafkaBrokerStr, ","),
 		KafkaDefaultTopic:  kafkaDefaultTopic,
+		KafkaIdentityTopic: kafkaIdentityTopic,
+		ConsumerGroup:      consumerGroup,
 		GRPCPort:           grpcPort,
 		IdentityGRPCURL:    identityGRPCURL,
 		RedisURL:           redisURL,
 		IntegrationGRPCURL: integrationGRPCURL,
+		MaxPinnedMessages:  maxPinnedMessages,
+		DispatchInterval:   dispatchInterval,
+		LinkUnfurling:      linkUnfurling,
+		AttachmentDir:      attachmentDir,
+		AttachmentKey:      attachmentKey,
 		Environment:        environment,
 		LogLevel:           level,
 	}, nil
@@ -180,32 +257,168 @@ func main() {
 	)
 	logger.Info("Channel service initialized.")
 
+	var linkUnfurler *services.LinkUnfurler
+	if cfg.LinkUnfurling {
+		linkUnfurler = services.NewLinkUnfurler(
+			repository,
+			redisCache,
+			unfurl.NewOpenGraphFetcher(unfurl.DefaultConfig()),
+			logger,
+		)
+		logger.Info("Link unfurler initialized.")
+	}
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.AttachmentDir)
+	if err != nil {
+		logger.Fatal("Failed to create attachment store", zap.Error(err))
+	}
+	attachmentURLs := services.NewAttachmentURLSigner(cfg.AttachmentKey, services.DefaultAttachmentURLTTL)
+
+	customEmojiService := services.NewCustomEmojiService(
+		persistence.NewPostgresCustomEmojiRepository(dbPool),
+		blobStore,
+		logger,
+	)
+	logger.Info("Custom emoji service initialized.")
+
 	messageService := services.NewMessageService(
 		repository,
 		eventPublisher,
 		identityClient,
 		integrationClient,
+		cfg.MaxPinnedMessages,
+		linkUnfurler,
+		attachmentURLs,
+		customEmojiService,
 		logger,
 	)
 	logger.Info("Message service initialized.")
 
-	httpHandler := handlers.NewHttpHandler(
-		channelService,
-		messageService,
-		redisCache,
+	attachmentRepository := persistence.NewPostgresAttachmentRepository(dbPool)
+	attachmentProcessor := services.NewAttachmentProcessor(
+		attachmentRepository,
+		blobStore,
+		media.NewImageProcessor(media.DefaultThumbnailSizes),
+		attachmentURLs,
 		logger,
 	)
-	logger.Info("HTTP Handler initialized")
+
+	attachmentService := services.NewAttachmentService(
+		attachmentRepository,
+		repository,
+		blobStore,
+		attachmentURLs,
+		attachmentProcessor,
+		logger,
+	)
+	logger.Info("Attachment service initialized.")
+
+	bookmarkService := services.NewBookmarkService(
+		persistence.NewPostgresBookmarkRepository(dbPool),
+		repository,
+		logger,
+	)
+	logger.Info("Bookmark service initialized.")
+
+	scheduledMessageRepository := persistence.NewPostgresScheduledMessageRepository(dbPool)
+	scheduledMessageService := services.NewScheduledMessageService(
+		scheduledMessageRepository,
+		repository,
+		logger,
+	)
+	logger.Info("Scheduled message service initialized.")
+
+	reminderService := services.NewReminderService(
+		persistence.NewPostgresReminderRepository(dbPool),
+		repository,
+		logger,
+	)
+	logger.Info("Reminder service initialized.")
+
+	slashCommands := services.NewSlashCommandRegistry(logger)
+	if err := services.RegisterBuiltinSlashCommands(slashCommands, channelService, messageService, reminderService); err != nil {
+		logger.Fatal("Failed to register slash commands", zap.Error(err))
+	}
+	logger.Info("Slash commands registered.")
+
+	// --- Kafka Consumer ---
+	eventHandler := handlers.NewMessagingEventHandler(channelService, logger)
+
+	consumerConfig := sarama.NewConfig()
+	consumerConfig.Consumer.Group.Rebalance.Strategy = sarama.BalanceStrategyRoundRobin
+	consumerConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	consumer := kafka.NewEventConsumer(cfg.KafkaBrokers, cfg.ConsumerGroup, consumerConfig)
+	logger.Info("Kafka consumer initialized.")
+
+	go func() {
+		topics := []string{cfg.KafkaIdentityTopic}
+		logger.Info("Starting Kafka consumer", zap.Strings("topics", topics), zap.String("consumer_group", cfg.ConsumerGroup))
+		if err := consumer.ConsumeEvents(ctx, topics, eventHandler); err != nil {
+			logger.Error("Error consuming events", zap.Error(err))
+		}
+	}()
 
 	wsHandler := handlers.NewWebSocketHandler(
 		channelService,
 		messageService,
+		slashCommands,
 		redisClient,
+		redisCache,
 		identityClient,
 		logger,
 	)
 	logger.Info("WebSocket Handler initialized")
 
+	dispatcher := services.NewScheduledMessageDispatcher(
+		scheduledMessageRepository,
+		messageService,
+		wsHandler,
+		cfg.DispatchInterval,
+		logger,
+	)
+	go dispatcher.Run(ctx)
+
+	reminderDispatcher := services.NewReminderDispatcher(
+		reminderService,
+		wsHandler,
+		cfg.DispatchInterval,
+		logger,
+	)
+	go reminderDispatcher.Run(ctx)
+
+	pollCloser := services.NewPollCloser(
+		messageService,
+		wsHandler,
+		cfg.DispatchInterval,
+		logger,
+	)
+	go pollCloser.Run(ctx)
+
+	if linkUnfurler != nil {
+		go linkUnfurler.Run(ctx, wsHandler)
+	}
+
+	go attachmentProcessor.Run(ctx, wsHandler)
+
+	attachmentJanitor := services.NewAttachmentJanitor(attachmentService, logger)
+	go attachmentJanitor.Run(ctx)
+
+	httpHandler := handlers.NewHttpHandler(
+		channelService,
+		messageService,
+		bookmarkService,
+		scheduledMessageService,
+		reminderService,
+		attachmentService,
+		customEmojiService,
+		slashCommands,
+		wsHandler,
+		redisCache,
+		logger,
+	)
+	logger.Info("HTTP Handler initialized")
+
 	// -- GIN ROUTE SETUP --
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
@@ -0,0 +1,31 @@
+import config from '@/lib/config'
+import type { Attachment } from '@/types/models/attachment'
+import type { UploadAttachmentRequest } from '@/types/responses/attachment'
+import { apiRequest, multipartApiRequest } from './api.service'
+
+const messagesApiURL = `${config.apiUrl}/messages`
+
+const attachmentService = {
+  uploadAttachment: (channelId: string, input: UploadAttachmentRequest) =>
+    multipartApiRequest<UploadAttachmentRequest, Attachment>({
+      url: `${messagesApiURL}/channels/${channelId}/attachments`,
+      method: 'POST',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  getAttachment: (attachmentId: string) =>
+    apiRequest<Attachment>({
+      url: `${messagesApiURL}/attachments/${attachmentId}`,
+      method: 'GET',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+    }),
+  // Signed URLs are relative to the API host and need no Authorization header
+  downloadUrl: (attachment: Attachment) =>
+    attachment.url ? `${config.baseUrl}${attachment.url}` : undefined,
+}
+
+export default attachmentService
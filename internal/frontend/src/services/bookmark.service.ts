@@ -0,0 +1,40 @@
+import config from '@/lib/config'
+import type {
+  BookmarkPageParams,
+  BookmarkPageResponse,
+  CreateBookmarkRequest,
+} from '@/types/responses/bookmark'
+import { apiRequest } from './api.service'
+import type { Bookmark } from '@/types/models/bookmark'
+
+const bookmarksApiURL = `${config.apiUrl}/messages/bookmarks`
+
+const bookmarkService = {
+  getBookmarks: (params?: BookmarkPageParams) =>
+    apiRequest<BookmarkPageResponse>({
+      url: bookmarksApiURL,
+      method: 'GET',
+      protected: true,
+      headers: undefined,
+      params: params,
+    }),
+  addBookmark: (input: CreateBookmarkRequest) =>
+    apiRequest<Bookmark>({
+      url: bookmarksApiURL,
+      method: 'POST',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  removeBookmark: (bookmarkId: string) =>
+    apiRequest<void>({
+      url: `${bookmarksApiURL}/${bookmarkId}`,
+      method: 'DELETE',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+    }),
+}
+
+export default bookmarkService
@@ -1,10 +1,22 @@
 import config from '@/lib/config'
-import type { CreateChannelRequest } from '@/types/responses/channel'
+import type {
+  ChannelDirectoryParams,
+  ChannelDirectoryResponse,
+  CreateChannelRequest,
+} from '@/types/responses/channel'
 import { apiRequest } from './api.service'
-import type { Channel } from '@/types/models/channel'
+import type { Channel, ChannelBan, ChannelMember, MemberRole } from '@/types/models/channel'
+import type { MessagePageParams, MessagePageResponse } from '@/types/responses/message'
 import type { Message } from '@/types/models/message'
+import type { Poll } from '@/types/models/poll'
+import type { MessagePreview } from '@/types/models/message_reference'
 import type { Reaction } from '@/types/models/reaction'
 import type { ReactionCreateRequest, ReactionRemoveRequest } from '@/types/responses/reaction'
+import type { CreatePollRequest, VotePollRequest } from '@/types/responses/poll'
+import type {
+  ForwardMessageRequest,
+  QuoteMessageRequest,
+} from '@/types/responses/message_reference'
 
 const channelApiURL = `${config.apiUrl}/messages/channels`
 
@@ -26,6 +38,14 @@ const channelService = {
       params: undefined,
       method: 'GET',
     }),
+  getDirectory: (params?: ChannelDirectoryParams) =>
+    apiRequest<ChannelDirectoryResponse>({
+      url: `${channelApiURL}/directory`,
+      protected: true,
+      headers: undefined,
+      params: params,
+      method: 'GET',
+    }),
   getChannel: (input: string) =>
     apiRequest<Channel>({
       url: `${channelApiURL}/${input}`,
@@ -50,14 +70,192 @@ const channelService = {
       params: undefined,
       method: 'PUT',
     }),
-  getMessages: (channelId: string) =>
-    apiRequest<Message[]>({
+  leaveChannel: (channelId: string) =>
+    apiRequest<void>({
+      url: `${channelApiURL}/${channelId}/leave`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'POST',
+    }),
+  setTopic: (channelId: string, topic: string) =>
+    apiRequest<Channel>({
+      url: `${channelApiURL}/${channelId}/topic`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'PUT',
+      data: { topic },
+    }),
+  renameChannel: (channelId: string, name: string) =>
+    apiRequest<Channel>({
+      url: `${channelApiURL}/${channelId}/name`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'PUT',
+      data: { name },
+    }),
+  removeBot: (channelId: string, integrationId: string) =>
+    apiRequest<Channel>({
+      url: `${channelApiURL}/${channelId}/bots/${integrationId}`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'DELETE',
+    }),
+  promoteMember: (channelId: string, userId: string, role: MemberRole) =>
+    apiRequest<ChannelMember>({
+      url: `${channelApiURL}/${channelId}/members/${userId}/promote`,
+      method: 'POST',
+      headers: undefined,
+      params: undefined,
+      protected: true,
+      data: { role },
+    }),
+  demoteMember: (channelId: string, userId: string, role: MemberRole) =>
+    apiRequest<ChannelMember>({
+      url: `${channelApiURL}/${channelId}/members/${userId}/demote`,
+      method: 'POST',
+      headers: undefined,
+      params: undefined,
+      protected: true,
+      data: { role },
+    }),
+  transferOwnership: (channelId: string, userId: string) =>
+    apiRequest<Channel>({
+      url: `${channelApiURL}/${channelId}/transfer-ownership`,
+      method: 'POST',
+      headers: undefined,
+      params: undefined,
+      protected: true,
+      data: { user_id: userId },
+    }),
+  kickMember: (channelId: string, userId: string) =>
+    apiRequest<void>({
+      url: `${channelApiURL}/${channelId}/members/${userId}/kick`,
+      method: 'POST',
+      headers: undefined,
+      params: undefined,
+      protected: true,
+    }),
+  getBans: (channelId: string) =>
+    apiRequest<ChannelBan[]>({
+      url: `${channelApiURL}/${channelId}/bans`,
+      method: 'GET',
+      headers: undefined,
+      params: undefined,
+      protected: true,
+    }),
+  banMember: (channelId: string, userId: string, reason?: string, expiresAt?: string) =>
+    apiRequest<ChannelBan>({
+      url: `${channelApiURL}/${channelId}/bans`,
+      method: 'POST',
+      headers: undefined,
+      params: undefined,
+      protected: true,
+      data: { user_id: userId, reason, expires_at: expiresAt },
+    }),
+  unbanMember: (channelId: string, userId: string) =>
+    apiRequest<void>({
+      url: `${channelApiURL}/${channelId}/bans/${userId}`,
+      method: 'DELETE',
+      headers: undefined,
+      params: undefined,
+      protected: true,
+    }),
+  getMessages: (channelId: string, params?: MessagePageParams) =>
+    apiRequest<MessagePageResponse>({
       url: `${channelApiURL}/${channelId}/messages`,
       protected: true,
       headers: undefined,
+      params: params,
+      method: 'GET',
+    }),
+  getPins: (channelId: string) =>
+    apiRequest<Message[]>({
+      url: `${channelApiURL}/${channelId}/pins`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'GET',
+    }),
+  pinMessage: (channelId: string, messageId: string) =>
+    apiRequest<Message>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/pin`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'PUT',
+    }),
+  unpinMessage: (channelId: string, messageId: string) =>
+    apiRequest<Message>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/pin`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'DELETE',
+    }),
+  forwardMessage: (channelId: string, messageId: string, input: ForwardMessageRequest) =>
+    apiRequest<Message>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/forward`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'POST',
+      data: input,
+    }),
+  quoteMessage: (channelId: string, messageId: string, input: QuoteMessageRequest) =>
+    apiRequest<Message>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/quote`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'POST',
+      data: input,
+    }),
+  getMessageSource: (channelId: string, messageId: string) =>
+    apiRequest<MessagePreview>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/source`,
+      protected: true,
+      headers: undefined,
       params: undefined,
       method: 'GET',
     }),
+  createPoll: (channelId: string, input: CreatePollRequest) =>
+    apiRequest<Message>({
+      url: `${channelApiURL}/${channelId}/polls`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'POST',
+      data: input,
+    }),
+  votePoll: (channelId: string, messageId: string, input: VotePollRequest) =>
+    apiRequest<Poll>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/poll/votes`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'PUT',
+      data: input,
+    }),
+  retractPollVote: (channelId: string, messageId: string) =>
+    apiRequest<Poll>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/poll/votes`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'DELETE',
+    }),
+  closePoll: (channelId: string, messageId: string) =>
+    apiRequest<Poll>({
+      url: `${channelApiURL}/${channelId}/messages/${messageId}/poll/close`,
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      method: 'POST',
+    }),
   addReaction: (channelId: string, messageId: string, input: ReactionCreateRequest) =>
     apiRequest<Reaction>({
       url: `${channelApiURL}/${channelId}/messages/${messageId}/reactions`,
@@ -0,0 +1,50 @@
+import config from '@/lib/config'
+import type { CustomEmoji } from '@/types/models/custom_emoji'
+import type {
+  AddCustomEmojiAliasRequest,
+  UploadCustomEmojiRequest,
+} from '@/types/responses/custom_emoji'
+import { apiRequest, multipartApiRequest } from './api.service'
+
+const emojiApiURL = `${config.apiUrl}/messages/emoji`
+
+const emojiService = {
+  getCustomEmoji: () =>
+    apiRequest<CustomEmoji[]>({
+      url: emojiApiURL,
+      method: 'GET',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+    }),
+  uploadCustomEmoji: (input: UploadCustomEmojiRequest) =>
+    multipartApiRequest<UploadCustomEmojiRequest, CustomEmoji>({
+      url: emojiApiURL,
+      method: 'POST',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  addAlias: (name: string, input: AddCustomEmojiAliasRequest) =>
+    apiRequest<CustomEmoji>({
+      url: `${emojiApiURL}/${name}/aliases`,
+      method: 'POST',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  deleteCustomEmoji: (name: string) =>
+    apiRequest<void>({
+      url: `${emojiApiURL}/${name}`,
+      method: 'DELETE',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+    }),
+  // Image URLs are relative to the API host and need no Authorization header
+  imageUrl: (emoji: CustomEmoji) => `${config.baseUrl}${emoji.image_url}`,
+}
+
+export default emojiService
@@ -0,0 +1,45 @@
+import config from '@/lib/config'
+import type { CreateReminderRequest, ReminderParams, ReminderTimeRequest } from '@/types/responses/reminder'
+import { apiRequest } from './api.service'
+import type { Reminder } from '@/types/models/reminder'
+
+const remindersApiURL = `${config.apiUrl}/messages/reminders`
+
+const reminderService = {
+  getReminders: (params?: ReminderParams) =>
+    apiRequest<Reminder[]>({
+      url: remindersApiURL,
+      method: 'GET',
+      protected: true,
+      headers: undefined,
+      params: params,
+    }),
+  setReminder: (input: CreateReminderRequest) =>
+    apiRequest<Reminder>({
+      url: remindersApiURL,
+      method: 'POST',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  snoozeReminder: (reminderId: string, input: ReminderTimeRequest) =>
+    apiRequest<Reminder>({
+      url: `${remindersApiURL}/${reminderId}/snooze`,
+      method: 'PUT',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  deleteReminder: (reminderId: string) =>
+    apiRequest<void>({
+      url: `${remindersApiURL}/${reminderId}`,
+      method: 'DELETE',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+    }),
+}
+
+export default reminderService
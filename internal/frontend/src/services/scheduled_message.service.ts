@@ -0,0 +1,49 @@
+import config from '@/lib/config'
+import type {
+  CreateScheduledMessageRequest,
+  ScheduledMessageParams,
+  UpdateScheduledMessageRequest,
+} from '@/types/responses/scheduled_message'
+import { apiRequest } from './api.service'
+import type { ScheduledMessage } from '@/types/models/scheduled_message'
+
+const scheduledApiURL = `${config.apiUrl}/messages/scheduled`
+
+const scheduledMessageService = {
+  getScheduledMessages: (params?: ScheduledMessageParams) =>
+    apiRequest<ScheduledMessage[]>({
+      url: scheduledApiURL,
+      method: 'GET',
+      protected: true,
+      headers: undefined,
+      params: params,
+    }),
+  scheduleMessage: (input: CreateScheduledMessageRequest) =>
+    apiRequest<ScheduledMessage>({
+      url: scheduledApiURL,
+      method: 'POST',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  updateScheduledMessage: (scheduledMessageId: string, input: UpdateScheduledMessageRequest) =>
+    apiRequest<ScheduledMessage>({
+      url: `${scheduledApiURL}/${scheduledMessageId}`,
+      method: 'PUT',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+      data: input,
+    }),
+  cancelScheduledMessage: (scheduledMessageId: string) =>
+    apiRequest<void>({
+      url: `${scheduledApiURL}/${scheduledMessageId}`,
+      method: 'DELETE',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+    }),
+}
+
+export default scheduledMessageService
@@ -0,0 +1,18 @@
+import config from '@/lib/config'
+import type { MessageSearchParams, MessageSearchResponse } from '@/types/responses/message'
+import { apiRequest } from './api.service'
+
+const searchApiURL = `${config.apiUrl}/messages/search`
+
+const searchService = {
+  searchMessages: (params: MessageSearchParams) =>
+    apiRequest<MessageSearchResponse>({
+      url: searchApiURL,
+      method: 'GET',
+      protected: true,
+      headers: undefined,
+      params: params,
+    }),
+}
+
+export default searchService
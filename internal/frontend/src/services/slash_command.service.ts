@@ -0,0 +1,18 @@
+import config from '@/lib/config'
+import type { SlashCommand } from '@/types/responses/slash_command'
+import { apiRequest } from './api.service'
+
+const commandsApiURL = `${config.apiUrl}/messages/commands`
+
+const slashCommandService = {
+  getCommands: () =>
+    apiRequest<SlashCommand[]>({
+      url: commandsApiURL,
+      method: 'GET',
+      protected: true,
+      headers: undefined,
+      params: undefined,
+    }),
+}
+
+export default slashCommandService
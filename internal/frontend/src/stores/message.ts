@@ -2,10 +2,11 @@ import channelService from '@/services/channel.service'
 import websocketService from '@/services/websocket.service'
 import { useAuthStore } from '@/stores/auth'
 import type { Message } from '@/types/models/message'
-import type { Reaction } from '@/types/models/reaction'
+import type { Poll } from '@/types/models/poll'
 import type {
   IncomingMessagePayload,
   IncomingReactionPayload,
+  PollUpdatedPayload,
   TypingPayload,
 } from '@/types/websocket'
 import { defineStore } from 'pinia'
@@ -26,9 +27,9 @@ export const useMessageStore = defineStore('message', () => {
   async function fetchMessages(channelId: string) {
     loading.value = true
     try {
-      const fetchedMessages = await channelService.getMessages(channelId)
+      const page = await channelService.getMessages(channelId)
       const existingIds = new Set(messages.value.map((m) => m.id))
-      const newMessages = fetchedMessages.filter((m) => !existingIds.has(m.id))
+      const newMessages = page.messages.filter((m) => !existingIds.has(m.id))
       messages.value = [...messages.value, ...newMessages]
       currentChannelId.value = channelId
     } catch (error) {
@@ -56,6 +57,11 @@ export const useMessageStore = defineStore('message', () => {
       sender_user_id: payload.sender_user_id,
       integration_id: payload.integration_id,
       content_text: payload.content,
+      content_rich_text: payload.content_rich_text,
+      is_formatted: payload.is_formatted,
+      attachments: payload.attachments,
+      poll: payload.poll,
+      reference: payload.reference,
       parent_message_id: payload.parent_message_id,
       created_at: payload.timestamp,
       sender_user: payload.sender_user,
@@ -63,6 +69,23 @@ export const useMessageStore = defineStore('message', () => {
     }
 
     addMessage(message)
+    if (message.reference && message.reference.accessible === undefined) {
+      loadReferencePreview(message.channel_id, message.id)
+    }
+  }
+
+  // Live events leave out previews of other channels, as only some members may read them
+  async function loadReferencePreview(channelId: string, messageId: string) {
+    const message = messages.value.find((m) => m.id === messageId)
+    if (!message?.reference) return
+
+    try {
+      const preview = await channelService.getMessageSource(channelId, messageId)
+      message.reference = { ...message.reference, accessible: true, preview }
+    } catch (error) {
+      console.log('Source of message is not available:', messageId, error)
+      message.reference = { ...message.reference, accessible: false }
+    }
   }
 
   function addReactionToMessage(messageId: string, reactionPayload: IncomingReactionPayload) {
@@ -78,25 +101,19 @@ export const useMessageStore = defineStore('message', () => {
       message.reactions = []
     }
 
-    // Convert payload to Reaction type
-    const reaction: Reaction = {
-      id: reactionPayload.id,
-      message_id: reactionPayload.message_id,
-      user_id: reactionPayload.user_id,
-      reaction_type: reactionPayload.reaction_type,
-      timestamp: reactionPayload.timestamp,
-    }
-
-    // Check if user already has this reaction
-    const existingReactionIndex = message.reactions.findIndex(
-      (r) => r.user_id === reaction.user_id && r.reaction_type === reaction.reaction_type,
-    )
-
-    if (existingReactionIndex === -1) {
-      message.reactions.push(reaction)
-      console.log('Reaction added, new count:', message.reactions.length)
-    } else {
+    const reactedByMe = reactionPayload.user_id === authStore.user?.id
+    const existing = message.reactions.find((r) => r.reaction_type === reactionPayload.reaction_type)
+    if (!existing) {
+      message.reactions.push({
+        reaction_type: reactionPayload.reaction_type,
+        count: 1,
+        reacted_by_me: reactedByMe,
+      })
+    } else if (reactedByMe && existing.reacted_by_me) {
       console.log('Reaction already exists, skipping')
+    } else {
+      existing.count++
+      existing.reacted_by_me = existing.reacted_by_me || reactedByMe
     }
   }
 
@@ -109,17 +126,69 @@ export const useMessageStore = defineStore('message', () => {
     }
 
     const message = messages.value[messageIndex]
-    if (!message.reactions) {
+    const existing = message.reactions?.find((r) => r.reaction_type === reactionType)
+    if (!message.reactions || !existing) {
       console.log('No reactions to remove')
       return
     }
 
-    const beforeCount = message.reactions.length
-    message.reactions = message.reactions.filter(
-      (r) => !(r.user_id === userId && r.reaction_type === reactionType),
+    existing.count--
+    if (userId === authStore.user?.id) {
+      existing.reacted_by_me = false
+    }
+    message.reactions = message.reactions.filter((r) => r.count > 0)
+    console.log(`Reaction removed: ${reactionType} -> ${existing.count}`)
+  }
+
+  function setMessagePoll(messageId: string, poll: Poll) {
+    const message = messages.value.find((m) => m.id === messageId)
+    if (message) {
+      message.poll = poll
+    }
+  }
+
+  // Broadcast tallies are shared by every member, so the user's own votes are kept from the store
+  function updatePollFromWebSocket(payload: PollUpdatedPayload) {
+    const message = messages.value.find((m) => m.id === payload.message_id)
+    if (!message) {
+      return
+    }
+
+    const votedByMe = new Set(
+      message.poll?.options.filter((o) => o.voted_by_me).map((o) => o.id) ?? [],
     )
-    const afterCount = message.reactions.length
-    console.log(`Reaction removed: ${beforeCount} -> ${afterCount}`)
+    const myId = authStore.user?.id
+    message.poll = {
+      ...payload.poll,
+      options: payload.poll.options.map((option) => ({
+        ...option,
+        voted_by_me:
+          myId && option.voters ? option.voters.includes(myId) : votedByMe.has(option.id),
+      })),
+    }
+  }
+
+  async function votePoll(messageId: string, optionIds: string[]) {
+    if (!currentChannelId.value) return
+
+    const poll = await channelService.votePoll(currentChannelId.value, messageId, {
+      option_ids: optionIds,
+    })
+    setMessagePoll(messageId, poll)
+  }
+
+  async function retractPollVote(messageId: string) {
+    if (!currentChannelId.value) return
+
+    const poll = await channelService.retractPollVote(currentChannelId.value, messageId)
+    setMessagePoll(messageId, poll)
+  }
+
+  async function closePoll(messageId: string) {
+    if (!currentChannelId.value) return
+
+    const poll = await channelService.closePoll(currentChannelId.value, messageId)
+    setMessagePoll(messageId, poll)
   }
 
   function addReaction(messageId: string, reactionType: string) {
@@ -231,6 +300,17 @@ export const useMessageStore = defineStore('message', () => {
       }
     })
 
+    websocketService.on('poll_updated', (payload: unknown) => {
+      if (
+        payload &&
+        typeof payload === 'object' &&
+        'message_id' in payload &&
+        'poll' in payload
+      ) {
+        updatePollFromWebSocket(payload as PollUpdatedPayload)
+      }
+    })
+
     websocketService.on('typing_start', (payload: unknown) => {
       console.log('Received typing_start event:', payload)
       if (
@@ -291,6 +371,16 @@ export const useMessageStore = defineStore('message', () => {
         )
       }
     })
+    websocketService.off('poll_updated', (payload: unknown) => {
+      if (
+        payload &&
+        typeof payload === 'object' &&
+        'message_id' in payload &&
+        'poll' in payload
+      ) {
+        updatePollFromWebSocket(payload as PollUpdatedPayload)
+      }
+    })
     websocketService.off('typing_start', (payload: unknown) => {
       if (
         payload &&
@@ -325,6 +415,9 @@ export const useMessageStore = defineStore('message', () => {
     removeReactionFromMessage,
     addReaction,
     removeReaction,
+    votePoll,
+    retractPollVote,
+    closePoll,
     clearMessages,
     sendMessage,
     startTyping,
@@ -0,0 +1,25 @@
+export type AttachmentProcessingStatus = 'none' | 'pending' | 'ready' | 'failed'
+
+export interface AttachmentThumbnail {
+  name: string
+  width: number
+  height: number
+  content_type: string
+  url?: string
+}
+
+export interface Attachment {
+  id: string
+  file_name: string
+  content_type: string
+  size: number
+  url?: string
+  url_expires_at?: string
+  processing_status: AttachmentProcessingStatus
+  width?: number
+  height?: number
+  blur_hash?: string
+  dominant_color?: string
+  thumbnails?: AttachmentThumbnail[]
+  created_at: string
+}
@@ -0,0 +1,10 @@
+import type { Message } from './message'
+
+export interface Bookmark {
+  id: string
+  channel_id: string
+  message_id: string
+  note: string
+  created_at: string
+  message?: Message
+}
@@ -1,14 +1,40 @@
 import type { IntegrationBot } from './integration_bot'
 import type { User } from './user'
 
+export type ChannelKind = 'channel' | 'dm' | 'group_dm'
+
+export type ChannelVisibility = 'public' | 'private'
+
+export type MemberRole = 'owner' | 'admin' | 'moderator' | 'member' | 'bot' | 'guest'
+
+export interface ChannelMember {
+  channel_id: string
+  user_id: string
+  role: MemberRole
+  joined_at: string
+}
+
+export interface ChannelBan {
+  channel_id: string
+  user_id: string
+  banned_by: string
+  reason: string
+  expires_at: string | null
+  created_at: string
+}
+
 export interface Channel {
   id: string
+  kind: ChannelKind
+  visibility: ChannelVisibility
   name: string
   topic: string
   creator_user_id: string
   creation_time: string
   is_archived: boolean
   members_count: number
+  unread_count?: number
+  mention_count?: number
   last_message_time: string
   members: User[]
   bots: IntegrationBot[]
@@ -0,0 +1,8 @@
+export interface CustomEmoji {
+  id: string
+  name: string
+  alias_for?: string
+  image_url: string
+  creator_id: string
+  created_at: string
+}
@@ -0,0 +1,7 @@
+export interface LinkPreview {
+  url: string
+  title?: string
+  description?: string
+  site_name?: string
+  image_url?: string
+}
@@ -1,4 +1,9 @@
-import type { Reaction } from './reaction'
+import type { Attachment } from './attachment'
+import type { LinkPreview } from './link_preview'
+import type { MessageReference } from './message_reference'
+import type { Poll } from './poll'
+import type { ReactionCount } from './reaction'
+import type { RichText } from './rich_text'
 
 export interface Message {
   id: string
@@ -6,9 +11,21 @@ export interface Message {
   sender_user_id?: string
   integration_id?: string
   content_text: string
+  content_rich_text: RichText
+  is_formatted: boolean
   parent_message_id?: string
   created_at: string
-  reactions?: Reaction[]
+  is_pinned?: boolean
+  pinned_at?: string
+  pinned_by?: string
+  reply_count?: number
+  last_reply_at?: string
+  participants?: string[]
+  reactions?: ReactionCount[]
+  link_previews?: LinkPreview[]
+  attachments?: Attachment[]
+  poll?: Poll
+  reference?: MessageReference
   sender_user?: {
     id: string
     username: string
@@ -0,0 +1,34 @@
+import type { RichText } from './rich_text'
+
+export type MessageReferenceKind = 'forward' | 'quote'
+
+export interface MessagePreview {
+  id: string
+  channel_id: string
+  sender_user_id?: string
+  integration_id?: string
+  sender_user?: {
+    id: string
+    username: string
+    email: string
+    first_name: string
+    last_name: string
+  }
+  content_text: string
+  content_rich_text: RichText
+  is_formatted: boolean
+  attachment_count: number
+  is_poll: boolean
+  created_at: string
+  edited_at?: string
+  is_deleted: boolean
+}
+
+export interface MessageReference {
+  kind: MessageReferenceKind
+  source_message_id: string
+  source_channel_id: string
+  // Left out of live events for sources in other channels; load the preview with getMessageSource
+  accessible?: boolean
+  preview?: MessagePreview
+}
@@ -0,0 +1,18 @@
+export interface PollOption {
+  id: string
+  text: string
+  vote_count: number
+  voters?: string[]
+  voted_by_me: boolean
+}
+
+export interface Poll {
+  question: string
+  options: PollOption[]
+  multiple_choice: boolean
+  anonymous: boolean
+  voter_count: number
+  closes_at?: string
+  closed_at?: string
+  is_closed: boolean
+}
@@ -5,3 +5,10 @@ export interface Reaction {
   reaction_type: string
   timestamp: string
 }
+
+// The reactions of one type on a message, as returned with every message
+export interface ReactionCount {
+  reaction_type: string
+  count: number
+  reacted_by_me: boolean
+}
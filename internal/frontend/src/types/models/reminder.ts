@@ -0,0 +1,14 @@
+import type { Message } from './message'
+
+export type ReminderStatus = 'pending' | 'fired'
+
+export interface Reminder {
+  id: string
+  channel_id: string
+  message_id: string
+  remind_at: string
+  status: ReminderStatus
+  fired_at?: string
+  created_at: string
+  message?: Message
+}
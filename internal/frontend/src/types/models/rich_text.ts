@@ -0,0 +1,32 @@
+export type RichTextNodeType =
+  | 'paragraph'
+  | 'code_block'
+  | 'quote'
+  | 'list'
+  | 'list_item'
+  | 'text'
+  | 'bold'
+  | 'italic'
+  | 'code'
+  | 'link'
+  | 'mention'
+  | 'channel_ref'
+  | 'line_break'
+
+export interface RichTextNode {
+  type: RichTextNodeType
+  text?: string
+  language?: string
+  url?: string
+  username?: string
+  user_id?: string
+  channel_name?: string
+  ordered?: boolean
+  start?: number
+  children?: RichTextNode[]
+}
+
+export interface RichText {
+  version: number
+  blocks: RichTextNode[]
+}
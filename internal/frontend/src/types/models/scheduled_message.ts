@@ -0,0 +1,13 @@
+export type ScheduledMessageStatus = 'pending' | 'sending' | 'failed'
+
+export interface ScheduledMessage {
+  id: string
+  channel_id: string
+  parent_message_id: string | null
+  content_text: string
+  scheduled_for: string
+  status: ScheduledMessageStatus
+  failure_reason?: string
+  created_at: string
+  updated_at: string
+}
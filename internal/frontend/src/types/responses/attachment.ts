@@ -0,0 +1,3 @@
+export type UploadAttachmentRequest = {
+  file: File
+}
@@ -0,0 +1,17 @@
+import type { Bookmark } from '@/types/models/bookmark'
+
+export type CreateBookmarkRequest = {
+  channel_id: string
+  message_id: string
+  note?: string
+}
+
+export type BookmarkPageResponse = {
+  bookmarks: Bookmark[]
+  next_cursor: string | null
+}
+
+export type BookmarkPageParams = {
+  limit?: number
+  before?: string
+}
@@ -1,8 +1,29 @@
-import type { Channel } from '@/types/models/channel'
+import type { Channel, ChannelVisibility } from '@/types/models/channel'
 
 export type CreateChannelRequest = {
   name: string
   topic: string
+  visibility?: ChannelVisibility
+}
+
+export type ChannelDirectoryEntry = {
+  id: string
+  name: string
+  topic: string
+  members_count: number
+  creation_time: string
+  last_message_time: string
+}
+
+export type ChannelDirectoryResponse = {
+  channels: ChannelDirectoryEntry[]
+  next_cursor: string | null
+}
+
+export type ChannelDirectoryParams = {
+  q?: string
+  limit?: number
+  before?: string
 }
 
 export type CreateChannelResponse = Channel
@@ -0,0 +1,8 @@
+export type UploadCustomEmojiRequest = {
+  name: string
+  file: File
+}
+
+export type AddCustomEmojiAliasRequest = {
+  name: string
+}
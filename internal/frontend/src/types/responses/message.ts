@@ -1,5 +1,38 @@
+import type { Message } from '@/types/models/message'
+
 export type CreateMessageRequest = {
   content_text: string
   is_integration_message?: boolean
   parent_message_id?: string
+  attachment_ids?: string[]
+}
+
+export type MessagePageResponse = {
+  messages: Message[]
+  next_cursor: string | null
+  prev_cursor: string | null
+}
+
+export type MessagePageParams = {
+  limit?: number
+  before?: string
+  after?: string
+  around?: string
+}
+
+export type MessageSearchResult = {
+  message: Message
+  snippet: string
+  rank: number
+}
+
+export type MessageSearchResponse = {
+  results: MessageSearchResult[]
+  next_cursor: string | null
+}
+
+export type MessageSearchParams = {
+  q: string
+  limit?: number
+  cursor?: string
 }
@@ -0,0 +1,10 @@
+export type ForwardMessageRequest = {
+  channel_id: string
+  content_text?: string
+}
+
+export type QuoteMessageRequest = {
+  channel_id?: string
+  content_text: string
+  parent_message_id?: string
+}
@@ -0,0 +1,12 @@
+export type CreatePollRequest = {
+  question: string
+  options: string[]
+  multiple_choice: boolean
+  anonymous: boolean
+  closes_at?: string
+  parent_message_id?: string
+}
+
+export type VotePollRequest = {
+  option_ids: string[]
+}
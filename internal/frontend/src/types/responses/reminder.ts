@@ -0,0 +1,12 @@
+import type { ReminderStatus } from '@/types/models/reminder'
+
+export type ReminderTimeRequest = { remind_at: string; in_minutes?: never } | { in_minutes: number; remind_at?: never }
+
+export type CreateReminderRequest = {
+  channel_id: string
+  message_id: string
+} & ReminderTimeRequest
+
+export type ReminderParams = {
+  status?: ReminderStatus
+}
@@ -0,0 +1,15 @@
+export type CreateScheduledMessageRequest = {
+  channel_id: string
+  content_text: string
+  scheduled_for: string
+  parent_message_id?: string
+}
+
+export type UpdateScheduledMessageRequest = {
+  content_text: string
+  scheduled_for: string
+}
+
+export type ScheduledMessageParams = {
+  channel_id?: string
+}
@@ -0,0 +1,10 @@
+export type SlashCommand = {
+  name: string
+  usage: string
+  description: string
+}
+
+export type SlashCommandResponse = {
+  command: string
+  text: string
+}
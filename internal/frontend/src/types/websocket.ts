@@ -1,3 +1,9 @@
+import type { Attachment } from '@/types/models/attachment'
+import type { LinkPreview } from '@/types/models/link_preview'
+import type { MessageReference } from '@/types/models/message_reference'
+import type { Poll } from '@/types/models/poll'
+import type { RichText } from '@/types/models/rich_text'
+
 export interface WebSocketMessage {
   type: string
   payload: unknown
@@ -6,6 +12,11 @@ export interface WebSocketMessage {
 export interface IncomingMessagePayload {
   id: string
   content: string
+  content_rich_text: RichText
+  is_formatted: boolean
+  attachments?: Attachment[]
+  poll?: Poll
+  reference?: MessageReference
   sender_user_id?: string
   integration_id?: string
   channel_id: string
@@ -52,6 +63,7 @@ export interface SendMessagePayload {
   content: string
   channel_id: string
   parent_message_id?: string
+  attachment_ids?: string[]
 }
 
 export interface SendReactionPayload {
@@ -59,3 +71,27 @@ export interface SendReactionPayload {
   channel_id: string
   reaction_type: string
 }
+
+export interface CommandResponsePayload {
+  channel_id: string
+  command: string
+  text: string
+}
+
+export interface MessageUnfurledPayload {
+  channel_id: string
+  message_id: string
+  link_previews: LinkPreview[]
+}
+
+export interface AttachmentProcessedPayload {
+  channel_id: string
+  message_id?: string
+  attachment: Attachment
+}
+
+export interface PollUpdatedPayload {
+  channel_id: string
+  message_id: string
+  poll: Poll
+}
@@ -143,6 +143,34 @@ func (s *GRPCServer) GetUsers(ctx context.Context, req *identitypb.GetUsersReque
 	return response, nil
 }
 
+func (s *GRPCServer) GetUsersByUsernames(ctx context.Context, req *identitypb.GetUsersByUsernamesRequest) (*identitypb.GetUsersResponse, error) {
+	logger := s.logger.WithMethod("GetUsersByUsernames")
+	logger.Info("Getting users by usernames")
+
+	cmd := domain.GetUsersByUsernamesCommand{
+		Usernames: req.Usernames,
+	}
+	users, err := s.identityService.GetUsersByUsernames(ctx, cmd)
+	if err != nil {
+		logger.Error("Error getting users", zap.Error(err))
+		return nil, fmt.Errorf("failed to get users: %v", err)
+	}
+
+	pbUsers := make([]*identitypb.User, len(users))
+	for i, user := range users {
+		pbUsers[i] = &identitypb.User{
+			Id:        user.ID.String(),
+			Username:  user.Username.String(),
+			Email:     user.Email.String(),
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+		}
+	}
+
+	logger.Info("Users retrieved", zap.Int("count", len(users)))
+	return &identitypb.GetUsersResponse{Users: pbUsers}, nil
+}
+
 func StartGRPCServer(
 	port string,
 	tokenVerifier auth.TokenVerifier,
@@ -230,6 +230,22 @@ func (s *IdentityService) GetUsers(ctx context.Context, cmd domain.GetUsersComma
 	return users, nil
 }
 
+func (s *IdentityService) GetUsersByUsernames(ctx context.Context, cmd domain.GetUsersByUsernamesCommand) ([]*domain.User, error) {
+	logger := s.logger.WithMethod("GetUsersByUsernames")
+	logger.Info("Getting users by usernames")
+	if len(cmd.Usernames) == 0 {
+		return []*domain.User{}, nil
+	}
+	users, err := s.repo.FindByUsernames(ctx, cmd.Usernames)
+	if err != nil {
+		logger.Error("Error retrieving users", zap.Error(err))
+		return nil, fmt.Errorf("error retrieving users: %w", err)
+	}
+
+	logger.Info("Users retrieved", zap.Int("count", len(users)))
+	return users, nil
+}
+
 func (s *IdentityService) UpdateUserProfile(ctx context.Context, cmd domain.UpdateUserProfileCommand) (*domain.User, error) {
 	logger := s.logger.WithMethod("UpdateUserProfile")
 	logger.Info("Updating user profile")
@@ -43,6 +43,14 @@ func (c GetUsersCommand) CommandName() string {
 	return "GetUsers"
 }
 
+type GetUsersByUsernamesCommand struct {
+	Usernames []string
+}
+
+func (c GetUsersByUsernamesCommand) CommandName() string {
+	return "GetUsersByUsernames"
+}
+
 type UpdateUserProfileCommand struct {
 	UserID       string
 	NewEmail     *string
@@ -361,6 +361,50 @@ func (x *GetUserByIDResponse) GetUser() *User {
 	return nil
 }
 
+type GetUsersByUsernamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Usernames     []string               `protobuf:"bytes,1,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersByUsernamesRequest) Reset() {
+	*x = GetUsersByUsernamesRequest{}
+	mi := &file_internal_identity_infrastructure_api_identity_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersByUsernamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersByUsernamesRequest) ProtoMessage() {}
+
+func (x *GetUsersByUsernamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_identity_infrastructure_api_identity_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersByUsernamesRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersByUsernamesRequest) Descriptor() ([]byte, []int) {
+	return file_internal_identity_infrastructure_api_identity_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUsersByUsernamesRequest) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
 var File_internal_identity_infrastructure_api_identity_proto protoreflect.FileDescriptor
 
 const file_internal_identity_infrastructure_api_identity_proto_rawDesc = "" +
@@ -384,11 +428,14 @@ const file_internal_identity_infrastructure_api_identity_proto_rawDesc = "" +
 	"\x10GetUsersResponse\x12'\n" +
 	"\x05users\x18\x01 \x03(\v2\x11.identity.v1.UserR\x05users\"<\n" +
 	"\x13GetUserByIDResponse\x12%\n" +
-	"\x04user\x18\x01 \x01(\v2\x11.identity.v1.UserR\x04user2\x84\x02\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.identity.v1.UserR\x04user\":\n" +
+	"\x1aGetUsersByUsernamesRequest\x12\x1c\n" +
+	"\tusernames\x18\x01 \x03(\tR\tusernames2\xe3\x02\n" +
 	"\x0fIdentityService\x12V\n" +
 	"\rValidateToken\x12!.identity.v1.ValidateTokenRequest\x1a\".identity.v1.ValidateTokenResponse\x12P\n" +
 	"\vGetUserByID\x12\x1f.identity.v1.GetUserByIDRequest\x1a .identity.v1.GetUserByIDResponse\x12G\n" +
-	"\bGetUsers\x12\x1c.identity.v1.GetUsersRequest\x1a\x1d.identity.v1.GetUsersResponseBSZQgithub.com/m1thrandir225/meridian/internal/identity/infrastructure/api;identitypbb\x06proto3"
+	"\bGetUsers\x12\x1c.identity.v1.GetUsersRequest\x1a\x1d.identity.v1.GetUsersResponse\x12]\n" +
+	"\x13GetUsersByUsernames\x12'.identity.v1.GetUsersByUsernamesRequest\x1a\x1d.identity.v1.GetUsersResponseBSZQgithub.com/m1thrandir225/meridian/internal/identity/infrastructure/api;identitypbb\x06proto3"
 
 var (
 	file_internal_identity_infrastructure_api_identity_proto_rawDescOnce sync.Once
@@ -402,15 +449,16 @@ func file_internal_identity_infrastructure_api_identity_proto_rawDescGZIP() []by
 	return file_internal_identity_infrastructure_api_identity_proto_rawDescData
 }
 
-var file_internal_identity_infrastructure_api_identity_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_internal_identity_infrastructure_api_identity_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_internal_identity_infrastructure_api_identity_proto_goTypes = []any{
-	(*ValidateTokenRequest)(nil),  // 0: identity.v1.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil), // 1: identity.v1.ValidateTokenResponse
-	(*GetUserByIDRequest)(nil),    // 2: identity.v1.GetUserByIDRequest
-	(*GetUsersRequest)(nil),       // 3: identity.v1.GetUsersRequest
-	(*User)(nil),                  // 4: identity.v1.User
-	(*GetUsersResponse)(nil),      // 5: identity.v1.GetUsersResponse
-	(*GetUserByIDResponse)(nil),   // 6: identity.v1.GetUserByIDResponse
+	(*ValidateTokenRequest)(nil),       // 0: identity.v1.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),      // 1: identity.v1.ValidateTokenResponse
+	(*GetUserByIDRequest)(nil),         // 2: identity.v1.GetUserByIDRequest
+	(*GetUsersRequest)(nil),            // 3: identity.v1.GetUsersRequest
+	(*User)(nil),                       // 4: identity.v1.User
+	(*GetUsersResponse)(nil),           // 5: identity.v1.GetUsersResponse
+	(*GetUserByIDResponse)(nil),        // 6: identity.v1.GetUserByIDResponse
+	(*GetUsersByUsernamesRequest)(nil), // 7: identity.v1.GetUsersByUsernamesRequest
 }
 var file_internal_identity_infrastructure_api_identity_proto_depIdxs = []int32{
 	4, // 0: identity.v1.GetUsersResponse.users:type_name -> identity.v1.User
@@ -418,11 +466,13 @@ var file_internal_identity_infrastructure_api_identity_proto_depIdxs = []int32{
 	0, // 2: identity.v1.IdentityService.ValidateToken:input_type -> identity.v1.ValidateTokenRequest
 	2, // 3: identity.v1.IdentityService.GetUserByID:input_type -> identity.v1.GetUserByIDRequest
 	3, // 4: identity.v1.IdentityService.GetUsers:input_type -> identity.v1.GetUsersRequest
-	1, // 5: identity.v1.IdentityService.ValidateToken:output_type -> identity.v1.ValidateTokenResponse
-	6, // 6: identity.v1.IdentityService.GetUserByID:output_type -> identity.v1.GetUserByIDResponse
-	5, // 7: identity.v1.IdentityService.GetUsers:output_type -> identity.v1.GetUsersResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
+	7, // 5: identity.v1.IdentityService.GetUsersByUsernames:input_type -> identity.v1.GetUsersByUsernamesRequest
+	1, // 6: identity.v1.IdentityService.ValidateToken:output_type -> identity.v1.ValidateTokenResponse
+	6, // 7: identity.v1.IdentityService.GetUserByID:output_type -> identity.v1.GetUserByIDResponse
+	5, // 8: identity.v1.IdentityService.GetUsers:output_type -> identity.v1.GetUsersResponse
+	5, // 9: identity.v1.IdentityService.GetUsersByUsernames:output_type -> identity.v1.GetUsersResponse
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
@@ -439,7 +489,7 @@ func file_internal_identity_infrastructure_api_identity_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_identity_infrastructure_api_identity_proto_rawDesc), len(file_internal_identity_infrastructure_api_identity_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -176,6 +177,46 @@ func (r *PostgresUserRepository) FindByUsername(ctx context.Context, username st
 	return r.findByField(ctx, "username", username)
 }
 
+// FindByUsernames looks the usernames up case-insensitively
+func (r *PostgresUserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error) {
+	if len(usernames) == 0 {
+		return nil, nil
+	}
+
+	lowered := make([]string, len(usernames))
+	for i, username := range usernames {
+		lowered[i] = strings.ToLower(username)
+	}
+
+	query := `
+	SELECT id, username, first_name, last_name, email, password, version, registartion_time
+	FROM users
+	WHERE lower(username) = ANY($1)
+	ORDER BY id
+	`
+
+	rows, err := r.db.Query(ctx, query, lowered)
+	if err != nil {
+		return nil, fmt.Errorf("error querying users by usernames: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := r.scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
 func (r *PostgresUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	return r.findByField(ctx, "email", email)
 }
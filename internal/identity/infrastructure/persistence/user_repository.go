@@ -12,6 +12,7 @@ type UserRepository interface {
 	FindById(ctx context.Context, id uuid.UUID) (*domain.User, error)
 	FindByIds(ctx context.Context, ids []uuid.UUID) ([]*domain.User, error)
 	FindByUsername(ctx context.Context, username string) (*domain.User, error)
+	FindByUsernames(ctx context.Context, usernames []string) ([]*domain.User, error)
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	FindByRefreshTokenHash(ctx context.Context, hash string) (*domain.User, error)
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/application/services"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/kafka"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// UserDeletedEvent is the payload published by the identity service when an account is deleted
+type UserDeletedEvent struct {
+	ID     string `json:"ID"`
+	Name   string `json:"Name"`
+	AggrID string `json:"AggrID"`
+	UserID string `json:"user_id"`
+}
+
+// MessagingEventHandler consumes events from other services that affect channels
+type MessagingEventHandler struct {
+	channelService *services.ChannelService
+	logger         *logging.Logger
+}
+
+func NewMessagingEventHandler(channelService *services.ChannelService, logger *logging.Logger) *MessagingEventHandler {
+	return &MessagingEventHandler{
+		channelService: channelService,
+		logger:         logger,
+	}
+}
+
+func (h *MessagingEventHandler) HandleEvent(ctx context.Context, event kafka.Event) error {
+	logger := h.logger.WithMethod("HandleEvent")
+
+	var baseEvent struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(event.Data, &baseEvent); err != nil {
+		logger.Error("Failed to parse event name", zap.Error(err))
+		return err
+	}
+
+	switch baseEvent.Name {
+	case "UserDeleted":
+		return h.handleUserDeleted(ctx, event)
+	default:
+		return nil
+	}
+}
+
+// handleUserDeleted removes the deleted user from their channels
+func (h *MessagingEventHandler) handleUserDeleted(ctx context.Context, event kafka.Event) error {
+	logger := h.logger.WithMethod("handleUserDeleted")
+	logger.Info("Processing user deleted event", zap.String("key", event.Key), zap.Int64("offset", event.Offset))
+
+	var userEvent UserDeletedEvent
+	if err := json.Unmarshal(event.Data, &userEvent); err != nil {
+		logger.Error("Failed to unmarshal user deleted event", zap.Error(err))
+		return err
+	}
+
+	userID, err := uuid.Parse(userEvent.UserID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		return err
+	}
+
+	return h.channelService.HandleUserDeleted(ctx, domain.HandleUserDeletedCommand{
+		UserID: userID,
+	})
+}
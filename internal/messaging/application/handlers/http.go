@@ -3,7 +3,10 @@ package handlers
 import (
 	"errors"
 	"fmt"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -11,32 +14,60 @@ import (
 	"github.com/m1thrandir225/meridian/internal/messaging/application/services"
 	"github.com/m1thrandir225/meridian/internal/messaging/domain"
 	"github.com/m1thrandir225/meridian/pkg/cache"
+	"github.com/m1thrandir225/meridian/pkg/common"
 	"github.com/m1thrandir225/meridian/pkg/logging"
 	"go.uber.org/zap"
 )
 
+// maxMultipartOverhead is the room left for multipart headers on top of the file in an upload
+const maxMultipartOverhead = 1 << 20
+
 var (
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrMultipleCursors = errors.New("only one of before, after or around may be set")
+	ErrReminderTime    = errors.New("exactly one of remind_at or in_minutes must be set")
+	ErrEmptyMessage    = errors.New("a message needs content or attachments")
 )
 
 type HTTPHandler struct {
-	channelService *services.ChannelService
-	messageService *services.MessageService
-	cache          *cache.RedisCache
-	logger         *logging.Logger
+	channelService     *services.ChannelService
+	messageService     *services.MessageService
+	bookmarkService    *services.BookmarkService
+	scheduleService    *services.ScheduledMessageService
+	reminderService    *services.ReminderService
+	attachmentService  *services.AttachmentService
+	customEmojiService *services.CustomEmojiService
+	slashCommands      *services.SlashCommandRegistry
+	wsHandler          *WebSocketHandler
+	cache              *cache.RedisCache
+	logger             *logging.Logger
 }
 
 func NewHttpHandler(
 	channelService *services.ChannelService,
 	messageService *services.MessageService,
+	bookmarkService *services.BookmarkService,
+	scheduleService *services.ScheduledMessageService,
+	reminderService *services.ReminderService,
+	attachmentService *services.AttachmentService,
+	customEmojiService *services.CustomEmojiService,
+	slashCommands *services.SlashCommandRegistry,
+	wsHandler *WebSocketHandler,
 	cache *cache.RedisCache,
 	logger *logging.Logger,
 ) *HTTPHandler {
 	return &HTTPHandler{
-		channelService: channelService,
-		messageService: messageService,
-		cache:          cache,
-		logger:         logger,
+		channelService:     channelService,
+		messageService:     messageService,
+		bookmarkService:    bookmarkService,
+		scheduleService:    scheduleService,
+		reminderService:    reminderService,
+		attachmentService:  attachmentService,
+		customEmojiService: customEmojiService,
+		slashCommands:      slashCommands,
+		wsHandler:          wsHandler,
+		cache:              cache,
+		logger:             logger,
 	}
 }
 
@@ -82,11 +113,92 @@ func (h *HTTPHandler) handleGetUserChannels(ctx *gin.Context) {
 		return
 	}
 
+	unreadCounts, err := h.channelService.HandleGetUnreadCounts(ctx, domain.GetUnreadCountsCommand{UserID: userID})
+	if err != nil {
+		logger.Error("Failed to get unread counts", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	for i, channel := range channels {
+		counts := unreadCounts[channel.ID]
+		channelsDTO[i].UnreadCount = counts.Unread
+		channelsDTO[i].MentionCount = counts.Mentions
+	}
+
 	//h.cache.Set(ctx.Request.Context(), cacheKey, channelsDTO, 5*time.Minute)
 
 	ctx.JSON(http.StatusOK, channelsDTO)
 }
 
+// PUT /api/v1/channels/:channelId/read
+func (h *HTTPHandler) handleMarkRead(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleMarkRead")
+	logger.Info("Marking channel as read")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req MarkReadRequest
+	var uriReq ChannelIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(req.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	member, advanced, err := h.channelService.HandleMarkRead(ctx, domain.MarkReadCommand{
+		ChannelID: channelId,
+		UserID:    userId,
+		MessageID: messageId,
+	})
+	if err != nil {
+		logger.Error("Failed to mark channel as read", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if advanced && h.wsHandler != nil {
+		go h.wsHandler.BroadcastReadMarker(channelId, member)
+	}
+
+	logger.Info("Channel marked as read", zap.String("channel_id", channelId.String()))
+	ctx.JSON(http.StatusOK, domain.ToReadMarkerDTO(channelId.String(), member))
+}
+
 // POST /api/v1/channels/
 func (h *HTTPHandler) handleCreateChannel(ctx *gin.Context) {
 	logger := h.logger.WithMethod("handleCreateChannel")
@@ -110,10 +222,18 @@ func (h *HTTPHandler) handleCreateChannel(ctx *gin.Context) {
 		return
 	}
 
+	visibility, err := domain.ParseChannelVisibility(req.Visibility)
+	if err != nil {
+		logger.Error("Failed to parse channel visibility", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	channel, err := h.channelService.HandleCreateChannel(ctx, domain.CreateChannelCommand{
 		CreatorUserID: creatorUserID,
 		Name:          req.Name,
 		Topic:         req.Topic,
+		Visibility:    visibility,
 	})
 	if err != nil {
 		logger.Error("Failed to create channel", zap.Error(err))
@@ -134,6 +254,124 @@ func (h *HTTPHandler) handleCreateChannel(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, channelDTO)
 }
 
+// GET /api/v1/channels/directory
+func (h *HTTPHandler) handleGetChannelDirectory(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetChannelDirectory")
+	logger.Info("Getting channel directory")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req ChannelDirectoryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query := domain.ChannelDirectoryQuery{
+		Search: strings.TrimSpace(req.Search),
+		Limit:  req.Limit,
+	}
+	if req.Before != "" {
+		before, err := uuid.Parse(req.Before)
+		if err != nil {
+			logger.Error("Failed to parse cursor", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		query.Before = &before
+	}
+
+	page, err := h.channelService.HandleListChannelDirectory(ctx, domain.ListChannelDirectoryCommand{
+		Query: query,
+	})
+	if err != nil {
+		logger.Error("Failed to get channel directory", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Channel directory retrieved", zap.Int("count", len(page.Channels)))
+	ctx.JSON(http.StatusOK, domain.ToChannelDirectoryDTO(page))
+}
+
+// POST /api/v1/dms
+func (h *HTTPHandler) handleOpenDirectChannel(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleOpenDirectChannel")
+	logger.Info("Opening direct channel")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req OpenDirectChannelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	participantIDs := make([]uuid.UUID, 0, len(req.UserIDs))
+	for _, id := range req.UserIDs {
+		participantID, err := uuid.Parse(id)
+		if err != nil {
+			logger.Error("Failed to parse participant ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		participantIDs = append(participantIDs, participantID)
+	}
+
+	channel, created, err := h.channelService.HandleOpenDirectChannel(ctx, domain.OpenDirectChannelCommand{
+		UserID:         userId,
+		ParticipantIDs: participantIDs,
+	})
+	if err != nil {
+		logger.Error("Failed to open direct channel", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		for _, member := range channel.Members {
+			cacheKey := fmt.Sprintf("user_channels:%s", member.GetId().String())
+			h.cache.Delete(ctx.Request.Context(), cacheKey)
+		}
+	}
+
+	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
+	if err != nil {
+		logger.Error("Failed to return channel DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(status, channelDTO)
+}
+
 // GET /api/v1/channels/:channelId
 func (h *HTTPHandler) handleGetChannel(ctx *gin.Context) {
 	logger := h.logger.WithMethod("handleGetChannel")
@@ -155,6 +393,22 @@ func (h *HTTPHandler) handleGetChannel(ctx *gin.Context) {
 	cacheKey := fmt.Sprintf("channel:%s", channelId.String())
 	var cachedChannel domain.ChannelDTO
 	if hit, _ := h.cache.GetWithMetrics(ctx.Request.Context(), cacheKey, &cachedChannel); hit {
+		// The cached copy is shared by all users, so private channels still need a membership check
+		readable := cachedChannel.Kind == string(domain.ChannelKindChannel) && cachedChannel.Visibility == string(domain.ChannelVisibilityPublic)
+		if !readable {
+			isMember, err := h.channelService.IsChannelMember(ctx, channelId, viewerID(ctx))
+			if err != nil {
+				logger.Error("Failed to check channel membership", zap.Error(err))
+				ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+			readable = isMember
+		}
+		if !readable {
+			ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("channel with ID %s: %w", channelId, common.ErrNotFound)))
+			return
+		}
+
 		logger.Info("Channel retrieved from cache", zap.String("channel_id", channelId.String()))
 		ctx.JSON(http.StatusOK, cachedChannel)
 		return
@@ -162,6 +416,7 @@ func (h *HTTPHandler) handleGetChannel(ctx *gin.Context) {
 
 	channel, err := h.channelService.HandleGetChannel(ctx, domain.GetChannelCommand{
 		ChannelID: channelId,
+		UserID:    viewerID(ctx),
 	})
 	if err != nil {
 		logger.Error("Failed to get channel", zap.Error(err))
@@ -181,11 +436,23 @@ func (h *HTTPHandler) handleGetChannel(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, channelDTO)
 }
 
+// invalidateChannelCache drops the cached channel details so the next read sees the change
+func (h *HTTPHandler) invalidateChannelCache(ctx *gin.Context, channelID uuid.UUID) {
+	cacheKey := fmt.Sprintf("channel:%s", channelID.String())
+	h.cache.Delete(ctx.Request.Context(), cacheKey)
+}
+
 // POST /api/v1/channels/:channelId/bots
 func (h *HTTPHandler) handleAddBotToChannel(ctx *gin.Context) {
 	logger := h.logger.WithMethod("handleAddBotToChannel")
 	logger.Info("Adding bot to channel")
 
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
 	var channelIdUri ChannelIDUri
 	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
@@ -215,15 +482,29 @@ func (h *HTTPHandler) handleAddBotToChannel(ctx *gin.Context) {
 		return
 	}
 
+	requestorId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	channel, err := h.channelService.HandleAddBotToChannel(ctx, domain.AddBotToChannelCommand{
 		ChannelID:     channelId,
 		IntegrationID: integrationId,
+		RequestorID:   requestorId,
 	})
 	if err != nil {
 		logger.Error("Failed to add bot to channel", zap.Error(err))
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	h.invalidateChannelCache(ctx, channel.ID)
+
 	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
 	if err != nil {
 		logger.Error("Failed to return channel DTO", zap.Error(err))
@@ -235,10 +516,10 @@ func (h *HTTPHandler) handleAddBotToChannel(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, channelDTO)
 }
 
-// PUT /api/v1/channels/:channelId/archive
-func (h *HTTPHandler) handleArchiveChannel(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleArchiveChannel")
-	logger.Info("Archiving channel")
+// DELETE /api/v1/channels/:channelId/bots/:integrationId
+func (h *HTTPHandler) handleRemoveBotFromChannel(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleRemoveBotFromChannel")
+	logger.Info("Removing bot from channel")
 
 	userID := ctx.GetHeader("X-User-ID")
 	if userID == "" {
@@ -246,8 +527,8 @@ func (h *HTTPHandler) handleArchiveChannel(ctx *gin.Context) {
 		return
 	}
 
-	var req ChannelIDUri
-	if err := ctx.ShouldBindUri(&req); err != nil {
+	var uriReq ChannelBotUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -260,27 +541,44 @@ func (h *HTTPHandler) handleArchiveChannel(ctx *gin.Context) {
 		return
 	}
 
-	channelId, err := uuid.Parse(req.ChannelID)
+	channelId, err := uuid.Parse(uriReq.ChannelID)
 	if err != nil {
 		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.ArchiveChannelCommand{
-		ChannelID: channelId,
-		UserID:    userUUID,
+	integrationId, err := uuid.Parse(uriReq.IntegrationID)
+	if err != nil {
+		logger.Error("Failed to parse integration ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	channel, err := h.channelService.HandleArchiveChannel(ctx, cmd)
+	channel, err := h.channelService.HandleRemoveBotFromChannel(ctx, domain.RemoveBotFromChannelCommand{
+		ChannelID:     channelId,
+		IntegrationID: integrationId,
+		RequestorID:   userUUID,
+	})
 	if err != nil {
-		logger.Error("Failed to archive channel", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		logger.Error("Failed to remove bot from channel", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) || errors.Is(err, domain.ErrDirectChannel) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cacheKey := fmt.Sprintf("user_channels:%s", userUUID.String())
-	h.cache.Delete(ctx.Request.Context(), cacheKey)
+	h.invalidateChannelCache(ctx, channelId)
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMemberLeft(channelId, integrationId)
+	}
 
 	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
 	if err != nil {
@@ -289,14 +587,14 @@ func (h *HTTPHandler) handleArchiveChannel(ctx *gin.Context) {
 		return
 	}
 
-	logger.Info("Channel archived", zap.String("channel_id", channel.ID.String()))
+	logger.Info("Bot removed from channel", zap.String("channel_id", channel.ID.String()))
 	ctx.JSON(http.StatusOK, channelDTO)
 }
 
-// PUT /api/v1/channels/:channelId/unarchive
-func (h *HTTPHandler) handleUnarchiveChannel(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleUnarchiveChannel")
-	logger.Info("Unarchiving channel")
+// POST /api/v1/channels/:channelId/leave
+func (h *HTTPHandler) handleLeaveChannel(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleLeaveChannel")
+	logger.Info("Leaving channel")
 
 	userID := ctx.GetHeader("X-User-ID")
 	if userID == "" {
@@ -304,8 +602,8 @@ func (h *HTTPHandler) handleUnarchiveChannel(ctx *gin.Context) {
 		return
 	}
 
-	var req ChannelIDUri
-	if err := ctx.ShouldBindUri(&req); err != nil {
+	var uriReq ChannelIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
@@ -318,65 +616,76 @@ func (h *HTTPHandler) handleUnarchiveChannel(ctx *gin.Context) {
 		return
 	}
 
-	channelId, err := uuid.Parse(req.ChannelID)
+	channelId, err := uuid.Parse(uriReq.ChannelID)
 	if err != nil {
 		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.UnarchiveChannelCommand{
+	_, err = h.channelService.HandleLeaveChannel(ctx, domain.LeaveChannelCommand{
 		ChannelID: channelId,
 		UserID:    userUUID,
-	}
-
-	channel, err := h.channelService.HandleUnarchiveChannel(ctx, cmd)
+	})
 	if err != nil {
-		logger.Error("Failed to unarchive channel", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		logger.Error("Failed to leave channel", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) || errors.Is(err, domain.ErrDirectChannel) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
 	cacheKey := fmt.Sprintf("user_channels:%s", userUUID.String())
 	h.cache.Delete(ctx.Request.Context(), cacheKey)
 
-	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
-	if err != nil {
-		logger.Error("Failed to return channel DTO", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	h.invalidateChannelCache(ctx, channelId)
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMemberLeft(channelId, userUUID)
 	}
 
-	logger.Info("Channel unarchived", zap.String("channel_id", channel.ID.String()))
-	ctx.JSON(http.StatusOK, channelDTO)
+	logger.Info("Left channel", zap.String("channel_id", channelId.String()))
+	ctx.Status(http.StatusOK)
 }
 
-// POST /api/v1/channels/:channelId/join
-func (h *HTTPHandler) handleJoinChannel(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleJoinChannel")
-	logger.Info("Joining channel")
+// PUT /api/v1/channels/:channelId/topic
+func (h *HTTPHandler) handleSetChannelTopic(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleSetChannelTopic")
+	logger.Info("Setting channel topic")
 
-	var req JoinChannelRequest
-	var uriReq ChannelIDUri
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
 
+	var req SetChannelTopicRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("Failed to bind JSON", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	var uriReq ChannelIDUri
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	userId, err := uuid.Parse(req.UserID)
+	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		logger.Error("Failed to parse user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+
 	channelId, err := uuid.Parse(uriReq.ChannelID)
 	if err != nil {
 		logger.Error("Failed to parse channel ID", zap.Error(err))
@@ -384,16 +693,31 @@ func (h *HTTPHandler) handleJoinChannel(ctx *gin.Context) {
 		return
 	}
 
-	channel, err := h.channelService.HandleJoinChannel(ctx, domain.JoinChannelCommand{
+	channel, err := h.channelService.HandleSetChannelTopic(ctx, domain.SetChannelTopicCommand{
 		ChannelID: channelId,
-		UserID:    userId,
+		UserID:    userUUID,
+		Topic:     req.Topic,
 	})
 	if err != nil {
-		logger.Error("Failed to join channel", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		logger.Error("Failed to set channel topic", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) || errors.Is(err, domain.ErrDirectChannel) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	h.invalidateChannelCache(ctx, channel.ID)
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastChannelUpdated(channel, userUUID)
+	}
+
 	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
 	if err != nil {
 		logger.Error("Failed to return channel DTO", zap.Error(err))
@@ -401,327 +725,2894 @@ func (h *HTTPHandler) handleJoinChannel(ctx *gin.Context) {
 		return
 	}
 
-	cacheKey := fmt.Sprintf("user_channels:%s", userId.String())
-	h.cache.Delete(ctx.Request.Context(), cacheKey)
-
-	logger.Info("Channel joined", zap.String("channel_id", channel.ID.String()))
+	logger.Info("Channel topic set", zap.String("channel_id", channel.ID.String()))
 	ctx.JSON(http.StatusOK, channelDTO)
 }
 
-// POST /api/v1/channels/:channelId/messages
-// FIXME: redundant, should be removed
-func (h *HTTPHandler) handleSendMessage(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleSendMessage")
-	logger.Info("Sending message")
+// PUT /api/v1/channels/:channelId/name
+func (h *HTTPHandler) handleRenameChannel(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleRenameChannel")
+	logger.Info("Renaming channel")
 
 	userID := ctx.GetHeader("X-User-ID")
 	if userID == "" {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
 		return
 	}
-	var req SendMessageRequest
-	var uriReq ChannelIDUri
 
+	var req RenameChannelRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("Failed to bind JSON", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	var uriReq ChannelIDUri
 	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	channelId, err := uuid.Parse(uriReq.ChannelID)
+	userUUID, err := uuid.Parse(userID)
 	if err != nil {
-		logger.Error("Failed to parse channel ID", zap.Error(err))
+		logger.Error("Failed to parse user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	senderID, err := uuid.Parse(userID)
+	channelId, err := uuid.Parse(uriReq.ChannelID)
 	if err != nil {
-		logger.Error("Failed to parse sender user ID", zap.Error(err))
+		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	var parentMessageID *uuid.UUID
-	if req.ParentMessageID != nil {
-		parsed, err := uuid.Parse(*req.ParentMessageID)
-		if err != nil {
-			logger.Error("Failed to parse parent message ID", zap.Error(err))
-			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	channel, err := h.channelService.HandleRenameChannel(ctx, domain.RenameChannelCommand{
+		ChannelID: channelId,
+		UserID:    userUUID,
+		Name:      req.Name,
+	})
+	if err != nil {
+		logger.Error("Failed to rename channel", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
-		parentMessageID = &parsed
+		if errors.Is(err, domain.ErrPermissionDenied) || errors.Is(err, domain.ErrDirectChannel) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	content := domain.NewMessageContent(req.ContentText) //TODO: add content type
+	h.invalidateChannelCache(ctx, channel.ID)
 
-	message, err := h.messageService.HandleMessageSent(ctx, domain.SendMessageCommand{
-		ChannelID:       channelId,
-		SenderUserID:    senderID,
-		ParentMessageID: parentMessageID,
-		Content:         content,
-	})
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastChannelUpdated(channel, userUUID)
 	}
 
-	messageDTO, err := h.messageService.ToMessageDTO(ctx, message)
+	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
 	if err != nil {
-		logger.Error("Failed to send message", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		logger.Error("Failed to return channel DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	logger.Info("Message sent", zap.String("message_id", message.GetId().String()))
-	ctx.JSON(http.StatusCreated, messageDTO)
+	logger.Info("Channel renamed", zap.String("channel_id", channel.ID.String()))
+	ctx.JSON(http.StatusOK, channelDTO)
 }
 
-// GET /api/v1/channels/:channelId/messages
-func (h *HTTPHandler) handleGetMessages(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleGetMessages")
-	logger.Info("Getting messages")
+// PUT /api/v1/channels/:channelId/archive
+func (h *HTTPHandler) handleArchiveChannel(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleArchiveChannel")
+	logger.Info("Archiving channel")
 
-	var uriReq ChannelIDUri
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
 
-	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+	var req ChannelIDUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	channelId, err := uuid.Parse(uriReq.ChannelID)
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(req.ChannelID)
 	if err != nil {
 		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.ListMessagesForChannelCommand{
+	cmd := domain.ArchiveChannelCommand{
 		ChannelID: channelId,
-		Limit:     50,
-		Offset:    0,
+		UserID:    userUUID,
 	}
 
-	messages, err := h.messageService.HandleListMessages(ctx, cmd)
+	channel, err := h.channelService.HandleArchiveChannel(ctx, cmd)
 	if err != nil {
-		logger.Error("Failed to list messages", zap.Error(err))
+		logger.Error("Failed to archive channel", zap.Error(err))
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	messagesDTO, err := h.messageService.ToMessageDTOs(ctx, messages)
+	cacheKey := fmt.Sprintf("user_channels:%s", userUUID.String())
+	h.cache.Delete(ctx.Request.Context(), cacheKey)
+
+	h.invalidateChannelCache(ctx, channel.ID)
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastChannelUpdated(channel, userUUID)
+	}
+
+	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
 	if err != nil {
-		logger.Error("Failed to convert messages to DTOs", zap.Error(err))
+		logger.Error("Failed to return channel DTO", zap.Error(err))
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	logger.Info("Messages retrieved", zap.String("channel_id", channelId.String()))
-	ctx.JSON(http.StatusOK, messagesDTO)
+	logger.Info("Channel archived", zap.String("channel_id", channel.ID.String()))
+	ctx.JSON(http.StatusOK, channelDTO)
 }
 
-// POST /api/v1/channels/:channelId/messages/:messageId/reactions
-func (h *HTTPHandler) handleAddReaction(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleAddReaction")
-	logger.Info("Adding reaction")
+// POST /api/v1/channels/:channelId/members/:userId/promote
+func (h *HTTPHandler) handlePromoteMember(ctx *gin.Context) {
+	h.changeMemberRole(ctx, true)
+}
 
-	var req AddReactionRequest
-	var channelIdUri ChannelIDUri
-	var messageIdUri MessageIDUri
+// POST /api/v1/channels/:channelId/members/:userId/demote
+func (h *HTTPHandler) handleDemoteMember(ctx *gin.Context) {
+	h.changeMemberRole(ctx, false)
+}
 
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		logger.Error("Failed to bind JSON", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+func (h *HTTPHandler) changeMemberRole(ctx *gin.Context, promote bool) {
+	logger := h.logger.WithMethod("changeMemberRole")
+	logger.Info("Changing member role", zap.Bool("promote", promote))
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
 		return
 	}
-	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
-		logger.Error("Failed to bind URI", zap.Error(err))
+
+	var req ChangeMemberRoleRequest
+	var uriReq MemberUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	userId, err := uuid.Parse(req.UserID)
+
+	requestorId, err := uuid.Parse(userID)
 	if err != nil {
 		logger.Error("Failed to parse user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	channelId, err := uuid.Parse(uriReq.ChannelID)
 	if err != nil {
 		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	targetUserId, err := uuid.Parse(uriReq.UserID)
 	if err != nil {
-		logger.Error("Failed to parse message ID", zap.Error(err))
+		logger.Error("Failed to parse target user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.AddReactionCommand{
-		ChannelID:    channelId,
-		MessageID:    messageId,
-		UserID:       userId,
-		ReactionType: req.ReactionType,
+	role, err := domain.ParseMemberRole(req.Role)
+	if err != nil {
+		logger.Error("Failed to parse role", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	reaction, err := h.messageService.HandleAddReaction(ctx, cmd)
+	member, err := h.channelService.HandleChangeMemberRole(ctx, domain.ChangeMemberRoleCommand{
+		ChannelID:    channelId,
+		RequestorID:  requestorId,
+		TargetUserID: targetUserId,
+		Role:         role,
+		Promote:      promote,
+	})
 	if err != nil {
-		logger.Error("Failed to add reaction", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		logger.Error("Failed to change member role", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	logger.Info("Reaction added", zap.String("reaction_id", reaction.GetId().String()))
-	//TODO: return the reaction as a DTO? or return the message with the reaction?
-	ctx.JSON(http.StatusCreated, reaction)
+	h.invalidateChannelCache(ctx, channelId)
+
+	logger.Info("Member role changed", zap.String("channel_id", channelId.String()), zap.String("role", string(member.GetRole())))
+	ctx.JSON(http.StatusOK, domain.ToMemberDTO(channelId.String(), member))
 }
 
-// DELETE /api/v1/channels/:channelId/messages/:messageId/reactions
-func (h *HTTPHandler) handleRemoveReaction(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleRemoveReaction")
-	logger.Info("Removing reaction")
+// POST /api/v1/channels/:channelId/transfer-ownership
+func (h *HTTPHandler) handleTransferOwnership(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleTransferOwnership")
+	logger.Info("Transferring channel ownership")
 
-	var req RemoveReactionRequest
-	var channelIdUri ChannelIDUri
-	var messageIdUri MessageIDUri
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req TransferOwnershipRequest
+	var uriReq ChannelIDUri
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		logger.Error("Failed to bind JSON", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
-		logger.Error("Failed to bind URI", zap.Error(err))
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
-	}
-	userId, err := uuid.Parse(req.UserID)
+	requestorId, err := uuid.Parse(userID)
 	if err != nil {
 		logger.Error("Failed to parse user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	channelId, err := uuid.Parse(uriReq.ChannelID)
 	if err != nil {
 		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	newOwnerId, err := uuid.Parse(req.UserID)
 	if err != nil {
-		logger.Error("Failed to parse message ID", zap.Error(err))
+		logger.Error("Failed to parse new owner ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.RemoveReactionCommand{
-		ChannelID:    channelId,
-		MessageID:    messageId,
-		UserID:       userId,
-		ReactionType: req.ReactionType,
+	channel, err := h.channelService.HandleTransferOwnership(ctx, domain.TransferOwnershipCommand{
+		ChannelID:   channelId,
+		RequestorID: requestorId,
+		NewOwnerID:  newOwnerId,
+	})
+	if err != nil {
+		logger.Error("Failed to transfer channel ownership", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
 
-	_, err = h.messageService.HandleRemoveReaction(ctx, cmd)
+	h.invalidateChannelCache(ctx, channel.ID)
+
+	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
 	if err != nil {
-		logger.Error("Failed to remove reaction", zap.Error(err))
+		logger.Error("Failed to return channel DTO", zap.Error(err))
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	logger.Info("Reaction removed", zap.String("reaction_id", cmd.MessageID.String()))
-	ctx.Status(http.StatusOK)
+
+	logger.Info("Channel ownership transferred", zap.String("channel_id", channel.ID.String()))
+	ctx.JSON(http.StatusOK, channelDTO)
 }
 
-// POST /api/v1/channels/:channelId/invites
-func (h *HTTPHandler) handleCreateChannelInvite(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleCreateChannelInvite")
-	logger.Info("Creating channel invite")
+// POST /api/v1/channels/:channelId/members/:userId/kick
+func (h *HTTPHandler) handleKickMember(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleKickMember")
+	logger.Info("Kicking member")
 
-	var channelIdUri ChannelIDUri
-	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq MemberUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	requestorId, err := uuid.Parse(userID)
 	if err != nil {
-		logger.Error("Failed to parse channel ID", zap.Error(err))
+		logger.Error("Failed to parse user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	var req CreateChannelInviteRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		logger.Error("Failed to bind JSON", zap.Error(err))
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	creatorID := ctx.GetHeader("X-User-ID")
-	if creatorID == "" {
-		logger.Error("Unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+	targetUserId, err := uuid.Parse(uriReq.UserID)
+	if err != nil {
+		logger.Error("Failed to parse target user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	creatorUserID, err := uuid.Parse(creatorID)
+	err = h.channelService.HandleKickMember(ctx, domain.KickMemberCommand{
+		ChannelID:    channelId,
+		RequestorID:  requestorId,
+		TargetUserID: targetUserId,
+	})
 	if err != nil {
-		logger.Error("Failed to parse creator user ID", zap.Error(err))
+		logger.Error("Failed to kick member", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	var maxUses *int
-	if req.MaxUses != 0 {
-		maxUses = &req.MaxUses
-	}
+	cacheKey := fmt.Sprintf("user_channels:%s", targetUserId.String())
+	h.cache.Delete(ctx.Request.Context(), cacheKey)
 
-	cmd := domain.CreateChannelInviteCommand{
-		ChannelID:       channelId,
-		CreatedByUserID: creatorUserID,
-		ExpiresAt:       req.ExpiresAt,
-		MaxUses:         maxUses,
-	}
+	h.invalidateChannelCache(ctx, channelId)
 
-	_, invite, err := h.channelService.HandleCreateChannelInvite(ctx, cmd)
-	if err != nil {
-		logger.Error("Failed to create channel invite", zap.Error(err))
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMemberRemoved(channelId, targetUserId, requestorId, "kicked", "")
 	}
 
-	inviteDTO := domain.ToChannelInviteDTO(invite)
-
-	logger.Info("Channel invite created", zap.String("invite_id", invite.ID.String()))
-	ctx.JSON(http.StatusCreated, inviteDTO)
+	logger.Info("Member kicked", zap.String("channel_id", channelId.String()))
+	ctx.Status(http.StatusOK)
 }
 
-// GET /api/v1/channels/:channelId/invites
-func (h *HTTPHandler) handleGetChannelInvites(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleGetChannelInvites")
-	logger.Info("Getting channel invites")
+// POST /api/v1/channels/:channelId/bans
+func (h *HTTPHandler) handleBanMember(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleBanMember")
+	logger.Info("Banning member")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req BanMemberRequest
+	var uriReq ChannelIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	requestorId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	targetUserId, err := uuid.Parse(req.UserID)
+	if err != nil {
+		logger.Error("Failed to parse target user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ban, err := h.channelService.HandleBanMember(ctx, domain.BanMemberCommand{
+		ChannelID:    channelId,
+		RequestorID:  requestorId,
+		TargetUserID: targetUserId,
+		Reason:       req.Reason,
+		ExpiresAt:    req.ExpiresAt,
+	})
+	if err != nil {
+		logger.Error("Failed to ban member", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cacheKey := fmt.Sprintf("user_channels:%s", targetUserId.String())
+	h.cache.Delete(ctx.Request.Context(), cacheKey)
+
+	h.invalidateChannelCache(ctx, channelId)
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMemberRemoved(channelId, targetUserId, requestorId, "banned", ban.GetReason())
+	}
+
+	logger.Info("Member banned", zap.String("channel_id", channelId.String()))
+	ctx.JSON(http.StatusCreated, domain.ToChannelBanDTO(channelId.String(), ban))
+}
+
+// DELETE /api/v1/channels/:channelId/bans/:userId
+func (h *HTTPHandler) handleUnbanMember(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleUnbanMember")
+	logger.Info("Unbanning member")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq MemberUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	requestorId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	targetUserId, err := uuid.Parse(uriReq.UserID)
+	if err != nil {
+		logger.Error("Failed to parse target user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = h.channelService.HandleUnbanMember(ctx, domain.UnbanMemberCommand{
+		ChannelID:    channelId,
+		RequestorID:  requestorId,
+		TargetUserID: targetUserId,
+	})
+	if err != nil {
+		logger.Error("Failed to unban member", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	logger.Info("Member unbanned", zap.String("channel_id", channelId.String()))
+	ctx.Status(http.StatusOK)
+}
+
+// GET /api/v1/channels/:channelId/bans
+func (h *HTTPHandler) handleGetBans(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetBans")
+	logger.Info("Getting channel bans")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq ChannelIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	requestorId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	bans, err := h.channelService.HandleListBans(ctx, domain.ListBansCommand{
+		ChannelID:   channelId,
+		RequestorID: requestorId,
+	})
+	if err != nil {
+		logger.Error("Failed to get channel bans", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	bansDTO := make([]domain.ChannelBanDTO, len(bans))
+	for i := range bans {
+		bansDTO[i] = domain.ToChannelBanDTO(channelId.String(), &bans[i])
+	}
+
+	logger.Info("Channel bans retrieved", zap.Int("count", len(bansDTO)))
+	ctx.JSON(http.StatusOK, bansDTO)
+}
+
+// PUT /api/v1/channels/:channelId/unarchive
+func (h *HTTPHandler) handleUnarchiveChannel(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleUnarchiveChannel")
+	logger.Info("Unarchiving channel")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req ChannelIDUri
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(req.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.UnarchiveChannelCommand{
+		ChannelID: channelId,
+		UserID:    userUUID,
+	}
+
+	channel, err := h.channelService.HandleUnarchiveChannel(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to unarchive channel", zap.Error(err))
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	cacheKey := fmt.Sprintf("user_channels:%s", userUUID.String())
+	h.cache.Delete(ctx.Request.Context(), cacheKey)
+
+	h.invalidateChannelCache(ctx, channel.ID)
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastChannelUpdated(channel, userUUID)
+	}
+
+	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
+	if err != nil {
+		logger.Error("Failed to return channel DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Channel unarchived", zap.String("channel_id", channel.ID.String()))
+	ctx.JSON(http.StatusOK, channelDTO)
+}
+
+// POST /api/v1/channels/:channelId/join
+func (h *HTTPHandler) handleJoinChannel(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleJoinChannel")
+	logger.Info("Joining channel")
+
+	var req JoinChannelRequest
+	var uriReq ChannelIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(req.UserID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channel, err := h.channelService.HandleJoinChannel(ctx, domain.JoinChannelCommand{
+		ChannelID: channelId,
+		UserID:    userId,
+	})
+	if err != nil {
+		logger.Error("Failed to join channel", zap.Error(err))
+		if errors.Is(err, domain.ErrPrivateChannel) || errors.Is(err, domain.ErrDirectChannel) || errors.Is(err, domain.ErrUserBanned) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
+	if err != nil {
+		logger.Error("Failed to return channel DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	cacheKey := fmt.Sprintf("user_channels:%s", userId.String())
+	h.cache.Delete(ctx.Request.Context(), cacheKey)
+	h.invalidateChannelCache(ctx, channel.ID)
+
+	logger.Info("Channel joined", zap.String("channel_id", channel.ID.String()))
+	ctx.JSON(http.StatusOK, channelDTO)
+}
+
+// POST /api/v1/channels/:channelId/messages
+// FIXME: redundant, should be removed
+func (h *HTTPHandler) handleSendMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleSendMessage")
+	logger.Info("Sending message")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+	var req SendMessageRequest
+	var uriReq ChannelIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	senderID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse sender user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var parentMessageID *uuid.UUID
+	if req.ParentMessageID != nil {
+		parsed, err := uuid.Parse(*req.ParentMessageID)
+		if err != nil {
+			logger.Error("Failed to parse parent message ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		parentMessageID = &parsed
+	}
+
+	if req.ContentText == "" && len(req.AttachmentIDs) == 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(ErrEmptyMessage))
+		return
+	}
+
+	attachmentIDs := make([]uuid.UUID, len(req.AttachmentIDs))
+	for i, id := range req.AttachmentIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			logger.Error("Failed to parse attachment ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		attachmentIDs[i] = parsed
+	}
+
+	if name, args, ok := domain.ParseSlashCommand(req.ContentText); ok && len(attachmentIDs) == 0 {
+		h.runSlashCommand(ctx, domain.SlashCommandInvocation{
+			Name:            name,
+			Args:            args,
+			ChannelID:       channelId,
+			UserID:          senderID,
+			ParentMessageID: parentMessageID,
+		})
+		return
+	}
+
+	content := domain.NewMessageContent(domain.UnescapeSlashCommand(req.ContentText)) //TODO: add content type
+
+	message, err := h.messageService.HandleMessageSent(ctx, domain.SendMessageCommand{
+		ChannelID:       channelId,
+		SenderUserID:    senderID,
+		ParentMessageID: parentMessageID,
+		Content:         content,
+		AttachmentIDs:   attachmentIDs,
+	})
+	if err != nil {
+		logger.Error("Failed to send message", zap.Error(err))
+		switch {
+		case errors.Is(err, domain.ErrAttachmentUnavailable), errors.Is(err, domain.ErrTooManyAttachments):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, common.ErrNotFound):
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, domain.ErrNotChannelMember), errors.Is(err, domain.ErrUserBanned),
+			errors.Is(err, domain.ErrPrivateChannel), errors.Is(err, domain.ErrDirectChannel),
+			errors.Is(err, domain.ErrChannelArchived), errors.Is(err, domain.ErrPermissionDenied):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, senderID)
+	if err != nil {
+		logger.Error("Failed to send message", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	logger.Info("Message sent", zap.String("message_id", message.GetId().String()))
+	ctx.JSON(http.StatusCreated, messageDTO)
+}
+
+// GET /api/v1/channels/:channelId/messages
+func (h *HTTPHandler) handleGetMessages(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetMessages")
+	logger.Info("Getting messages")
+
+	var uriReq ChannelIDUri
+	var req ListMessagesRequest
+
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query, err := req.toMessageQuery()
+	if err != nil {
+		logger.Error("Invalid message cursor", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.ListMessagesForChannelCommand{
+		ChannelID: channelId,
+		UserID:    viewerID(ctx),
+		Query:     query,
+	}
+
+	page, err := h.messageService.HandleListMessages(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to list messages", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	messagesDTO, err := h.messageService.ToMessageDTOs(ctx, page.Messages, viewerID(ctx))
+	if err != nil {
+		logger.Error("Failed to convert messages to DTOs", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Messages retrieved", zap.String("channel_id", channelId.String()))
+	ctx.JSON(http.StatusOK, domain.ToMessagePageDTO(page, messagesDTO))
+}
+
+// GET /api/v1/messages/mentions
+func (h *HTTPHandler) handleGetMentions(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetMentions")
+	logger.Info("Getting mentions")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req ListMentionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query := domain.MessageQuery{Limit: req.Limit}
+	if req.Before != "" {
+		before, err := uuid.Parse(req.Before)
+		if err != nil {
+			logger.Error("Invalid message cursor", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		query.Before = &before
+	}
+
+	page, err := h.messageService.HandleListMentions(ctx, domain.ListMentionsCommand{
+		UserID: userId,
+		Query:  query,
+	})
+	if err != nil {
+		logger.Error("Failed to list mentions", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	messagesDTO, err := h.messageService.ToMessageDTOs(ctx, page.Messages, userId)
+	if err != nil {
+		logger.Error("Failed to convert messages to DTOs", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Mentions retrieved", zap.String("user_id", userId.String()))
+	ctx.JSON(http.StatusOK, domain.ToMessagePageDTO(page, messagesDTO))
+}
+
+// GET /api/v1/messages/search
+func (h *HTTPHandler) handleSearchMessages(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleSearchMessages")
+	logger.Info("Searching messages")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req SearchMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query, err := domain.ParseMessageSearchQuery(req.Query)
+	if err != nil {
+		logger.Error("Invalid search query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	query.Limit = req.Limit
+	if req.Cursor != "" {
+		cursor, err := domain.ParseMessageSearchCursor(req.Cursor)
+		if err != nil {
+			logger.Error("Invalid search cursor", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		query.Cursor = cursor
+	}
+
+	page, err := h.messageService.HandleSearchMessages(ctx, domain.SearchMessagesCommand{
+		UserID: userId,
+		Query:  query,
+	})
+	if err != nil {
+		logger.Error("Failed to search messages", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	messages := make([]domain.Message, len(page.Results))
+	for i, result := range page.Results {
+		messages[i] = result.Message
+	}
+	messagesDTO, err := h.messageService.ToMessageDTOs(ctx, messages, userId)
+	if err != nil {
+		logger.Error("Failed to convert messages to DTOs", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Messages searched", zap.String("user_id", userId.String()), zap.Int("count", len(page.Results)))
+	ctx.JSON(http.StatusOK, domain.ToMessageSearchPageDTO(page, messagesDTO))
+}
+
+// GET /api/v1/messages/bookmarks
+func (h *HTTPHandler) handleGetBookmarks(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetBookmarks")
+	logger.Info("Getting bookmarks")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req ListBookmarksRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query := domain.BookmarkQuery{Limit: req.Limit}
+	if req.Before != "" {
+		before, err := uuid.Parse(req.Before)
+		if err != nil {
+			logger.Error("Invalid bookmark cursor", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		query.Before = &before
+	}
+
+	page, err := h.bookmarkService.HandleListBookmarks(ctx, domain.ListBookmarksCommand{
+		UserID: userId,
+		Query:  query,
+	})
+	if err != nil {
+		logger.Error("Failed to list bookmarks", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	bookmarksDTO := make([]domain.BookmarkDTO, len(page.Bookmarks))
+	for i := range page.Bookmarks {
+		bookmarkDTO, err := h.toBookmarkDTO(ctx, &page.Bookmarks[i])
+		if err != nil {
+			logger.Error("Failed to convert bookmark to DTO", zap.Error(err))
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		bookmarksDTO[i] = bookmarkDTO
+	}
+
+	logger.Info("Bookmarks retrieved", zap.Int("count", len(bookmarksDTO)))
+	ctx.JSON(http.StatusOK, domain.ToBookmarkPageDTO(page, bookmarksDTO))
+}
+
+// POST /api/v1/messages/bookmarks
+func (h *HTTPHandler) handleAddBookmark(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleAddBookmark")
+	logger.Info("Adding bookmark")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req AddBookmarkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(req.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(req.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	bookmark, err := h.bookmarkService.HandleAddBookmark(ctx, domain.AddBookmarkCommand{
+		UserID:    userId,
+		ChannelID: channelId,
+		MessageID: messageId,
+		Note:      req.Note,
+	})
+	if err != nil {
+		logger.Error("Failed to add bookmark", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, common.ErrConflict) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	bookmarkDTO, err := h.toBookmarkDTO(ctx, bookmark)
+	if err != nil {
+		logger.Error("Failed to convert bookmark to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Bookmark added", zap.String("bookmark_id", bookmark.GetId().String()))
+	ctx.JSON(http.StatusCreated, bookmarkDTO)
+}
+
+// DELETE /api/v1/messages/bookmarks/:bookmarkId
+func (h *HTTPHandler) handleRemoveBookmark(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleRemoveBookmark")
+	logger.Info("Removing bookmark")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq BookmarkIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	bookmarkId, err := uuid.Parse(uriReq.BookmarkID)
+	if err != nil {
+		logger.Error("Failed to parse bookmark ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = h.bookmarkService.HandleRemoveBookmark(ctx, domain.RemoveBookmarkCommand{
+		UserID:     userId,
+		BookmarkID: bookmarkId,
+	})
+	if err != nil {
+		logger.Error("Failed to remove bookmark", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Bookmark removed", zap.String("bookmark_id", bookmarkId.String()))
+	ctx.Status(http.StatusOK)
+}
+
+// GET /api/v1/messages/scheduled
+func (h *HTTPHandler) handleGetScheduledMessages(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetScheduledMessages")
+	logger.Info("Getting scheduled messages")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req ListScheduledMessagesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.ListScheduledMessagesCommand{UserID: userId}
+	if req.ChannelID != "" {
+		channelId, err := uuid.Parse(req.ChannelID)
+		if err != nil {
+			logger.Error("Failed to parse channel ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		cmd.ChannelID = &channelId
+	}
+
+	messages, err := h.scheduleService.HandleListScheduledMessages(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to list scheduled messages", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	messagesDTO := make([]domain.ScheduledMessageDTO, len(messages))
+	for i := range messages {
+		messagesDTO[i] = domain.ToScheduledMessageDTO(&messages[i])
+	}
+
+	logger.Info("Scheduled messages retrieved", zap.Int("count", len(messagesDTO)))
+	ctx.JSON(http.StatusOK, messagesDTO)
+}
+
+// POST /api/v1/messages/scheduled
+func (h *HTTPHandler) handleScheduleMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleScheduleMessage")
+	logger.Info("Scheduling message")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req ScheduleMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(req.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var parentMessageId *uuid.UUID
+	if req.ParentMessageID != nil {
+		parsed, err := uuid.Parse(*req.ParentMessageID)
+		if err != nil {
+			logger.Error("Failed to parse parent message ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		parentMessageId = &parsed
+	}
+
+	message, err := h.scheduleService.HandleScheduleMessage(ctx, domain.ScheduleMessageCommand{
+		ChannelID:       channelId,
+		UserID:          userId,
+		ContentText:     req.ContentText,
+		ParentMessageID: parentMessageId,
+		ScheduledFor:    req.ScheduledFor,
+	})
+	if err != nil {
+		logger.Error("Failed to schedule message", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrScheduledMessageLimit) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	logger.Info("Message scheduled", zap.String("scheduled_message_id", message.GetId().String()))
+	ctx.JSON(http.StatusCreated, domain.ToScheduledMessageDTO(message))
+}
+
+// PUT /api/v1/messages/scheduled/:scheduledMessageId
+func (h *HTTPHandler) handleUpdateScheduledMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleUpdateScheduledMessage")
+	logger.Info("Updating scheduled message")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq ScheduledMessageIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req UpdateScheduledMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduledMessageId, err := uuid.Parse(uriReq.ScheduledMessageID)
+	if err != nil {
+		logger.Error("Failed to parse scheduled message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	message, err := h.scheduleService.HandleUpdateScheduledMessage(ctx, domain.UpdateScheduledMessageCommand{
+		UserID:             userId,
+		ScheduledMessageID: scheduledMessageId,
+		ContentText:        req.ContentText,
+		ScheduledFor:       req.ScheduledFor,
+	})
+	if err != nil {
+		logger.Error("Failed to update scheduled message", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrScheduledMessageSending) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	logger.Info("Scheduled message updated", zap.String("scheduled_message_id", message.GetId().String()))
+	ctx.JSON(http.StatusOK, domain.ToScheduledMessageDTO(message))
+}
+
+// DELETE /api/v1/messages/scheduled/:scheduledMessageId
+func (h *HTTPHandler) handleCancelScheduledMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleCancelScheduledMessage")
+	logger.Info("Cancelling scheduled message")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq ScheduledMessageIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	scheduledMessageId, err := uuid.Parse(uriReq.ScheduledMessageID)
+	if err != nil {
+		logger.Error("Failed to parse scheduled message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = h.scheduleService.HandleCancelScheduledMessage(ctx, domain.CancelScheduledMessageCommand{
+		UserID:             userId,
+		ScheduledMessageID: scheduledMessageId,
+	})
+	if err != nil {
+		logger.Error("Failed to cancel scheduled message", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrScheduledMessageSending) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Scheduled message cancelled", zap.String("scheduled_message_id", scheduledMessageId.String()))
+	ctx.Status(http.StatusOK)
+}
+
+// GET /api/v1/messages/reminders
+func (h *HTTPHandler) handleGetReminders(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetReminders")
+	logger.Info("Getting reminders")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req ListRemindersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.ListRemindersCommand{UserID: userId}
+	if req.Status != "" {
+		status := domain.ReminderStatus(req.Status)
+		cmd.Status = &status
+	}
+
+	reminders, err := h.reminderService.HandleListReminders(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to list reminders", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	remindersDTO := make([]domain.ReminderDTO, len(reminders))
+	for i := range reminders {
+		remindersDTO[i], err = h.toReminderDTO(ctx, &reminders[i])
+		if err != nil {
+			logger.Error("Failed to convert reminder to DTO", zap.Error(err))
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	logger.Info("Reminders retrieved", zap.Int("count", len(remindersDTO)))
+	ctx.JSON(http.StatusOK, remindersDTO)
+}
+
+// POST /api/v1/messages/reminders
+func (h *HTTPHandler) handleSetReminder(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleSetReminder")
+	logger.Info("Setting reminder")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req SetReminderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	remindAt, err := req.toRemindAt(time.Now().UTC())
+	if err != nil {
+		logger.Error("Invalid reminder time", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(req.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(req.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reminder, err := h.reminderService.HandleSetReminder(ctx, domain.SetReminderCommand{
+		UserID:    userId,
+		ChannelID: channelId,
+		MessageID: messageId,
+		RemindAt:  remindAt,
+	})
+	if err != nil {
+		logger.Error("Failed to set reminder", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrReminderLimit) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reminderDTO, err := h.toReminderDTO(ctx, reminder)
+	if err != nil {
+		logger.Error("Failed to convert reminder to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Reminder set", zap.String("reminder_id", reminder.GetId().String()))
+	ctx.JSON(http.StatusCreated, reminderDTO)
+}
+
+// PUT /api/v1/messages/reminders/:reminderId/snooze
+func (h *HTTPHandler) handleSnoozeReminder(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleSnoozeReminder")
+	logger.Info("Snoozing reminder")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq ReminderIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ReminderTimeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	remindAt, err := req.toRemindAt(time.Now().UTC())
+	if err != nil {
+		logger.Error("Invalid reminder time", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reminderId, err := uuid.Parse(uriReq.ReminderID)
+	if err != nil {
+		logger.Error("Failed to parse reminder ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reminder, err := h.reminderService.HandleSnoozeReminder(ctx, domain.SnoozeReminderCommand{
+		UserID:     userId,
+		ReminderID: reminderId,
+		RemindAt:   remindAt,
+	})
+	if err != nil {
+		logger.Error("Failed to snooze reminder", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reminderDTO, err := h.toReminderDTO(ctx, reminder)
+	if err != nil {
+		logger.Error("Failed to convert reminder to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Reminder snoozed", zap.String("reminder_id", reminder.GetId().String()))
+	ctx.JSON(http.StatusOK, reminderDTO)
+}
+
+// DELETE /api/v1/messages/reminders/:reminderId
+func (h *HTTPHandler) handleDeleteReminder(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleDeleteReminder")
+	logger.Info("Deleting reminder")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq ReminderIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reminderId, err := uuid.Parse(uriReq.ReminderID)
+	if err != nil {
+		logger.Error("Failed to parse reminder ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err = h.reminderService.HandleDeleteReminder(ctx, domain.DeleteReminderCommand{
+		UserID:     userId,
+		ReminderID: reminderId,
+	})
+	if err != nil {
+		logger.Error("Failed to delete reminder", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Reminder deleted", zap.String("reminder_id", reminderId.String()))
+	ctx.Status(http.StatusOK)
+}
+
+// toBookmarkDTO converts a bookmark, including its loaded message, to a DTO
+func (h *HTTPHandler) toBookmarkDTO(ctx *gin.Context, bookmark *domain.Bookmark) (domain.BookmarkDTO, error) {
+	var messageDTO *domain.MessageDTO
+	if bookmark.GetMessage() != nil {
+		dto, err := h.messageService.ToMessageDTO(ctx, bookmark.GetMessage(), bookmark.GetUserId())
+		if err != nil {
+			return domain.BookmarkDTO{}, err
+		}
+		messageDTO = dto
+	}
+	return domain.ToBookmarkDTO(bookmark, messageDTO), nil
+}
+
+// runSlashCommand answers a message that starts with a slash command.
+// A command that posts a message responds like a regular send; any other command responds with its ephemeral text.
+func (h *HTTPHandler) runSlashCommand(ctx *gin.Context, invocation domain.SlashCommandInvocation) {
+	logger := h.logger.WithMethod("runSlashCommand")
+	logger.Info("Running slash command", zap.String("command", invocation.Name))
+
+	result, err := h.slashCommands.Execute(ctx, invocation)
+	if err != nil {
+		logger.Error("Failed to execute slash command", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) || errors.Is(err, domain.ErrDirectChannel) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if result.LeftChannel {
+		cacheKey := fmt.Sprintf("user_channels:%s", invocation.UserID.String())
+		h.cache.Delete(ctx.Request.Context(), cacheKey)
+	}
+	if result.LeftChannel || result.UpdatedChannel != nil {
+		h.invalidateChannelCache(ctx, invocation.ChannelID)
+	}
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastSlashCommandEffects(invocation, result)
+	}
+
+	if result.PostedMessage != nil {
+		messageDTO, err := h.messageService.ToMessageDTO(ctx, result.PostedMessage, invocation.UserID)
+		if err != nil {
+			logger.Error("Failed to convert message to DTO", zap.Error(err))
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusCreated, messageDTO)
+		return
+	}
+
+	logger.Info("Slash command executed", zap.String("command", invocation.Name))
+	ctx.JSON(http.StatusOK, domain.SlashCommandResponseDTO{
+		Command: invocation.Name,
+		Text:    result.EphemeralText,
+	})
+}
+
+// GET /api/v1/messages/commands
+func (h *HTTPHandler) handleGetSlashCommands(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetSlashCommands")
+	logger.Info("Getting slash commands")
+
+	commands := h.slashCommands.Commands()
+	commandsDTO := make([]domain.SlashCommandDTO, len(commands))
+	for i, command := range commands {
+		commandsDTO[i] = domain.SlashCommandDTO{
+			Name:        command.Name,
+			Usage:       command.Usage,
+			Description: command.Description,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, commandsDTO)
+}
+
+func (h *HTTPHandler) toReminderDTO(ctx *gin.Context, reminder *domain.Reminder) (domain.ReminderDTO, error) {
+	var messageDTO *domain.MessageDTO
+	if reminder.GetMessage() != nil {
+		dto, err := h.messageService.ToMessageDTO(ctx, reminder.GetMessage(), reminder.GetUserId())
+		if err != nil {
+			return domain.ReminderDTO{}, err
+		}
+		messageDTO = dto
+	}
+	return domain.ToReminderDTO(reminder, messageDTO), nil
+}
+
+// PUT /api/v1/channels/:channelId/messages/:messageId
+func (h *HTTPHandler) handleEditMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleEditMessage")
+	logger.Info("Editing message")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req EditMessageRequest
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	message, err := h.messageService.HandleEditMessage(ctx, domain.EditMessageCommand{
+		ChannelID: channelId,
+		MessageID: messageId,
+		UserID:    userId,
+		Content:   domain.NewMessageContent(req.ContentText),
+	})
+	if err != nil {
+		logger.Error("Failed to edit message", zap.Error(err))
+		if errors.Is(err, domain.ErrNotChannelMember) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, userId)
+	if err != nil {
+		logger.Error("Failed to convert message to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMessageEdited(message)
+	}
+
+	logger.Info("Message edited", zap.String("message_id", message.GetId().String()))
+	ctx.JSON(http.StatusOK, messageDTO)
+}
+
+// DELETE /api/v1/channels/:channelId/messages/:messageId
+func (h *HTTPHandler) handleDeleteMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleDeleteMessage")
+	logger.Info("Deleting message")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	message, err := h.messageService.HandleDeleteMessage(ctx, domain.DeleteMessageCommand{
+		ChannelID: channelId,
+		MessageID: messageId,
+		UserID:    userId,
+	})
+	if err != nil {
+		logger.Error("Failed to delete message", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMessageDeleted(message)
+	}
+
+	logger.Info("Message deleted", zap.String("message_id", message.GetId().String()))
+	ctx.Status(http.StatusOK)
+}
+
+// PUT /api/v1/channels/:channelId/messages/:messageId/pin
+func (h *HTTPHandler) handlePinMessage(ctx *gin.Context) {
+	h.changeMessagePin(ctx, true)
+}
+
+// DELETE /api/v1/channels/:channelId/messages/:messageId/pin
+func (h *HTTPHandler) handleUnpinMessage(ctx *gin.Context) {
+	h.changeMessagePin(ctx, false)
+}
+
+func (h *HTTPHandler) changeMessagePin(ctx *gin.Context, pin bool) {
+	logger := h.logger.WithMethod("changeMessagePin")
+	logger.Info("Changing message pin", zap.Bool("pin", pin))
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var message *domain.Message
+	if pin {
+		message, err = h.messageService.HandlePinMessage(ctx, domain.PinMessageCommand{
+			ChannelID: channelId,
+			MessageID: messageId,
+			UserID:    userId,
+		})
+	} else {
+		message, err = h.messageService.HandleUnpinMessage(ctx, domain.UnpinMessageCommand{
+			ChannelID: channelId,
+			MessageID: messageId,
+			UserID:    userId,
+		})
+	}
+	if err != nil {
+		logger.Error("Failed to change message pin", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrPinLimitReached) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastPinChanged(message, userId, pin)
+	}
+
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, userId)
+	if err != nil {
+		logger.Error("Failed to convert message to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Message pin changed", zap.String("message_id", message.GetId().String()))
+	ctx.JSON(http.StatusOK, messageDTO)
+}
+
+// POST /api/v1/channels/:channelId/messages/:messageId/forward
+func (h *HTTPHandler) handleForwardMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleForwardMessage")
+	logger.Info("Forwarding message")
+
+	var req ForwardMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	targetChannelId, err := uuid.Parse(req.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	h.postMessageReference(ctx, "forward", func(sourceChannelId, sourceMessageId, userId uuid.UUID) (*domain.Message, error) {
+		return h.messageService.HandleForwardMessage(ctx, domain.ForwardMessageCommand{
+			ChannelID:       targetChannelId,
+			SenderUserID:    userId,
+			SourceChannelID: sourceChannelId,
+			SourceMessageID: sourceMessageId,
+			Comment:         domain.NewMessageContent(req.ContentText),
+		})
+	})
+}
+
+// POST /api/v1/channels/:channelId/messages/:messageId/quote
+func (h *HTTPHandler) handleQuoteMessage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleQuoteMessage")
+	logger.Info("Quoting message")
+
+	var req QuoteMessageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var targetChannelId *uuid.UUID
+	if req.ChannelID != nil {
+		parsed, err := uuid.Parse(*req.ChannelID)
+		if err != nil {
+			logger.Error("Failed to parse channel ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		targetChannelId = &parsed
+	}
+
+	var parentMessageID *uuid.UUID
+	if req.ParentMessageID != nil {
+		parsed, err := uuid.Parse(*req.ParentMessageID)
+		if err != nil {
+			logger.Error("Failed to parse parent message ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		parentMessageID = &parsed
+	}
+
+	h.postMessageReference(ctx, "quote", func(sourceChannelId, sourceMessageId, userId uuid.UUID) (*domain.Message, error) {
+		channelId := sourceChannelId
+		if targetChannelId != nil {
+			channelId = *targetChannelId
+		}
+		return h.messageService.HandleQuoteMessage(ctx, domain.QuoteMessageCommand{
+			ChannelID:       channelId,
+			SenderUserID:    userId,
+			SourceChannelID: sourceChannelId,
+			SourceMessageID: sourceMessageId,
+			Content:         domain.NewMessageContent(req.ContentText),
+			ParentMessageID: parentMessageID,
+		})
+	})
+}
+
+// postMessageReference posts a message referencing the message in the URI, broadcasts it and responds with it
+func (h *HTTPHandler) postMessageReference(ctx *gin.Context, kind string, post func(sourceChannelId, sourceMessageId, userId uuid.UUID) (*domain.Message, error)) {
+	logger := h.logger.WithMethod("postMessageReference")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	message, err := post(channelId, messageId, userId)
+	if err != nil {
+		logger.Error("Failed to post message", zap.String("kind", kind), zap.Error(err))
+		writeMessageReferenceError(ctx, err)
+		return
+	}
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMessage(message)
+	}
+
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, userId)
+	if err != nil {
+		logger.Error("Failed to convert message to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Message posted", zap.String("kind", kind), zap.String("message_id", message.GetId().String()))
+	ctx.JSON(http.StatusCreated, messageDTO)
+}
+
+// GET /api/v1/channels/:channelId/messages/:messageId/source
+func (h *HTTPHandler) handleGetMessageSource(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetMessageSource")
+	logger.Info("Getting message source")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	source, err := h.messageService.HandleGetMessageSource(ctx, domain.GetMessageSourceCommand{
+		ChannelID: channelId,
+		MessageID: messageId,
+		UserID:    userId,
+	})
+	if err != nil {
+		logger.Error("Failed to get message source", zap.Error(err))
+		writeMessageReferenceError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.messageService.ToMessagePreviewDTO(ctx, source))
+}
+
+// writeMessageReferenceError responds with the status matching a failed forward, quote or source lookup
+func writeMessageReferenceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, common.ErrNotFound), errors.Is(err, domain.ErrSourceMessageUnavailable):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, domain.ErrSourceAccessDenied):
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	}
+}
+
+// POST /api/v1/channels/:channelId/polls
+func (h *HTTPHandler) handleCreatePoll(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleCreatePoll")
+	logger.Info("Creating poll")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var req CreatePollRequest
+	var uriReq ChannelIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	senderID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse sender user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var parentMessageID *uuid.UUID
+	if req.ParentMessageID != nil {
+		parsed, err := uuid.Parse(*req.ParentMessageID)
+		if err != nil {
+			logger.Error("Failed to parse parent message ID", zap.Error(err))
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		parentMessageID = &parsed
+	}
+
+	message, err := h.messageService.HandleCreatePoll(ctx, domain.CreatePollCommand{
+		ChannelID:       channelId,
+		SenderUserID:    senderID,
+		Question:        req.Question,
+		Options:         req.Options,
+		MultipleChoice:  req.MultipleChoice,
+		Anonymous:       req.Anonymous,
+		ClosesAt:        req.ClosesAt,
+		ParentMessageID: parentMessageID,
+	})
+	if err != nil {
+		logger.Error("Failed to create poll", zap.Error(err))
+		writePollError(ctx, err)
+		return
+	}
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastMessage(message)
+	}
+
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, senderID)
+	if err != nil {
+		logger.Error("Failed to convert message to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Poll created", zap.String("message_id", message.GetId().String()))
+	ctx.JSON(http.StatusCreated, messageDTO)
+}
+
+// PUT /api/v1/channels/:channelId/messages/:messageId/poll/votes
+func (h *HTTPHandler) handleVotePoll(ctx *gin.Context) {
+	var req VotePollRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	optionIDs := make([]uuid.UUID, len(req.OptionIDs))
+	for i, id := range req.OptionIDs {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		optionIDs[i] = parsed
+	}
+
+	h.changePoll(ctx, "vote", func(channelId, messageId, userId uuid.UUID) (*domain.Message, error) {
+		return h.messageService.HandleVotePoll(ctx, domain.VotePollCommand{
+			ChannelID: channelId,
+			MessageID: messageId,
+			UserID:    userId,
+			OptionIDs: optionIDs,
+		})
+	})
+}
+
+// DELETE /api/v1/channels/:channelId/messages/:messageId/poll/votes
+func (h *HTTPHandler) handleRetractPollVote(ctx *gin.Context) {
+	h.changePoll(ctx, "retract vote", func(channelId, messageId, userId uuid.UUID) (*domain.Message, error) {
+		return h.messageService.HandleRetractPollVote(ctx, domain.RetractPollVoteCommand{
+			ChannelID: channelId,
+			MessageID: messageId,
+			UserID:    userId,
+		})
+	})
+}
+
+// POST /api/v1/channels/:channelId/messages/:messageId/poll/close
+func (h *HTTPHandler) handleClosePoll(ctx *gin.Context) {
+	h.changePoll(ctx, "close", func(channelId, messageId, userId uuid.UUID) (*domain.Message, error) {
+		return h.messageService.HandleClosePoll(ctx, domain.ClosePollCommand{
+			ChannelID: channelId,
+			MessageID: messageId,
+			UserID:    userId,
+		})
+	})
+}
+
+// changePoll runs an action on a poll, broadcasts the new tally and responds with the poll as the user sees it
+func (h *HTTPHandler) changePoll(ctx *gin.Context, action string, change func(channelId, messageId, userId uuid.UUID) (*domain.Message, error)) {
+	logger := h.logger.WithMethod("changePoll")
+	logger.Info("Changing poll", zap.String("action", action))
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	message, err := change(channelId, messageId, userId)
+	if err != nil {
+		logger.Error("Failed to change poll", zap.String("action", action), zap.Error(err))
+		writePollError(ctx, err)
+		return
+	}
+
+	if h.wsHandler != nil {
+		go h.wsHandler.BroadcastPollUpdated(message)
+	}
+
+	logger.Info("Poll changed", zap.String("action", action), zap.String("message_id", message.GetId().String()))
+	ctx.JSON(http.StatusOK, domain.ToPollDTO(message.GetPoll(), userId))
+}
+
+// writePollError responds with the status matching a failed poll action
+func writePollError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, common.ErrNotFound), errors.Is(err, domain.ErrNotAPoll), errors.Is(err, domain.ErrPollVoteNotFound):
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+	case errors.Is(err, domain.ErrPollCloseForbidden):
+		ctx.JSON(http.StatusForbidden, errorResponse(err))
+	case errors.Is(err, domain.ErrPollClosed):
+		ctx.JSON(http.StatusConflict, errorResponse(err))
+	default:
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+	}
+}
+
+// GET /api/v1/channels/:channelId/pins
+func (h *HTTPHandler) handleGetPinnedMessages(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetPinnedMessages")
+	logger.Info("Getting pinned messages")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq ChannelIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(uriReq.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messages, err := h.messageService.HandleListPinnedMessages(ctx, domain.ListPinnedMessagesCommand{
+		ChannelID: channelId,
+		UserID:    userId,
+	})
+	if err != nil {
+		logger.Error("Failed to get pinned messages", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	messagesDTO, err := h.messageService.ToMessageDTOs(ctx, messages, userId)
+	if err != nil {
+		logger.Error("Failed to convert messages to DTOs", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Pinned messages retrieved", zap.Int("count", len(messagesDTO)))
+	ctx.JSON(http.StatusOK, messagesDTO)
+}
+
+// GET /api/v1/channels/:channelId/messages/:messageId/thread
+func (h *HTTPHandler) handleGetThread(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetThread")
+	logger.Info("Getting thread")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+	var req ListMessagesRequest
+
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query, err := req.toMessageQuery()
+	if err != nil {
+		logger.Error("Invalid message cursor", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	root, replies, err := h.messageService.HandleGetThread(ctx, domain.GetThreadCommand{
+		ChannelID: channelId,
+		MessageID: messageId,
+		UserID:    userId,
+		Query:     query,
+	})
+	if err != nil {
+		logger.Error("Failed to get thread", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rootDTO, err := h.messageService.ToMessageDTO(ctx, root, userId)
+	if err != nil {
+		logger.Error("Failed to convert message to DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	repliesDTO, err := h.messageService.ToMessageDTOs(ctx, replies.Messages, userId)
+	if err != nil {
+		logger.Error("Failed to convert messages to DTOs", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Thread retrieved", zap.String("message_id", messageId.String()))
+	ctx.JSON(http.StatusOK, domain.ThreadDTO{
+		Root:    *rootDTO,
+		Replies: domain.ToMessagePageDTO(replies, repliesDTO),
+	})
+}
+
+// GET /api/v1/channels/:channelId/messages/:messageId/revisions
+func (h *HTTPHandler) handleGetMessageRevisions(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetMessageRevisions")
+	logger.Info("Getting message revisions")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	revisions, err := h.messageService.HandleGetMessageRevisions(ctx, domain.GetMessageRevisionsCommand{
+		ChannelID: channelId,
+		MessageID: messageId,
+		UserID:    userId,
+	})
+	if err != nil {
+		logger.Error("Failed to get message revisions", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	revisionsDTO := make([]domain.MessageRevisionDTO, len(revisions))
+	for i, revision := range revisions {
+		revisionsDTO[i] = domain.ToMessageRevisionDTO(revision)
+	}
+
+	logger.Info("Message revisions retrieved", zap.String("message_id", messageId.String()))
+	ctx.JSON(http.StatusOK, revisionsDTO)
+}
+
+// POST /api/v1/channels/:channelId/messages/:messageId/reactions
+func (h *HTTPHandler) handleAddReaction(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleAddReaction")
+	logger.Info("Adding reaction")
+
+	var req AddReactionRequest
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	userId, err := uuid.Parse(req.UserID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.AddReactionCommand{
+		ChannelID:    channelId,
+		MessageID:    messageId,
+		UserID:       userId,
+		ReactionType: req.ReactionType,
+	}
+
+	reaction, err := h.messageService.HandleAddReaction(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to add reaction", zap.Error(err))
+		if errors.Is(err, domain.ErrInvalidReactionType) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Reaction added", zap.String("reaction_id", reaction.GetId().String()))
+	//TODO: return the reaction as a DTO? or return the message with the reaction?
+	ctx.JSON(http.StatusCreated, reaction)
+}
+
+// DELETE /api/v1/channels/:channelId/messages/:messageId/reactions
+func (h *HTTPHandler) handleRemoveReaction(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleRemoveReaction")
+	logger.Info("Removing reaction")
+
+	var req RemoveReactionRequest
+	var channelIdUri ChannelIDUri
+	var messageIdUri MessageIDUri
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindUri(&messageIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	userId, err := uuid.Parse(req.UserID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	messageId, err := uuid.Parse(messageIdUri.MessageID)
+	if err != nil {
+		logger.Error("Failed to parse message ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.RemoveReactionCommand{
+		ChannelID:    channelId,
+		MessageID:    messageId,
+		UserID:       userId,
+		ReactionType: req.ReactionType,
+	}
+
+	_, err = h.messageService.HandleRemoveReaction(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to remove reaction", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	logger.Info("Reaction removed", zap.String("reaction_id", cmd.MessageID.String()))
+	ctx.Status(http.StatusOK)
+}
+
+// POST /api/v1/channels/:channelId/invites
+func (h *HTTPHandler) handleCreateChannelInvite(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleCreateChannelInvite")
+	logger.Info("Creating channel invite")
+
+	var channelIdUri ChannelIDUri
+	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	var req CreateChannelInviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	creatorID := ctx.GetHeader("X-User-ID")
+	if creatorID == "" {
+		logger.Error("Unauthorized")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	creatorUserID, err := uuid.Parse(creatorID)
+	if err != nil {
+		logger.Error("Failed to parse creator user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var maxUses *int
+	if req.MaxUses != 0 {
+		maxUses = &req.MaxUses
+	}
+
+	cmd := domain.CreateChannelInviteCommand{
+		ChannelID:       channelId,
+		CreatedByUserID: creatorUserID,
+		ExpiresAt:       req.ExpiresAt,
+		MaxUses:         maxUses,
+	}
+
+	_, invite, err := h.channelService.HandleCreateChannelInvite(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to create channel invite", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	inviteDTO := domain.ToChannelInviteDTO(invite)
+
+	logger.Info("Channel invite created", zap.String("invite_id", invite.ID.String()))
+	ctx.JSON(http.StatusCreated, inviteDTO)
+}
+
+// GET /api/v1/channels/:channelId/invites
+func (h *HTTPHandler) handleGetChannelInvites(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetChannelInvites")
+	logger.Info("Getting channel invites")
 
 	var channelIdUri ChannelIDUri
 	if err := ctx.ShouldBindUri(&channelIdUri); err != nil {
@@ -730,132 +3621,576 @@ func (h *HTTPHandler) handleGetChannelInvites(ctx *gin.Context) {
 		return
 	}
 
-	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	channelId, err := uuid.Parse(channelIdUri.ChannelID)
+	if err != nil {
+		logger.Error("Failed to parse channel ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.GetChannelInvitesCommand{
+		ChannelID: channelId,
+	}
+
+	channel, err := h.channelService.HandleGetChannelInvites(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to get channel invites", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	invites := channel.GetActiveInvites()
+	invitesDTO := make([]domain.ChannelInviteDTO, len(invites))
+	for i, invite := range invites {
+		invitesDTO[i] = domain.ToChannelInviteDTO(&invite)
+	}
+
+	logger.Info("Channel invites retrieved", zap.String("channel_id", channel.ID.String()))
+	ctx.JSON(http.StatusOK, invitesDTO)
+}
+
+// POST /api/v1/invites/accept
+func (h *HTTPHandler) handleAcceptChannelInvite(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleAcceptChannelInvite")
+	logger.Info("Accepting channel invite")
+
+	var req AcceptChannelInviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userIdStr := ctx.GetHeader("X-User-ID")
+	if userIdStr == "" {
+		logger.Error("Unauthorized")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	userId, err := uuid.Parse(userIdStr)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.AcceptChannelInviteCommand{
+		InviteCode: req.InviteCode,
+		UserID:     userId,
+	}
+
+	channel, err := h.channelService.HandleAcceptChannelInvite(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to accept channel invite", zap.Error(err))
+		if errors.Is(err, domain.ErrUserBanned) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
+	if err != nil {
+		logger.Error("Failed to return channel DTO", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Channel invite accepted", zap.String("channel_id", channel.ID.String()))
+	cacheKey := fmt.Sprintf("user_channels:%s", userId.String())
+	h.cache.Delete(ctx.Request.Context(), cacheKey)
+	h.invalidateChannelCache(ctx, channel.ID)
+
+	ctx.JSON(http.StatusOK, channelDTO)
+}
+
+// DELETE /api/v1/invites/:inviteId
+func (h *HTTPHandler) handleDeactivateChannelInvite(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleDeactivateChannelInvite")
+	logger.Info("Deactivating channel invite")
+
+	var inviteIdUri InvideIDUri
+	if err := ctx.ShouldBindUri(&inviteIdUri); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	inviteId, err := uuid.Parse(inviteIdUri.InvideID)
+	if err != nil {
+		logger.Error("Failed to parse invite ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userIDStr := ctx.GetHeader("X-User-ID")
+	if userIDStr == "" {
+		logger.Error("Unauthorized")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	cmd := domain.DeactivateChannelInviteCommand{
+		InviteID: inviteId,
+		UserID:   userID,
+	}
+
+	_, err = h.channelService.HandleDeactivateChannelInvite(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to deactivate channel invite", zap.Error(err))
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	logger.Info("Channel invite deactivated", zap.String("invite_id", inviteId.String()))
+	ctx.Status(http.StatusOK)
+}
+
+// POST /api/v1/messages/channels/:channelId/attachments
+func (h *HTTPHandler) handleUploadAttachment(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleUploadAttachment")
+	logger.Info("Uploading attachment")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq ChannelIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	uploaderID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	channelID, err := uuid.Parse(uriReq.ChannelID)
 	if err != nil {
 		logger.Error("Failed to parse channel ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.GetChannelInvitesCommand{
-		ChannelID: channelId,
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, domain.MaxAttachmentSize+maxMultipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		logger.Error("Failed to read uploaded file", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(domain.ErrAttachmentTooLarge))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if fileHeader.Size > domain.MaxAttachmentSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(domain.ErrAttachmentTooLarge))
+		return
 	}
 
-	channel, err := h.channelService.HandleGetChannelInvites(ctx, cmd)
+	file, err := fileHeader.Open()
 	if err != nil {
-		logger.Error("Failed to get channel invites", zap.Error(err))
+		logger.Error("Failed to open uploaded file", zap.Error(err))
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.HandleUploadAttachment(ctx, domain.UploadAttachmentCommand{
+		ChannelID:  channelID,
+		UploaderID: uploaderID,
+		FileName:   fileHeader.Filename,
+		Size:       fileHeader.Size,
+		Content:    file,
+	})
+	if err != nil {
+		logger.Error("Failed to upload attachment", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrAttachmentTooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrAttachmentTypeNotAllowed) {
+			ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
-	invites := channel.GetActiveInvites()
-	invitesDTO := make([]domain.ChannelInviteDTO, len(invites))
-	for i, invite := range invites {
-		invitesDTO[i] = domain.ToChannelInviteDTO(&invite)
+	logger.Info("Attachment uploaded", zap.String("attachment_id", attachment.GetId().String()))
+	ctx.JSON(http.StatusCreated, h.attachmentService.ToAttachmentDTO(attachment))
+}
+
+// GET /api/v1/messages/attachments/:attachmentId
+func (h *HTTPHandler) handleGetAttachment(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetAttachment")
+	logger.Info("Getting attachment")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
 	}
 
-	logger.Info("Channel invites retrieved", zap.String("channel_id", channel.ID.String()))
-	ctx.JSON(http.StatusOK, invitesDTO)
+	var uriReq AttachmentIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	attachmentID, err := uuid.Parse(uriReq.AttachmentID)
+	if err != nil {
+		logger.Error("Failed to parse attachment ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	attachment, err := h.attachmentService.HandleGetAttachment(ctx, domain.GetAttachmentURLCommand{
+		AttachmentID: attachmentID,
+		UserID:       userId,
+	})
+	if err != nil {
+		logger.Error("Failed to get attachment", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.attachmentService.ToAttachmentDTO(attachment))
 }
 
-// POST /api/v1/invites/accept
-func (h *HTTPHandler) handleAcceptChannelInvite(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleAcceptChannelInvite")
-	logger.Info("Accepting channel invite")
+// GET /api/v1/messages/attachments/:attachmentId/download
+// The route is not behind the gateway's authentication, the signature in the URL grants access.
+func (h *HTTPHandler) handleDownloadAttachment(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleDownloadAttachment")
+	logger.Info("Downloading attachment")
 
-	var req AcceptChannelInviteRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		logger.Error("Failed to bind JSON", zap.Error(err))
+	var uriReq AttachmentIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	userIdStr := ctx.GetHeader("X-User-ID")
-	if userIdStr == "" {
-		logger.Error("Unauthorized")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+	var req DownloadAttachmentRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to bind query", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	userId, err := uuid.Parse(userIdStr)
+	attachmentID, err := uuid.Parse(uriReq.AttachmentID)
 	if err != nil {
-		logger.Error("Failed to parse user ID", zap.Error(err))
+		logger.Error("Failed to parse attachment ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.AcceptChannelInviteCommand{
-		InviteCode: req.InviteCode,
-		UserID:     userId,
+	attachment, thumbnail, content, err := h.attachmentService.HandleOpenAttachment(ctx, attachmentID, req.Variant, req.Expires, req.Signature)
+	if err != nil {
+		logger.Error("Failed to open attachment", zap.Error(err))
+		if errors.Is(err, services.ErrInvalidAttachmentSignature) || errors.Is(err, services.ErrAttachmentURLExpired) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
+	defer content.Close()
 
-	channel, err := h.channelService.HandleAcceptChannelInvite(ctx, cmd)
+	// Only media is shown in the browser, everything else is downloaded. The sandbox keeps any
+	// file that is opened anyway from running scripts on the API origin.
+	disposition := "attachment"
+	if attachment.IsImage() || strings.HasPrefix(attachment.GetContentType(), "audio/") || strings.HasPrefix(attachment.GetContentType(), "video/") {
+		disposition = "inline"
+	}
+	maxAge := max(req.Expires-time.Now().Unix(), 0)
+
+	size, contentType := attachment.GetSize(), attachment.GetContentType()
+	if thumbnail != nil {
+		size, contentType = thumbnail.GetSize(), thumbnail.GetContentType()
+	}
+
+	ctx.DataFromReader(http.StatusOK, size, contentType, content, map[string]string{
+		"Content-Disposition":     mime.FormatMediaType(disposition, map[string]string{"filename": attachment.GetFileName()}),
+		"Content-Security-Policy": "default-src 'none'; sandbox",
+		"X-Content-Type-Options":  "nosniff",
+		"Cache-Control":           fmt.Sprintf("private, max-age=%d", maxAge),
+	})
+}
+
+// GET /api/v1/messages/emoji
+func (h *HTTPHandler) handleGetCustomEmoji(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetCustomEmoji")
+	logger.Info("Getting custom emoji")
+
+	if ctx.GetHeader("X-User-ID") == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	emoji, err := h.customEmojiService.HandleListCustomEmoji(ctx)
 	if err != nil {
-		logger.Error("Failed to accept channel invite", zap.Error(err))
+		logger.Error("Failed to list custom emoji", zap.Error(err))
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	channelDTO, err := h.channelService.ReturnChannelDTO(ctx, channel)
+	dtos := make([]domain.CustomEmojiDTO, len(emoji))
+	for i := range emoji {
+		dtos[i] = h.customEmojiService.ToCustomEmojiDTO(&emoji[i])
+	}
+	ctx.JSON(http.StatusOK, dtos)
+}
+
+// POST /api/v1/messages/emoji
+func (h *HTTPHandler) handleUploadCustomEmoji(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleUploadCustomEmoji")
+	logger.Info("Uploading custom emoji")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	creatorID, err := uuid.Parse(userID)
 	if err != nil {
-		logger.Error("Failed to return channel DTO", zap.Error(err))
+		logger.Error("Failed to parse user ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, domain.MaxCustomEmojiSize+maxMultipartOverhead)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		logger.Error("Failed to read uploaded file", zap.Error(err))
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(domain.ErrCustomEmojiTooLarge))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if fileHeader.Size > domain.MaxCustomEmojiSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(domain.ErrCustomEmojiTooLarge))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		logger.Error("Failed to open uploaded file", zap.Error(err))
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	defer file.Close()
 
-	logger.Info("Channel invite accepted", zap.String("channel_id", channel.ID.String()))
-	cacheKey := fmt.Sprintf("user_channels:%s", userId.String())
-	h.cache.Delete(ctx.Request.Context(), cacheKey)
+	emoji, err := h.customEmojiService.HandleUploadCustomEmoji(ctx, domain.UploadCustomEmojiCommand{
+		Name:      ctx.PostForm("name"),
+		CreatorID: creatorID,
+		Size:      fileHeader.Size,
+		Content:   file,
+	})
+	if err != nil {
+		logger.Error("Failed to upload custom emoji", zap.Error(err))
+		if errors.Is(err, common.ErrConflict) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrCustomEmojiTooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrCustomEmojiTypeNotAllowed) {
+			ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
-	ctx.JSON(http.StatusOK, channelDTO)
+	logger.Info("Custom emoji uploaded", zap.String("emoji_id", emoji.GetId().String()))
+	ctx.JSON(http.StatusCreated, h.customEmojiService.ToCustomEmojiDTO(emoji))
 }
 
-// DELETE /api/v1/invites/:inviteId
-func (h *HTTPHandler) handleDeactivateChannelInvite(ctx *gin.Context) {
-	logger := h.logger.WithMethod("handleDeactivateChannelInvite")
-	logger.Info("Deactivating channel invite")
+// POST /api/v1/messages/emoji/:name/aliases
+func (h *HTTPHandler) handleAddCustomEmojiAlias(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleAddCustomEmojiAlias")
+	logger.Info("Adding custom emoji alias")
 
-	var inviteIdUri InvideIDUri
-	if err := ctx.ShouldBindUri(&inviteIdUri); err != nil {
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
+		return
+	}
+
+	var uriReq CustomEmojiNameUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
 		logger.Error("Failed to bind URI", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
-	inviteId, err := uuid.Parse(inviteIdUri.InvideID)
+
+	var req AddCustomEmojiAliasRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to bind JSON", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	creatorID, err := uuid.Parse(userID)
 	if err != nil {
-		logger.Error("Failed to parse invite ID", zap.Error(err))
+		logger.Error("Failed to parse user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	userIDStr := ctx.GetHeader("X-User-ID")
-	if userIDStr == "" {
-		logger.Error("Unauthorized")
+	alias, err := h.customEmojiService.HandleAddCustomEmojiAlias(ctx, domain.AddCustomEmojiAliasCommand{
+		Name:       req.Name,
+		TargetName: uriReq.Name,
+		CreatorID:  creatorID,
+	})
+	if err != nil {
+		logger.Error("Failed to add custom emoji alias", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, common.ErrConflict) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	logger.Info("Custom emoji alias added", zap.String("emoji_id", alias.GetId().String()))
+	ctx.JSON(http.StatusCreated, h.customEmojiService.ToCustomEmojiDTO(alias))
+}
+
+// DELETE /api/v1/messages/emoji/:name
+func (h *HTTPHandler) handleDeleteCustomEmoji(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleDeleteCustomEmoji")
+	logger.Info("Deleting custom emoji")
+
+	userID := ctx.GetHeader("X-User-ID")
+	if userID == "" {
 		ctx.JSON(http.StatusUnauthorized, errorResponse(ErrUnauthorized))
 		return
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	var uriReq CustomEmojiNameUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userId, err := uuid.Parse(userID)
 	if err != nil {
 		logger.Error("Failed to parse user ID", zap.Error(err))
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	cmd := domain.DeactivateChannelInviteCommand{
-		InviteID: inviteId,
-		UserID:   userID,
+	// The gateway sets X-User-Is-Admin from the user's token; a missing or invalid header means not an admin
+	isAdmin, _ := strconv.ParseBool(ctx.GetHeader("X-User-Is-Admin"))
+
+	err = h.customEmojiService.HandleDeleteCustomEmoji(ctx, domain.DeleteCustomEmojiCommand{
+		Name:    uriReq.Name,
+		UserID:  userId,
+		IsAdmin: isAdmin,
+	})
+	if err != nil {
+		logger.Error("Failed to delete custom emoji", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, domain.ErrCustomEmojiDeleteForbidden) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	_, err = h.channelService.HandleDeactivateChannelInvite(ctx, cmd)
+	logger.Info("Custom emoji deleted", zap.String("name", uriReq.Name))
+	ctx.Status(http.StatusNoContent)
+}
+
+// GET /api/v1/messages/emoji/:emojiId/image
+// Emoji images are public so they can be used in <img> tags, the gateway does not authenticate this route.
+func (h *HTTPHandler) handleGetCustomEmojiImage(ctx *gin.Context) {
+	logger := h.logger.WithMethod("handleGetCustomEmojiImage")
+
+	var uriReq CustomEmojiIDUri
+	if err := ctx.ShouldBindUri(&uriReq); err != nil {
+		logger.Error("Failed to bind URI", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	emojiID, err := uuid.Parse(uriReq.EmojiID)
 	if err != nil {
-		logger.Error("Failed to deactivate channel invite", zap.Error(err))
+		logger.Error("Failed to parse emoji ID", zap.Error(err))
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	emoji, content, err := h.customEmojiService.HandleOpenCustomEmojiImage(ctx, emojiID)
+	if err != nil {
+		logger.Error("Failed to open custom emoji image", zap.Error(err))
+		if errors.Is(err, common.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
+	defer content.Close()
 
-	logger.Info("Channel invite deactivated", zap.String("invite_id", inviteId.String()))
-	ctx.Status(http.StatusOK)
+	// An emoji ID always refers to the same image, a new upload gets a new ID
+	ctx.DataFromReader(http.StatusOK, emoji.GetSize(), emoji.GetContentType(), content, map[string]string{
+		"Content-Security-Policy": "default-src 'none'; sandbox",
+		"X-Content-Type-Options":  "nosniff",
+		"Cache-Control":           "public, max-age=31536000, immutable",
+	})
 }
 
 // GET /api/v1/metrics
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
 )
 
 type ChannelIDUri struct {
@@ -13,39 +15,230 @@ type ChannelIDUri struct {
 type MessageIDUri struct {
 	MessageID string `uri:"messageId" binding:"required,uuid"`
 }
+type MemberUri struct {
+	ChannelID string `uri:"channelId" binding:"required,uuid"`
+	UserID    string `uri:"userId" binding:"required,uuid"`
+}
+
 type InvideIDUri struct {
 	InvideID string `uri:"inviteId" binding:"required,uuid"`
 }
 
 type CreateChannelRequest struct {
-	Name  string `json:"name"  binding:"required"`
-	Topic string `json:"topic" `
+	Name       string `json:"name"  binding:"required"`
+	Topic      string `json:"topic" `
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public private"`
+}
+
+type ChangeMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin moderator member guest"`
+}
+
+type TransferOwnershipRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+type BanMemberRequest struct {
+	UserID    string     `json:"user_id" binding:"required,uuid"`
+	Reason    string     `json:"reason" binding:"omitempty,max=500"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ChannelDirectoryRequest struct {
+	Search string `form:"q" binding:"omitempty,max=100"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Before string `form:"before" binding:"omitempty,uuid"`
+}
+
+type OpenDirectChannelRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1"`
 }
 
 type SendMessageRequest struct {
-	ContentText          string  `json:"content_text" binding:"required"`
-	IsIntegrationMessage *bool   `json:"is_integration_message" binding:"required"`
-	ParentMessageID      *string `json:"parent_message_id,omitempty" binding:"omitempty"`
+	ContentText          string   `json:"content_text"`
+	IsIntegrationMessage *bool    `json:"is_integration_message" binding:"required"`
+	ParentMessageID      *string  `json:"parent_message_id,omitempty" binding:"omitempty"`
+	AttachmentIDs        []string `json:"attachment_ids,omitempty" binding:"omitempty,max=10,dive,uuid"`
+}
+
+type ListMessagesRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Before string `form:"before" binding:"omitempty,uuid"`
+	After  string `form:"after" binding:"omitempty,uuid"`
+	Around string `form:"around" binding:"omitempty,uuid"`
+}
+
+// toMessageQuery converts the optional cursor parameters into a domain query
+func (r ListMessagesRequest) toMessageQuery() (domain.MessageQuery, error) {
+	query := domain.MessageQuery{Limit: r.Limit}
+	cursors := 0
+
+	for _, cursor := range []struct {
+		value  string
+		target **uuid.UUID
+	}{
+		{r.Before, &query.Before},
+		{r.After, &query.After},
+		{r.Around, &query.Around},
+	} {
+		if cursor.value == "" {
+			continue
+		}
+		id, err := uuid.Parse(cursor.value)
+		if err != nil {
+			return query, err
+		}
+		*cursor.target = &id
+		cursors++
+	}
+
+	if cursors > 1 {
+		return query, ErrMultipleCursors
+	}
+	return query, nil
+}
+
+type ListMentionsRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Before string `form:"before" binding:"omitempty,uuid"`
+}
+
+type SearchMessagesRequest struct {
+	Query  string `form:"q" binding:"required,max=500"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`
+	Cursor string `form:"cursor"`
+}
+
+type ListBookmarksRequest struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Before string `form:"before" binding:"omitempty,uuid"`
+}
+
+type AddBookmarkRequest struct {
+	ChannelID string `json:"channel_id" binding:"required,uuid"`
+	MessageID string `json:"message_id" binding:"required,uuid"`
+	Note      string `json:"note" binding:"omitempty,max=500"`
+}
+
+type BookmarkIDUri struct {
+	BookmarkID string `uri:"bookmarkId" binding:"required,uuid"`
+}
+
+type ScheduleMessageRequest struct {
+	ChannelID       string    `json:"channel_id" binding:"required,uuid"`
+	ContentText     string    `json:"content_text" binding:"required"`
+	ParentMessageID *string   `json:"parent_message_id,omitempty" binding:"omitempty,uuid"`
+	ScheduledFor    time.Time `json:"scheduled_for" binding:"required"`
+}
+
+type UpdateScheduledMessageRequest struct {
+	ContentText  string    `json:"content_text" binding:"required"`
+	ScheduledFor time.Time `json:"scheduled_for" binding:"required"`
+}
+
+type ListScheduledMessagesRequest struct {
+	ChannelID string `form:"channel_id" binding:"omitempty,uuid"`
+}
+
+type ScheduledMessageIDUri struct {
+	ScheduledMessageID string `uri:"scheduledMessageId" binding:"required,uuid"`
+}
+
+// ReminderTimeRequest sets a reminder time either directly or relative to now
+type ReminderTimeRequest struct {
+	RemindAt  *time.Time `json:"remind_at" binding:"omitempty"`
+	InMinutes int        `json:"in_minutes" binding:"omitempty,min=1,max=525600"`
+}
+
+// toRemindAt resolves the requested reminder time; exactly one of the two fields must be set
+func (r ReminderTimeRequest) toRemindAt(now time.Time) (time.Time, error) {
+	if (r.RemindAt == nil) == (r.InMinutes == 0) {
+		return time.Time{}, ErrReminderTime
+	}
+	if r.RemindAt != nil {
+		return *r.RemindAt, nil
+	}
+	return now.Add(time.Duration(r.InMinutes) * time.Minute), nil
+}
+
+type SetReminderRequest struct {
+	ChannelID string `json:"channel_id" binding:"required,uuid"`
+	MessageID string `json:"message_id" binding:"required,uuid"`
+	ReminderTimeRequest
+}
+
+type ListRemindersRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending fired"`
+}
+
+type ReminderIDUri struct {
+	ReminderID string `uri:"reminderId" binding:"required,uuid"`
+}
+
+type EditMessageRequest struct {
+	ContentText string `json:"content_text" binding:"required"`
+}
+
+type MarkReadRequest struct {
+	MessageID string `json:"message_id" binding:"required,uuid"`
 }
 
 type JoinChannelRequest struct {
 	UserID string `json:"user_id" binding:"required,uuid"`
 }
 
+type ForwardMessageRequest struct {
+	ChannelID   string `json:"channel_id" binding:"required,uuid"`
+	ContentText string `json:"content_text"`
+}
+
+// QuoteMessageRequest quotes a message in ChannelID, or in the message's own channel when it is left out
+type QuoteMessageRequest struct {
+	ChannelID       *string `json:"channel_id,omitempty" binding:"omitempty,uuid"`
+	ContentText     string  `json:"content_text" binding:"required"`
+	ParentMessageID *string `json:"parent_message_id,omitempty" binding:"omitempty,uuid"`
+}
+
+type CreatePollRequest struct {
+	Question        string     `json:"question" binding:"required"`
+	Options         []string   `json:"options" binding:"required,min=2,max=10"`
+	MultipleChoice  bool       `json:"multiple_choice"`
+	Anonymous       bool       `json:"anonymous"`
+	ClosesAt        *time.Time `json:"closes_at,omitempty" binding:"omitempty"`
+	ParentMessageID *string    `json:"parent_message_id,omitempty" binding:"omitempty,uuid"`
+}
+
+type VotePollRequest struct {
+	OptionIDs []string `json:"option_ids" binding:"required,min=1,dive,uuid"`
+}
+
 type AddReactionRequest struct {
 	UserID       string `json:"user_id" binding:"required,uuid"`
-	ReactionType string `json:"reaction_type" binding:"required"`
+	ReactionType string `json:"reaction_type" binding:"required,max=100"`
 }
 
 type RemoveReactionRequest struct {
 	UserID       string `json:"user_id" binding:"required,uuid"`
-	ReactionType string `json:"reaction_type" binding:"required"`
+	ReactionType string `json:"reaction_type" binding:"required,max=100"`
 }
 
 type AddBotToChannelRequest struct {
 	IntegrationID string `json:"integration_id" binding:"required,uuid"`
 }
 
+type ChannelBotUri struct {
+	ChannelID     string `uri:"channelId" binding:"required,uuid"`
+	IntegrationID string `uri:"integrationId" binding:"required,uuid"`
+}
+
+type SetChannelTopicRequest struct {
+	Topic string `json:"topic" binding:"max=250"`
+}
+
+type RenameChannelRequest struct {
+	Name string `json:"name" binding:"required,max=80"`
+}
+
 type CreateChannelInviteRequest struct {
 	ExpiresAt time.Time `json:"expires_at" binding:"required"`
 	MaxUses   int       `json:"max_uses,omitempty"`
@@ -58,3 +251,34 @@ type AcceptChannelInviteRequest struct {
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
+
+// viewerID returns the requesting user for endpoints that do not require one, uuid.Nil when there is none
+func viewerID(ctx *gin.Context) uuid.UUID {
+	userID, err := uuid.Parse(ctx.GetHeader("X-User-ID"))
+	if err != nil {
+		return uuid.Nil
+	}
+	return userID
+}
+
+type AttachmentIDUri struct {
+	AttachmentID string `uri:"attachmentId" binding:"required,uuid"`
+}
+
+type CustomEmojiNameUri struct {
+	Name string `uri:"name" binding:"required"`
+}
+
+type CustomEmojiIDUri struct {
+	EmojiID string `uri:"emojiId" binding:"required,uuid"`
+}
+
+type AddCustomEmojiAliasRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type DownloadAttachmentRequest struct {
+	Variant   string `form:"variant" binding:"omitempty,alphanum,max=20"`
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
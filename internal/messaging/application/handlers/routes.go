@@ -18,15 +18,75 @@ func SetupRoutes(
 			wsHandler.HandleWebSocket(c)
 		})
 
+		apiV1.GET("/mentions", httpHandler.handleGetMentions)
+		apiV1.GET("/search", httpHandler.handleSearchMessages)
+		apiV1.GET("/commands", httpHandler.handleGetSlashCommands)
+		apiV1.POST("/dms", httpHandler.handleOpenDirectChannel)
+
+		bookmarksGroup := apiV1.Group("/bookmarks")
+		{
+			bookmarksGroup.GET("", httpHandler.handleGetBookmarks)
+			bookmarksGroup.POST("", httpHandler.handleAddBookmark)
+			bookmarksGroup.DELETE("/:bookmarkId", httpHandler.handleRemoveBookmark)
+		}
+
+		scheduledGroup := apiV1.Group("/scheduled")
+		{
+			scheduledGroup.GET("", httpHandler.handleGetScheduledMessages)
+			scheduledGroup.POST("", httpHandler.handleScheduleMessage)
+			scheduledGroup.PUT("/:scheduledMessageId", httpHandler.handleUpdateScheduledMessage)
+			scheduledGroup.DELETE("/:scheduledMessageId", httpHandler.handleCancelScheduledMessage)
+		}
+
+		attachmentsGroup := apiV1.Group("/attachments")
+		{
+			attachmentsGroup.GET("/:attachmentId", httpHandler.handleGetAttachment)
+			attachmentsGroup.GET("/:attachmentId/download", httpHandler.handleDownloadAttachment)
+		}
+
+		emojiGroup := apiV1.Group("/emoji")
+		{
+			emojiGroup.GET("", httpHandler.handleGetCustomEmoji)
+			emojiGroup.POST("", httpHandler.handleUploadCustomEmoji)
+			emojiGroup.POST("/:name/aliases", httpHandler.handleAddCustomEmojiAlias)
+			emojiGroup.DELETE("/:name", httpHandler.handleDeleteCustomEmoji)
+			emojiGroup.GET("/:emojiId/image", httpHandler.handleGetCustomEmojiImage)
+		}
+
+		remindersGroup := apiV1.Group("/reminders")
+		{
+			remindersGroup.GET("", httpHandler.handleGetReminders)
+			remindersGroup.POST("", httpHandler.handleSetReminder)
+			remindersGroup.PUT("/:reminderId/snooze", httpHandler.handleSnoozeReminder)
+			remindersGroup.DELETE("/:reminderId", httpHandler.handleDeleteReminder)
+		}
+
 		channelsGroup := apiV1.Group("/channels")
 		{
 			channelsGroup.GET("/", httpHandler.handleGetUserChannels)
 			channelsGroup.POST("/", httpHandler.handleCreateChannel)
+			channelsGroup.GET("/directory", httpHandler.handleGetChannelDirectory)
 			channelsGroup.GET("/:channelId", httpHandler.handleGetChannel)
 			channelsGroup.POST("/:channelId/join", httpHandler.handleJoinChannel)
 			channelsGroup.PUT("/:channelId/archive", httpHandler.handleArchiveChannel)
 			channelsGroup.PUT("/:channelId/unarchive", httpHandler.handleUnarchiveChannel)
+			channelsGroup.POST("/:channelId/leave", httpHandler.handleLeaveChannel)
+			channelsGroup.PUT("/:channelId/topic", httpHandler.handleSetChannelTopic)
+			channelsGroup.PUT("/:channelId/name", httpHandler.handleRenameChannel)
 			channelsGroup.POST("/:channelId/bots", httpHandler.handleAddBotToChannel)
+			channelsGroup.DELETE("/:channelId/bots/:integrationId", httpHandler.handleRemoveBotFromChannel)
+			channelsGroup.PUT("/:channelId/read", httpHandler.handleMarkRead)
+			channelsGroup.POST("/:channelId/members/:userId/promote", httpHandler.handlePromoteMember)
+			channelsGroup.POST("/:channelId/members/:userId/demote", httpHandler.handleDemoteMember)
+			channelsGroup.POST("/:channelId/transfer-ownership", httpHandler.handleTransferOwnership)
+			channelsGroup.POST("/:channelId/members/:userId/kick", httpHandler.handleKickMember)
+			channelsGroup.GET("/:channelId/bans", httpHandler.handleGetBans)
+			channelsGroup.POST("/:channelId/bans", httpHandler.handleBanMember)
+			channelsGroup.DELETE("/:channelId/bans/:userId", httpHandler.handleUnbanMember)
+
+			channelsGroup.GET("/:channelId/pins", httpHandler.handleGetPinnedMessages)
+			channelsGroup.POST("/:channelId/attachments", httpHandler.handleUploadAttachment)
+			channelsGroup.POST("/:channelId/polls", httpHandler.handleCreatePoll)
 
 			channelsGroup.POST("/:channelId/invites", httpHandler.handleCreateChannelInvite)
 			channelsGroup.GET("/:channelId/invites", httpHandler.handleGetChannelInvites)
@@ -35,6 +95,18 @@ func SetupRoutes(
 			{
 				messagesGroup.GET("", httpHandler.handleGetMessages)
 				messagesGroup.POST("", httpHandler.handleSendMessage)
+				messagesGroup.PUT("/:messageId", httpHandler.handleEditMessage)
+				messagesGroup.DELETE("/:messageId", httpHandler.handleDeleteMessage)
+				messagesGroup.GET("/:messageId/revisions", httpHandler.handleGetMessageRevisions)
+				messagesGroup.GET("/:messageId/thread", httpHandler.handleGetThread)
+				messagesGroup.PUT("/:messageId/pin", httpHandler.handlePinMessage)
+				messagesGroup.DELETE("/:messageId/pin", httpHandler.handleUnpinMessage)
+				messagesGroup.POST("/:messageId/forward", httpHandler.handleForwardMessage)
+				messagesGroup.POST("/:messageId/quote", httpHandler.handleQuoteMessage)
+				messagesGroup.GET("/:messageId/source", httpHandler.handleGetMessageSource)
+				messagesGroup.PUT("/:messageId/poll/votes", httpHandler.handleVotePoll)
+				messagesGroup.DELETE("/:messageId/poll/votes", httpHandler.handleRetractPollVote)
+				messagesGroup.POST("/:messageId/poll/close", httpHandler.handleClosePoll)
 
 				reactionsGroup := messagesGroup.Group("/:messageId/reactions")
 				{
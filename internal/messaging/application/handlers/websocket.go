@@ -14,6 +14,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/m1thrandir225/meridian/internal/messaging/application/services"
 	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/cache"
 	"github.com/m1thrandir225/meridian/pkg/logging"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -21,11 +22,13 @@ import (
 
 type WebSocketHandler struct {
 	upgrader       websocket.Upgrader
-	clients        map[string]*websocket.Conn
+	clients        map[string]map[*websocket.Conn]bool
 	mu             sync.RWMutex
 	channelService *services.ChannelService
 	messageService *services.MessageService
+	slashCommands  *services.SlashCommandRegistry
 	redisClient    *redis.Client
+	cache          *cache.RedisCache
 	identityClient *services.IdentityClient
 	logger         *logging.Logger
 }
@@ -33,7 +36,9 @@ type WebSocketHandler struct {
 func NewWebSocketHandler(
 	channelService *services.ChannelService,
 	messageService *services.MessageService,
+	slashCommands *services.SlashCommandRegistry,
 	redisClient *redis.Client,
+	cache *cache.RedisCache,
 	identityClient *services.IdentityClient,
 	logger *logging.Logger,
 ) *WebSocketHandler {
@@ -43,10 +48,12 @@ func NewWebSocketHandler(
 				return true //TODO fix for production
 			},
 		},
-		clients:        make(map[string]*websocket.Conn),
+		clients:        make(map[string]map[*websocket.Conn]bool),
 		channelService: channelService,
 		messageService: messageService,
+		slashCommands:  slashCommands,
 		redisClient:    redisClient,
+		cache:          cache,
 		identityClient: identityClient,
 		logger:         logger,
 	}
@@ -84,12 +91,12 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 	defer conn.Close()
 
 	h.addClient(userID, conn)
-	defer h.removeClient(userID)
+	defer h.removeClient(userID, conn)
 
 	logger.Info("WebSocket connection established", zap.String("user_id", userID))
 
 	// Send connection confirmation
-	h.sendToClient(userID, WebSocketMessage{
+	h.sendToConn(conn, WebSocketMessage{
 		Type:    "connected",
 		Payload: map[string]string{"user_id": userID, "timestamp": time.Now().UTC().Format(time.RFC3339)},
 	})
@@ -108,7 +115,7 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 		// Process different message types
 		switch msg.Type {
 		case "ping":
-			h.sendToClient(userID, WebSocketMessage{
+			h.sendToConn(conn, WebSocketMessage{
 				Type:    "pong",
 				Payload: map[string]string{"timestamp": time.Now().UTC().Format(time.RFC3339)},
 			})
@@ -116,16 +123,43 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 			err := h.handleIncomingMessage(userID, msg.Payload)
 			if err != nil {
 				logger.Error("Failed to handle message from user", zap.String("user_id", userID), zap.Error(err))
-				h.sendToClient(userID, WebSocketMessage{
+				h.sendToConn(conn, WebSocketMessage{
 					Type:    "error",
 					Payload: map[string]string{"message": "Failed to send message", "error": err.Error()},
 				})
 			}
+		case "message_edit":
+			err := h.handleEditMessage(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle message edit from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to edit message", "error": err.Error()},
+				})
+			}
+		case "message_delete":
+			err := h.handleDeleteMessage(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle message delete from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to delete message", "error": err.Error()},
+				})
+			}
+		case "mark_read":
+			err := h.handleMarkRead(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle mark read from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to mark channel as read", "error": err.Error()},
+				})
+			}
 		case "add_reaction":
 			err := h.handleIncomingReaction(userID, msg.Payload)
 			if err != nil {
 				logger.Error("Failed to handle reaction from user", zap.String("user_id", userID), zap.Error(err))
-				h.sendToClient(userID, WebSocketMessage{
+				h.sendToConn(conn, WebSocketMessage{
 					Type:    "error",
 					Payload: map[string]string{"message": "Failed to add reaction", "error": err.Error()},
 				})
@@ -134,11 +168,101 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 			err := h.handleRemoveReaction(userID, msg.Payload)
 			if err != nil {
 				logger.Error("Failed to handle reaction from user", zap.String("user_id", userID), zap.Error(err))
-				h.sendToClient(userID, WebSocketMessage{
+				h.sendToConn(conn, WebSocketMessage{
 					Type:    "error",
 					Payload: map[string]string{"message": "Failed to remove reaction", "error": err.Error()},
 				})
 			}
+		case "forward_message":
+			err := h.handleForwardMessage(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle forward message from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to forward message", "error": err.Error()},
+				})
+			}
+		case "quote_message":
+			err := h.handleQuoteMessage(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle quote message from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to quote message", "error": err.Error()},
+				})
+			}
+		case "create_poll":
+			err := h.handleCreatePoll(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle create poll from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to create poll", "error": err.Error()},
+				})
+			}
+		case "vote_poll":
+			err := h.handleVotePoll(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle poll vote from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to vote in poll", "error": err.Error()},
+				})
+			}
+		case "retract_poll_vote":
+			err := h.handleRetractPollVote(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle poll vote retraction from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to retract poll vote", "error": err.Error()},
+				})
+			}
+		case "close_poll":
+			err := h.handleClosePoll(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle close poll from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to close poll", "error": err.Error()},
+				})
+			}
+		case "leave_channel":
+			err := h.handleLeaveChannel(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle leave channel from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to leave channel", "error": err.Error()},
+				})
+			}
+		case "set_topic":
+			err := h.handleSetTopic(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle set topic from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to set channel topic", "error": err.Error()},
+				})
+			}
+		case "rename_channel":
+			err := h.handleRenameChannel(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle rename channel from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to rename channel", "error": err.Error()},
+				})
+			}
+		case "remove_bot":
+			err := h.handleRemoveBot(userID, msg.Payload)
+			if err != nil {
+				logger.Error("Failed to handle remove bot from user", zap.String("user_id", userID), zap.Error(err))
+				h.sendToConn(conn, WebSocketMessage{
+					Type:    "error",
+					Payload: map[string]string{"message": "Failed to remove bot from channel", "error": err.Error()},
+				})
+			}
 		case "typing_start":
 			h.handleTypingIndicator(userID, msg.Payload, "typing_start")
 		case "typing_stop":
@@ -171,9 +295,9 @@ func (h *WebSocketHandler) handleIncomingMessage(senderID string, payload interf
 		logger.Error("Channel ID is required")
 		return fmt.Errorf("channel_id is required")
 	}
-	if incomingMsg.Content == "" {
+	if incomingMsg.Content == "" && len(incomingMsg.AttachmentIDs) == 0 {
 		logger.Error("Content is required")
-		return fmt.Errorf("content is required")
+		return fmt.Errorf("content or attachment_ids is required")
 	}
 
 	// Parse UUIDs
@@ -199,90 +323,941 @@ func (h *WebSocketHandler) handleIncomingMessage(senderID string, payload interf
 		parentMessageUUID = &parentUUID
 	}
 
-	messageContent := domain.NewMessageContent(incomingMsg.Content)
+	attachmentUUIDs := make([]uuid.UUID, len(incomingMsg.AttachmentIDs))
+	for i, id := range incomingMsg.AttachmentIDs {
+		attachmentUUID, err := uuid.Parse(id)
+		if err != nil {
+			logger.Error("Invalid attachment ID", zap.Error(err))
+			return fmt.Errorf("invalid attachment ID: %w", err)
+		}
+		attachmentUUIDs[i] = attachmentUUID
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Messages starting with a slash command are dispatched instead of posted, unless files are attached
+	if name, args, ok := domain.ParseSlashCommand(incomingMsg.Content); ok && len(attachmentUUIDs) == 0 {
+		return h.handleSlashCommand(ctx, domain.SlashCommandInvocation{
+			Name:            name,
+			Args:            args,
+			ChannelID:       channelUUID,
+			UserID:          senderUUID,
+			ParentMessageID: parentMessageUUID,
+		})
+	}
+
+	messageContent := domain.NewMessageContent(domain.UnescapeSlashCommand(incomingMsg.Content))
 
 	cmd := domain.SendMessageCommand{
 		ChannelID:       channelUUID,
 		SenderUserID:    senderUUID,
 		Content:         messageContent,
 		ParentMessageID: parentMessageUUID,
+		AttachmentIDs:   attachmentUUIDs,
+	}
+
+	// Handle through domain service
+	message, err := h.messageService.HandleMessageSent(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to send message", zap.Error(err))
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, uuid.Nil)
+	if err != nil {
+		logger.Error("Failed to convert message to DTO", zap.Error(err))
+		return fmt.Errorf("failed to convert message to DTO: %w", err)
+	}
+
+	outgoingMsg := newOutgoingMessagePayload(message, messageDTO)
+
+	if h.redisClient != nil {
+		go h.publishMessageToRedis(outgoingMsg)
+	} else {
+		go h.broadcastToChannel(incomingMsg.ChannelID, WebSocketMessage{
+			Type:    "new_message",
+			Payload: outgoingMsg,
+		})
+	}
+
+	return nil
+}
+
+// handleSlashCommand runs a command typed in a channel; its answer goes to the invoker's devices only
+func (h *WebSocketHandler) handleSlashCommand(ctx context.Context, invocation domain.SlashCommandInvocation) error {
+	logger := h.logger.WithMethod("handleSlashCommand")
+	logger.Info("Handling slash command", zap.String("command", invocation.Name))
+
+	result, err := h.slashCommands.Execute(ctx, invocation)
+	if err != nil {
+		logger.Error("Failed to execute slash command", zap.Error(err))
+		return fmt.Errorf("/%s failed: %w", invocation.Name, err)
+	}
+
+	if result.EphemeralText != "" {
+		h.PublishToUser(invocation.UserID.String(), WebSocketMessage{
+			Type: "command_response",
+			Payload: OutgoingCommandResponsePayload{
+				ChannelID: invocation.ChannelID.String(),
+				Command:   invocation.Name,
+				Text:      result.EphemeralText,
+			},
+		})
+	}
+	if result.LeftChannel || result.UpdatedChannel != nil {
+		h.invalidateChannelCache(ctx, invocation.ChannelID)
+	}
+	go h.BroadcastSlashCommandEffects(invocation, result)
+
+	return nil
+}
+
+// BroadcastSlashCommandEffects sends the same notifications as the regular requests a command stands in for
+func (h *WebSocketHandler) BroadcastSlashCommandEffects(invocation domain.SlashCommandInvocation, result *domain.SlashCommandResult) {
+	if result.PostedMessage != nil {
+		h.BroadcastMessage(result.PostedMessage)
+	}
+	if result.UpdatedChannel != nil {
+		h.BroadcastChannelUpdated(result.UpdatedChannel, invocation.UserID)
+	}
+	if result.LeftChannel {
+		h.BroadcastMemberLeft(invocation.ChannelID, invocation.UserID)
+	}
+}
+
+// newOutgoingMessagePayload builds the websocket payload for a message from its DTO
+func newOutgoingMessagePayload(message *domain.Message, messageDTO *domain.MessageDTO) OutgoingMessagePayload {
+	outgoingMsg := OutgoingMessagePayload{
+		ID:              messageDTO.ID,
+		Content:         messageDTO.ContentText,
+		ContentRichText: messageDTO.ContentRichText,
+		IsFormatted:     messageDTO.IsFormatted,
+		Mentions:        messageDTO.Mentions,
+		ChannelID:       messageDTO.ChannelID,
+		Attachments:     messageDTO.Attachments,
+		Timestamp:       messageDTO.CreatedAt,
+		EditedAt:        messageDTO.EditedAt,
+		Poll:            messageDTO.Poll,
+		Reference:       messageDTO.Reference,
+	}
+
+	// Handle sender ID safely
+	if messageDTO.SenderUserID != nil {
+		outgoingMsg.SenderUserID = *messageDTO.SenderUserID
+	} else if message.GetSenderUserId() != nil {
+		outgoingMsg.SenderUserID = message.GetSenderUserId().String()
+	}
+
+	// Handle integration ID safely
+	if messageDTO.IntegrationID != nil {
+		outgoingMsg.IntegrationID = *messageDTO.IntegrationID
+	} else if message.GetIntegrationId() != nil {
+		outgoingMsg.IntegrationID = message.GetIntegrationId().String()
+	}
+
+	// Handle parent message ID safely
+	if messageDTO.ParentMessageID != nil {
+		outgoingMsg.ParentMessageID = *messageDTO.ParentMessageID
+	} else if message.GetParentMessageId() != nil {
+		outgoingMsg.ParentMessageID = message.GetParentMessageId().String()
+	}
+
+	// Handle sender user information safely
+	if messageDTO.SenderUser != nil {
+		outgoingMsg.SenderUser = &UserDTO{
+			ID:        messageDTO.SenderUser.ID,
+			Username:  messageDTO.SenderUser.Username,
+			Email:     messageDTO.SenderUser.Email,
+			FirstName: messageDTO.SenderUser.FirstName,
+			LastName:  messageDTO.SenderUser.LastName,
+		}
+	}
+
+	// Handle integration bot information safely
+	if messageDTO.IntegrationBot != nil {
+		outgoingMsg.IntegrationBot = &IntegrationBotDTO{
+			ID:          messageDTO.IntegrationBot.ID,
+			ServiceName: messageDTO.IntegrationBot.ServiceName,
+			CreatedAt:   messageDTO.IntegrationBot.CreatedAt,
+			IsRevoked:   messageDTO.IntegrationBot.IsRevoked,
+		}
+	}
+
+	return outgoingMsg
+}
+
+func (h *WebSocketHandler) handleEditMessage(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleEditMessage")
+	logger.Info("Handling message edit")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingEdit IncomingEditMessagePayload
+	if err := json.Unmarshal(payloadBytes, &incomingEdit); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingEdit.MessageID == "" {
+		logger.Error("Message ID is required")
+		return fmt.Errorf("message_id is required")
+	}
+	if incomingEdit.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+	if incomingEdit.Content == "" {
+		logger.Error("Content is required")
+		return fmt.Errorf("content is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingEdit.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	messageUUID, err := uuid.Parse(incomingEdit.MessageID)
+	if err != nil {
+		logger.Error("Invalid message ID", zap.Error(err))
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	cmd := domain.EditMessageCommand{
+		ChannelID: channelUUID,
+		MessageID: messageUUID,
+		UserID:    userUUID,
+		Content:   domain.NewMessageContent(incomingEdit.Content),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	message, err := h.messageService.HandleEditMessage(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to edit message", zap.Error(err))
+		return fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	go h.BroadcastMessageEdited(message)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleDeleteMessage(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleDeleteMessage")
+	logger.Info("Handling message delete")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingDelete IncomingDeleteMessagePayload
+	if err := json.Unmarshal(payloadBytes, &incomingDelete); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingDelete.MessageID == "" {
+		logger.Error("Message ID is required")
+		return fmt.Errorf("message_id is required")
+	}
+	if incomingDelete.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingDelete.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	messageUUID, err := uuid.Parse(incomingDelete.MessageID)
+	if err != nil {
+		logger.Error("Invalid message ID", zap.Error(err))
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	cmd := domain.DeleteMessageCommand{
+		ChannelID: channelUUID,
+		MessageID: messageUUID,
+		UserID:    userUUID,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	message, err := h.messageService.HandleDeleteMessage(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to delete message", zap.Error(err))
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	go h.BroadcastMessageDeleted(message)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleMarkRead(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleMarkRead")
+	logger.Info("Handling mark read")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingMarkRead IncomingMarkReadPayload
+	if err := json.Unmarshal(payloadBytes, &incomingMarkRead); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingMarkRead.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+	if incomingMarkRead.MessageID == "" {
+		logger.Error("Message ID is required")
+		return fmt.Errorf("message_id is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingMarkRead.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	messageUUID, err := uuid.Parse(incomingMarkRead.MessageID)
+	if err != nil {
+		logger.Error("Invalid message ID", zap.Error(err))
+		return fmt.Errorf("invalid message ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	member, advanced, err := h.channelService.HandleMarkRead(ctx, domain.MarkReadCommand{
+		ChannelID: channelUUID,
+		UserID:    userUUID,
+		MessageID: messageUUID,
+	})
+	if err != nil {
+		logger.Error("Failed to mark channel as read", zap.Error(err))
+		return fmt.Errorf("failed to mark channel as read: %w", err)
+	}
+
+	if advanced {
+		go h.BroadcastReadMarker(channelUUID, member)
+	}
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleLeaveChannel(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleLeaveChannel")
+	logger.Info("Handling leave channel")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingLeave IncomingChannelPayload
+	if err := json.Unmarshal(payloadBytes, &incomingLeave); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingLeave.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingLeave.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = h.channelService.HandleLeaveChannel(ctx, domain.LeaveChannelCommand{
+		ChannelID: channelUUID,
+		UserID:    userUUID,
+	})
+	if err != nil {
+		logger.Error("Failed to leave channel", zap.Error(err))
+		return fmt.Errorf("failed to leave channel: %w", err)
+	}
+
+	h.invalidateChannelCache(ctx, channelUUID)
+	go h.BroadcastMemberLeft(channelUUID, userUUID)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleSetTopic(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleSetTopic")
+	logger.Info("Handling set topic")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingTopic IncomingSetTopicPayload
+	if err := json.Unmarshal(payloadBytes, &incomingTopic); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingTopic.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingTopic.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	channel, err := h.channelService.HandleSetChannelTopic(ctx, domain.SetChannelTopicCommand{
+		ChannelID: channelUUID,
+		UserID:    userUUID,
+		Topic:     incomingTopic.Topic,
+	})
+	if err != nil {
+		logger.Error("Failed to set channel topic", zap.Error(err))
+		return fmt.Errorf("failed to set channel topic: %w", err)
+	}
+
+	h.invalidateChannelCache(ctx, channel.ID)
+	go h.BroadcastChannelUpdated(channel, userUUID)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleRenameChannel(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleRenameChannel")
+	logger.Info("Handling rename channel")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingRename IncomingRenameChannelPayload
+	if err := json.Unmarshal(payloadBytes, &incomingRename); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingRename.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+	if incomingRename.Name == "" {
+		logger.Error("Name is required")
+		return fmt.Errorf("name is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingRename.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	channel, err := h.channelService.HandleRenameChannel(ctx, domain.RenameChannelCommand{
+		ChannelID: channelUUID,
+		UserID:    userUUID,
+		Name:      incomingRename.Name,
+	})
+	if err != nil {
+		logger.Error("Failed to rename channel", zap.Error(err))
+		return fmt.Errorf("failed to rename channel: %w", err)
+	}
+
+	h.invalidateChannelCache(ctx, channel.ID)
+	go h.BroadcastChannelUpdated(channel, userUUID)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleRemoveBot(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleRemoveBot")
+	logger.Info("Handling remove bot")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingRemoveBot IncomingRemoveBotPayload
+	if err := json.Unmarshal(payloadBytes, &incomingRemoveBot); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingRemoveBot.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+	if incomingRemoveBot.IntegrationID == "" {
+		logger.Error("Integration ID is required")
+		return fmt.Errorf("integration_id is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingRemoveBot.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	integrationUUID, err := uuid.Parse(incomingRemoveBot.IntegrationID)
+	if err != nil {
+		logger.Error("Invalid integration ID", zap.Error(err))
+		return fmt.Errorf("invalid integration ID: %w", err)
+	}
+
+	_, err = h.channelService.HandleRemoveBotFromChannel(ctx, domain.RemoveBotFromChannelCommand{
+		ChannelID:     channelUUID,
+		IntegrationID: integrationUUID,
+		RequestorID:   userUUID,
+	})
+	if err != nil {
+		logger.Error("Failed to remove bot from channel", zap.Error(err))
+		return fmt.Errorf("failed to remove bot from channel: %w", err)
+	}
+
+	h.invalidateChannelCache(ctx, channelUUID)
+	go h.BroadcastMemberLeft(channelUUID, integrationUUID)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleForwardMessage(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleForwardMessage")
+	logger.Info("Handling forward message")
+
+	incoming, userUUID, sourceChannelUUID, sourceMessageUUID, err := parseMessageReferencePayload(userID, payload)
+	if err != nil {
+		logger.Error("Invalid forward message", zap.Error(err))
+		return err
+	}
+
+	if incoming.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+	channelUUID, err := uuid.Parse(incoming.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	cmd := domain.ForwardMessageCommand{
+		ChannelID:       channelUUID,
+		SenderUserID:    userUUID,
+		SourceChannelID: sourceChannelUUID,
+		SourceMessageID: sourceMessageUUID,
+		Comment:         domain.NewMessageContent(incoming.Content),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	message, err := h.messageService.HandleForwardMessage(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to forward message", zap.Error(err))
+		return fmt.Errorf("failed to forward message: %w", err)
+	}
+
+	go h.BroadcastMessage(message)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleQuoteMessage(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleQuoteMessage")
+	logger.Info("Handling quote message")
+
+	incoming, userUUID, sourceChannelUUID, sourceMessageUUID, err := parseMessageReferencePayload(userID, payload)
+	if err != nil {
+		logger.Error("Invalid quote message", zap.Error(err))
+		return err
+	}
+
+	channelUUID := sourceChannelUUID
+	if incoming.ChannelID != "" {
+		channelUUID, err = uuid.Parse(incoming.ChannelID)
+		if err != nil {
+			logger.Error("Invalid channel ID", zap.Error(err))
+			return fmt.Errorf("invalid channel ID: %w", err)
+		}
+	}
+
+	var parentMessageUUID *uuid.UUID
+	if incoming.ParentMessageID != "" {
+		parentUUID, err := uuid.Parse(incoming.ParentMessageID)
+		if err != nil {
+			logger.Error("Invalid parent message ID", zap.Error(err))
+			return fmt.Errorf("invalid parent message ID: %w", err)
+		}
+		parentMessageUUID = &parentUUID
+	}
+
+	cmd := domain.QuoteMessageCommand{
+		ChannelID:       channelUUID,
+		SenderUserID:    userUUID,
+		SourceChannelID: sourceChannelUUID,
+		SourceMessageID: sourceMessageUUID,
+		Content:         domain.NewMessageContent(incoming.Content),
+		ParentMessageID: parentMessageUUID,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	message, err := h.messageService.HandleQuoteMessage(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to quote message", zap.Error(err))
+		return fmt.Errorf("failed to quote message: %w", err)
+	}
+
+	go h.BroadcastMessage(message)
+
+	return nil
+}
+
+// parseMessageReferencePayload parses the payload of a forward or quote and the IDs both need
+func parseMessageReferencePayload(userID string, payload interface{}) (*IncomingMessageReferencePayload, uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	var incoming IncomingMessageReferencePayload
+	if err := json.Unmarshal(payloadBytes, &incoming); err != nil {
+		return nil, uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+
+	if incoming.SourceChannelID == "" {
+		return nil, uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("source_channel_id is required")
+	}
+	if incoming.SourceMessageID == "" {
+		return nil, uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("source_message_id is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	sourceChannelUUID, err := uuid.Parse(incoming.SourceChannelID)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid source channel ID: %w", err)
+	}
+	sourceMessageUUID, err := uuid.Parse(incoming.SourceMessageID)
+	if err != nil {
+		return nil, uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid source message ID: %w", err)
+	}
+	return &incoming, userUUID, sourceChannelUUID, sourceMessageUUID, nil
+}
+
+func (h *WebSocketHandler) handleCreatePoll(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleCreatePoll")
+	logger.Info("Handling create poll")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingPoll IncomingCreatePollPayload
+	if err := json.Unmarshal(payloadBytes, &incomingPoll); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	if incomingPoll.ChannelID == "" {
+		logger.Error("Channel ID is required")
+		return fmt.Errorf("channel_id is required")
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		logger.Error("Invalid user ID", zap.Error(err))
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	channelUUID, err := uuid.Parse(incomingPoll.ChannelID)
+	if err != nil {
+		logger.Error("Invalid channel ID", zap.Error(err))
+		return fmt.Errorf("invalid channel ID: %w", err)
+	}
+
+	var parentMessageUUID *uuid.UUID
+	if incomingPoll.ParentMessageID != "" {
+		parentUUID, err := uuid.Parse(incomingPoll.ParentMessageID)
+		if err != nil {
+			logger.Error("Invalid parent message ID", zap.Error(err))
+			return fmt.Errorf("invalid parent message ID: %w", err)
+		}
+		parentMessageUUID = &parentUUID
+	}
+
+	cmd := domain.CreatePollCommand{
+		ChannelID:       channelUUID,
+		SenderUserID:    userUUID,
+		Question:        incomingPoll.Question,
+		Options:         incomingPoll.Options,
+		MultipleChoice:  incomingPoll.MultipleChoice,
+		Anonymous:       incomingPoll.Anonymous,
+		ClosesAt:        incomingPoll.ClosesAt,
+		ParentMessageID: parentMessageUUID,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	message, err := h.messageService.HandleCreatePoll(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to create poll", zap.Error(err))
+		return fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	go h.BroadcastMessage(message)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleVotePoll(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleVotePoll")
+	logger.Info("Handling poll vote")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingVote IncomingVotePollPayload
+	if err := json.Unmarshal(payloadBytes, &incomingVote); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	channelUUID, messageUUID, userUUID, err := parsePollIDs(userID, incomingVote.ChannelID, incomingVote.MessageID)
+	if err != nil {
+		logger.Error("Invalid poll vote", zap.Error(err))
+		return err
+	}
+
+	optionUUIDs := make([]uuid.UUID, len(incomingVote.OptionIDs))
+	for i, id := range incomingVote.OptionIDs {
+		optionUUID, err := uuid.Parse(id)
+		if err != nil {
+			logger.Error("Invalid option ID", zap.Error(err))
+			return fmt.Errorf("invalid option ID: %w", err)
+		}
+		optionUUIDs[i] = optionUUID
+	}
+
+	cmd := domain.VotePollCommand{
+		ChannelID: channelUUID,
+		MessageID: messageUUID,
+		UserID:    userUUID,
+		OptionIDs: optionUUIDs,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	message, err := h.messageService.HandleVotePoll(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to vote in poll", zap.Error(err))
+		return fmt.Errorf("failed to vote in poll: %w", err)
+	}
+
+	go h.BroadcastPollUpdated(message)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleRetractPollVote(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleRetractPollVote")
+	logger.Info("Handling poll vote retraction")
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
+	}
+
+	var incomingPoll IncomingPollPayload
+	if err := json.Unmarshal(payloadBytes, &incomingPoll); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
+	}
+
+	channelUUID, messageUUID, userUUID, err := parsePollIDs(userID, incomingPoll.ChannelID, incomingPoll.MessageID)
+	if err != nil {
+		logger.Error("Invalid poll vote retraction", zap.Error(err))
+		return err
+	}
+
+	cmd := domain.RetractPollVoteCommand{
+		ChannelID: channelUUID,
+		MessageID: messageUUID,
+		UserID:    userUUID,
 	}
 
-	// Handle through domain service
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	message, err := h.messageService.HandleMessageSent(ctx, cmd)
+	message, err := h.messageService.HandleRetractPollVote(ctx, cmd)
 	if err != nil {
-		logger.Error("Failed to send message", zap.Error(err))
-		return fmt.Errorf("failed to send message: %w", err)
+		logger.Error("Failed to retract poll vote", zap.Error(err))
+		return fmt.Errorf("failed to retract poll vote: %w", err)
 	}
-	messageDTO, err := h.messageService.ToMessageDTO(ctx, message)
+
+	go h.BroadcastPollUpdated(message)
+
+	return nil
+}
+
+func (h *WebSocketHandler) handleClosePoll(userID string, payload interface{}) error {
+	logger := h.logger.WithMethod("handleClosePoll")
+	logger.Info("Handling close poll")
+
+	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
-		logger.Error("Failed to convert message to DTO", zap.Error(err))
-		return fmt.Errorf("failed to convert message to DTO: %w", err)
+		logger.Error("Failed to marshal payload", zap.Error(err))
+		return err
 	}
 
-	outgoingMsg := OutgoingMessagePayload{
-		ID:        messageDTO.ID,
-		Content:   messageDTO.ContentText,
-		ChannelID: messageDTO.ChannelID,
-		Timestamp: messageDTO.CreatedAt,
+	var incomingPoll IncomingPollPayload
+	if err := json.Unmarshal(payloadBytes, &incomingPoll); err != nil {
+		logger.Error("Failed to unmarshal payload", zap.Error(err))
+		return err
 	}
 
-	// Handle sender ID safely
-	if messageDTO.SenderUserID != nil {
-		outgoingMsg.SenderUserID = *messageDTO.SenderUserID
-	} else if message.GetSenderUserId() != nil {
-		outgoingMsg.SenderUserID = message.GetSenderUserId().String()
+	channelUUID, messageUUID, userUUID, err := parsePollIDs(userID, incomingPoll.ChannelID, incomingPoll.MessageID)
+	if err != nil {
+		logger.Error("Invalid close poll", zap.Error(err))
+		return err
 	}
 
-	// Handle integration ID safely
-	if messageDTO.IntegrationID != nil {
-		outgoingMsg.IntegrationID = *messageDTO.IntegrationID
-	} else if message.GetIntegrationId() != nil {
-		outgoingMsg.IntegrationID = message.GetIntegrationId().String()
+	cmd := domain.ClosePollCommand{
+		ChannelID: channelUUID,
+		MessageID: messageUUID,
+		UserID:    userUUID,
 	}
 
-	// Handle parent message ID safely
-	if messageDTO.ParentMessageID != nil {
-		outgoingMsg.ParentMessageID = *messageDTO.ParentMessageID
-	} else if message.GetParentMessageId() != nil {
-		outgoingMsg.ParentMessageID = message.GetParentMessageId().String()
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	// Handle sender user information safely
-	if messageDTO.SenderUser != nil {
-		outgoingMsg.SenderUser = &UserDTO{
-			ID:        messageDTO.SenderUser.ID,
-			Username:  messageDTO.SenderUser.Username,
-			Email:     messageDTO.SenderUser.Email,
-			FirstName: messageDTO.SenderUser.FirstName,
-			LastName:  messageDTO.SenderUser.LastName,
-		}
+	message, err := h.messageService.HandleClosePoll(ctx, cmd)
+	if err != nil {
+		logger.Error("Failed to close poll", zap.Error(err))
+		return fmt.Errorf("failed to close poll: %w", err)
 	}
 
-	// Handle integration bot information safely
-	if messageDTO.IntegrationBot != nil {
-		outgoingMsg.IntegrationBot = &IntegrationBotDTO{
-			ID:          messageDTO.IntegrationBot.ID,
-			ServiceName: messageDTO.IntegrationBot.ServiceName,
-			CreatedAt:   messageDTO.IntegrationBot.CreatedAt,
-			IsRevoked:   messageDTO.IntegrationBot.IsRevoked,
-		}
-	}
+	go h.BroadcastPollUpdated(message)
 
-	if h.redisClient != nil {
-		go h.publishMessageToRedis(outgoingMsg)
-	} else {
-		go h.broadcastToChannel(incomingMsg.ChannelID, WebSocketMessage{
-			Type:    "new_message",
-			Payload: outgoingMsg,
-		})
+	return nil
+}
+
+// parsePollIDs parses the IDs every poll action needs
+func parsePollIDs(userID, channelID, messageID string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	if channelID == "" {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("channel_id is required")
+	}
+	if messageID == "" {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("message_id is required")
 	}
 
-	return nil
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	channelUUID, err := uuid.Parse(channelID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid channel ID: %w", err)
+	}
+	messageUUID, err := uuid.Parse(messageID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, fmt.Errorf("invalid message ID: %w", err)
+	}
+	return channelUUID, messageUUID, userUUID, nil
 }
+
 func (h *WebSocketHandler) handleIncomingReaction(userID string, payload interface{}) error {
 	logger := h.logger.WithMethod("handleIncomingReaction")
 	logger.Info("Handling incoming reaction")
@@ -512,38 +1487,69 @@ func (h *WebSocketHandler) handleTypingIndicator(userID string, payload interfac
 	}
 }
 
+// invalidateChannelCache drops the cached channel details so the next read sees the change
+func (h *WebSocketHandler) invalidateChannelCache(ctx context.Context, channelID uuid.UUID) {
+	if h.cache == nil {
+		return
+	}
+	cacheKey := fmt.Sprintf("channel:%s", channelID.String())
+	h.cache.Delete(ctx, cacheKey)
+}
+
+// addClient registers a connection; a user may have several connected devices
 func (h *WebSocketHandler) addClient(userID string, conn *websocket.Conn) {
 	logger := h.logger.WithMethod("addClient")
 	logger.Info("Adding client")
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.clients[userID] = conn
+	if h.clients[userID] == nil {
+		h.clients[userID] = make(map[*websocket.Conn]bool)
+	}
+	h.clients[userID][conn] = true
 }
 
-func (h *WebSocketHandler) removeClient(userID string) {
+func (h *WebSocketHandler) removeClient(userID string, conn *websocket.Conn) {
 	logger := h.logger.WithMethod("removeClient")
 	logger.Info("Removing client")
 
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	delete(h.clients, userID)
+	delete(h.clients[userID], conn)
+	if len(h.clients[userID]) == 0 {
+		delete(h.clients, userID)
+	}
+}
+
+// sendToConn replies on a single connection, e.g. to the device that sent a frame
+func (h *WebSocketHandler) sendToConn(conn *websocket.Conn, message WebSocketMessage) error {
+	return conn.WriteJSON(message)
 }
 
+// sendToClient delivers a message to every device the user has connected to this instance
 func (h *WebSocketHandler) sendToClient(userID string, message WebSocketMessage) error {
 	logger := h.logger.WithMethod("sendToClient")
 	logger.Info("Sending to client")
 
 	h.mu.RLock()
-	conn, exists := h.clients[userID]
+	conns := make([]*websocket.Conn, 0, len(h.clients[userID]))
+	for conn := range h.clients[userID] {
+		conns = append(conns, conn)
+	}
 	h.mu.RUnlock()
 
-	if !exists {
+	if len(conns) == 0 {
 		logger.Error("Client not connected")
 		return nil // Client not connected
 	}
 
-	return conn.WriteJSON(message)
+	var firstErr error
+	for _, conn := range conns {
+		if err := conn.WriteJSON(message); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 func (h *WebSocketHandler) publishMessageToRedis(message OutgoingMessagePayload) {
@@ -616,7 +1622,7 @@ func (h *WebSocketHandler) subscribeToRedisMessages() {
 
 	ctx := context.Background()
 
-	pubsub := h.redisClient.PSubscribe(ctx, "channel:*")
+	pubsub := h.redisClient.PSubscribe(ctx, "channel:*", "user:*")
 	defer pubsub.Close()
 
 	ch := pubsub.Channel()
@@ -629,6 +1635,9 @@ func (h *WebSocketHandler) subscribeToRedisMessages() {
 		if strings.HasPrefix(msg.Channel, "channel:") {
 			channelID := strings.TrimPrefix(msg.Channel, "channel:")
 			h.broadcastToChannel(channelID, wsMessage)
+		} else if strings.HasPrefix(msg.Channel, "user:") {
+			userID := strings.TrimPrefix(msg.Channel, "user:")
+			h.sendToClient(userID, wsMessage)
 		}
 	}
 }
@@ -688,24 +1697,69 @@ func (h *WebSocketHandler) publishReactionRemovedToRedis(reaction OutgoingReacti
 
 }
 
+// broadcastToChannel delivers a message to the devices connected to this instance whose user is a member of the channel
 func (h *WebSocketHandler) broadcastToChannel(channelID string, message WebSocketMessage) {
 	logger := h.logger.WithMethod("broadcastToChannel")
 	logger.Info("Broadcasting to channel")
 
 	h.mu.RLock()
-	defer h.mu.RUnlock()
+	connected := len(h.clients)
+	h.mu.RUnlock()
+	if connected == 0 {
+		return
+	}
 
-	for userID, conn := range h.clients {
-		err := conn.WriteJSON(message)
-		//TODO: check if the current user is a member of the channel
-		if err != nil {
-			logger.Error("Failed to send message to user", zap.String("user_id", userID), zap.Error(err))
-			conn.Close()
+	recipients, err := h.channelRecipients(channelID)
+	if err != nil {
+		logger.Error("Failed to resolve channel recipients", zap.String("channel_id", channelID), zap.Error(err))
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for userID := range recipients {
+		conns, ok := h.clients[userID]
+		if !ok {
+			continue
+		}
+		for conn := range conns {
+			err := conn.WriteJSON(message)
+			if err != nil {
+				logger.Error("Failed to send message to user", zap.String("user_id", userID), zap.Error(err))
+				conn.Close()
+				delete(conns, conn)
+			}
+		}
+		if len(conns) == 0 {
 			delete(h.clients, userID)
 		}
 	}
 }
 
+// channelRecipients returns the users a channel frame may be delivered to, read from the current membership
+// so that members who left or were removed stop receiving the channel right away
+func (h *WebSocketHandler) channelRecipients(channelID string) (map[string]bool, error) {
+	channelId, err := uuid.Parse(channelID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid channel ID %q: %w", channelID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	memberIDs, err := h.channelService.GetChannelMemberIDs(ctx, channelId)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make(map[string]bool, len(memberIDs))
+	for _, memberID := range memberIDs {
+		recipients[memberID.String()] = true
+	}
+	return recipients, nil
+}
+
 func (h *WebSocketHandler) BroadcastMessage(message *domain.Message) {
 	logger := h.logger.WithMethod("BroadcastMessage")
 	logger.Info("Broadcasting message")
@@ -713,71 +1767,259 @@ func (h *WebSocketHandler) BroadcastMessage(message *domain.Message) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	messageDTO, err := h.messageService.ToMessageDTO(ctx, message)
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, uuid.Nil)
 	if err != nil {
 		logger.Error("Failed to convert message to DTO", zap.Error(err))
 		return
 	}
 
-	outgoingMsg := OutgoingMessagePayload{
-		ID:        messageDTO.ID,
-		Content:   messageDTO.ContentText,
-		ChannelID: messageDTO.ChannelID,
-		Timestamp: messageDTO.CreatedAt,
+	outgoingMsg := newOutgoingMessagePayload(message, messageDTO)
+
+	wsMessage := WebSocketMessage{
+		Type:    "new_message",
+		Payload: outgoingMsg,
 	}
 
-	// Handle sender ID safely
-	if messageDTO.SenderUserID != nil {
-		outgoingMsg.SenderUserID = *messageDTO.SenderUserID
-	} else if message.GetSenderUserId() != nil {
-		outgoingMsg.SenderUserID = message.GetSenderUserId().String()
+	if h.redisClient != nil {
+		h.publishMessageToRedis(outgoingMsg)
+	} else {
+		h.broadcastToChannel(message.GetChannelId().String(), wsMessage)
 	}
+}
 
-	// Handle integration ID safely
-	if messageDTO.IntegrationID != nil {
-		outgoingMsg.IntegrationID = *messageDTO.IntegrationID
-	} else if message.GetIntegrationId() != nil {
-		outgoingMsg.IntegrationID = message.GetIntegrationId().String()
+// BroadcastMessageEdited sends the updated message to every client watching its channel
+func (h *WebSocketHandler) BroadcastMessageEdited(message *domain.Message) {
+	logger := h.logger.WithMethod("BroadcastMessageEdited")
+	logger.Info("Broadcasting message edit")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	messageDTO, err := h.messageService.ToMessageDTO(ctx, message, uuid.Nil)
+	if err != nil {
+		logger.Error("Failed to convert message to DTO", zap.Error(err))
+		return
 	}
 
-	// Handle parent message ID safely
-	if messageDTO.ParentMessageID != nil {
-		outgoingMsg.ParentMessageID = *messageDTO.ParentMessageID
-	} else if message.GetParentMessageId() != nil {
-		outgoingMsg.ParentMessageID = message.GetParentMessageId().String()
+	h.BroadcastToChannel(message.GetChannelId().String(), WebSocketMessage{
+		Type:    "message_edited",
+		Payload: newOutgoingMessagePayload(message, messageDTO),
+	})
+}
+
+// BroadcastMessageDeleted tells every client watching the channel to replace the message with a tombstone
+func (h *WebSocketHandler) BroadcastMessageDeleted(message *domain.Message) {
+	logger := h.logger.WithMethod("BroadcastMessageDeleted")
+	logger.Info("Broadcasting message deletion")
+
+	if h.redisClient != nil {
+		messageKey := fmt.Sprintf("message:%s", message.GetId().String())
+		h.redisClient.Del(context.Background(), messageKey)
 	}
 
-	// Handle sender user information safely
-	if messageDTO.SenderUser != nil {
-		outgoingMsg.SenderUser = &UserDTO{
-			ID:        messageDTO.SenderUser.ID,
-			Username:  messageDTO.SenderUser.Username,
-			Email:     messageDTO.SenderUser.Email,
-			FirstName: messageDTO.SenderUser.FirstName,
-			LastName:  messageDTO.SenderUser.LastName,
-		}
+	h.BroadcastToChannel(message.GetChannelId().String(), WebSocketMessage{
+		Type: "message_deleted",
+		Payload: OutgoingMessageDeletedPayload{
+			ID:        message.GetId().String(),
+			ChannelID: message.GetChannelId().String(),
+			DeletedBy: message.GetDeletedBy().String(),
+			DeletedAt: *message.GetDeletedAt(),
+		},
+	})
+}
+
+// BroadcastPollUpdated sends the current tally of a poll to every client watching the channel
+func (h *WebSocketHandler) BroadcastPollUpdated(message *domain.Message) {
+	pollDTO := domain.ToPollDTO(message.GetPoll(), uuid.Nil)
+	if pollDTO == nil {
+		return
 	}
 
-	// Handle integration bot information safely
-	if messageDTO.IntegrationBot != nil {
-		outgoingMsg.IntegrationBot = &IntegrationBotDTO{
-			ID:          messageDTO.IntegrationBot.ID,
-			ServiceName: messageDTO.IntegrationBot.ServiceName,
-			CreatedAt:   messageDTO.IntegrationBot.CreatedAt,
-			IsRevoked:   messageDTO.IntegrationBot.IsRevoked,
-		}
+	h.BroadcastToChannel(message.GetChannelId().String(), WebSocketMessage{
+		Type: "poll_updated",
+		Payload: OutgoingPollUpdatedPayload{
+			ChannelID: message.GetChannelId().String(),
+			MessageID: message.GetId().String(),
+			Poll:      *pollDTO,
+		},
+	})
+}
+
+// BroadcastPinChanged tells every client watching the channel that a message was pinned or unpinned
+func (h *WebSocketHandler) BroadcastPinChanged(message *domain.Message, userID uuid.UUID, pinned bool) {
+	messageType := "pin_removed"
+	timestamp := time.Now().UTC()
+	if pinned {
+		messageType = "pin_added"
+		timestamp = *message.GetPinnedAt()
+	}
+
+	h.BroadcastToChannel(message.GetChannelId().String(), WebSocketMessage{
+		Type: messageType,
+		Payload: OutgoingPinPayload{
+			ChannelID: message.GetChannelId().String(),
+			MessageID: message.GetId().String(),
+			UserID:    userID.String(),
+			Timestamp: timestamp,
+		},
+	})
+}
+
+// BroadcastMessageUnfurled sends the link previews of a message once they have been fetched.
+// An empty list means the previews were removed by an edit.
+func (h *WebSocketHandler) BroadcastMessageUnfurled(message *domain.Message) {
+	h.BroadcastToChannel(message.GetChannelId().String(), WebSocketMessage{
+		Type: "message_unfurled",
+		Payload: OutgoingMessageUnfurledPayload{
+			ChannelID:    message.GetChannelId().String(),
+			MessageID:    message.GetId().String(),
+			LinkPreviews: domain.ToLinkPreviewDTOs(message.GetLinkPreviews()),
+		},
+	})
+}
+
+// BroadcastAttachmentProcessed sends the thumbnails and metadata of an image once they are ready. Attachments of
+// sent messages go to the channel, uploads that were not sent yet only to the uploader's devices.
+func (h *WebSocketHandler) BroadcastAttachmentProcessed(attachment *domain.Attachment, dto domain.AttachmentDTO) {
+	payload := OutgoingAttachmentProcessedPayload{
+		ChannelID:  attachment.GetChannelId().String(),
+		Attachment: dto,
 	}
 
-	wsMessage := WebSocketMessage{
-		Type:    "new_message",
-		Payload: outgoingMsg,
+	if attachment.GetMessageId() == nil {
+		h.PublishToUser(attachment.GetUploaderId().String(), WebSocketMessage{
+			Type:    "attachment_processed",
+			Payload: payload,
+		})
+		return
 	}
 
-	if h.redisClient != nil {
-		h.publishMessageToRedis(outgoingMsg)
-	} else {
-		h.broadcastToChannel(message.GetChannelId().String(), wsMessage)
+	payload.MessageID = attachment.GetMessageId().String()
+	h.BroadcastToChannel(attachment.GetChannelId().String(), WebSocketMessage{
+		Type:    "attachment_processed",
+		Payload: payload,
+	})
+}
+
+// BroadcastToChannel fans a message out through the Redis channel:<id> topic,
+// falling back to the local clients when Redis is not configured
+func (h *WebSocketHandler) BroadcastToChannel(channelID string, message WebSocketMessage) {
+	logger := h.logger.WithMethod("BroadcastToChannel")
+	logger.Info("Broadcasting to channel", zap.String("channel_id", channelID), zap.String("type", message.Type))
+
+	if h.redisClient == nil {
+		h.broadcastToChannel(channelID, message)
+		return
+	}
+
+	messageJSON, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("Failed to marshal message", zap.Error(err))
+		return
+	}
+
+	channelKey := fmt.Sprintf("channel:%s", channelID)
+	if err := h.redisClient.Publish(context.Background(), channelKey, messageJSON).Err(); err != nil {
+		logger.Error("Failed to publish message to Redis", zap.Error(err))
+	}
+}
+
+// PublishToUser delivers a message to all of the user's devices through the Redis user:<id> topic,
+// falling back to the local connections when Redis is not configured
+func (h *WebSocketHandler) PublishToUser(userID string, message WebSocketMessage) {
+	logger := h.logger.WithMethod("PublishToUser")
+	logger.Info("Publishing to user", zap.String("user_id", userID), zap.String("type", message.Type))
+
+	if h.redisClient == nil {
+		h.sendToClient(userID, message)
+		return
+	}
+
+	messageJSON, err := json.Marshal(message)
+	if err != nil {
+		logger.Error("Failed to marshal message", zap.Error(err))
+		return
+	}
+
+	userKey := fmt.Sprintf("user:%s", userID)
+	if err := h.redisClient.Publish(context.Background(), userKey, messageJSON).Err(); err != nil {
+		logger.Error("Failed to publish message to Redis", zap.Error(err))
+	}
+}
+
+// BroadcastReadMarker pushes a member's new read marker to all of their devices
+func (h *WebSocketHandler) BroadcastReadMarker(channelID uuid.UUID, member *domain.Member) {
+	h.PublishToUser(member.GetId().String(), WebSocketMessage{
+		Type:    "read_marker_updated",
+		Payload: domain.ToReadMarkerDTO(channelID.String(), member),
+	})
+}
+
+// BroadcastMemberRemoved tells the channel and the removed user's own devices that a member was kicked or banned
+// so their clients can close the channel. The removed user is no longer a member and so no longer receives
+// the channel's frames; they get this one through their user topic instead.
+func (h *WebSocketHandler) BroadcastMemberRemoved(channelID, userID, removedBy uuid.UUID, action, reason string) {
+	message := WebSocketMessage{
+		Type: "member_removed",
+		Payload: OutgoingMemberRemovedPayload{
+			ChannelID: channelID.String(),
+			UserID:    userID.String(),
+			RemovedBy: removedBy.String(),
+			Action:    action,
+			Reason:    reason,
+		},
+	}
+
+	h.BroadcastToChannel(channelID.String(), message)
+	h.PublishToUser(userID.String(), message)
+}
+
+// BroadcastMemberLeft tells the channel that a member or bot is no longer part of it
+func (h *WebSocketHandler) BroadcastMemberLeft(channelID, userID uuid.UUID) {
+	h.BroadcastToChannel(channelID.String(), WebSocketMessage{
+		Type: "member_left",
+		Payload: OutgoingMemberLeftPayload{
+			ChannelID: channelID.String(),
+			UserID:    userID.String(),
+		},
+	})
+}
+
+// BroadcastChannelUpdated pushes the channel's new name, topic and archive state so open clients can refresh the header
+func (h *WebSocketHandler) BroadcastChannelUpdated(channel *domain.Channel, updatedBy uuid.UUID) {
+	h.BroadcastToChannel(channel.ID.String(), WebSocketMessage{
+		Type: "channel_updated",
+		Payload: OutgoingChannelUpdatedPayload{
+			ChannelID:  channel.ID.String(),
+			Name:       channel.Name,
+			Topic:      channel.Topic,
+			IsArchived: channel.IsArchived,
+			UpdatedBy:  updatedBy.String(),
+		},
+	})
+}
+
+// BroadcastReminderDue delivers a fired reminder, with the message it points to, to all of the user's devices
+func (h *WebSocketHandler) BroadcastReminderDue(reminder *domain.Reminder) {
+	logger := h.logger.WithMethod("BroadcastReminderDue")
+
+	var messageDTO *domain.MessageDTO
+	if reminder.GetMessage() != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		dto, err := h.messageService.ToMessageDTO(ctx, reminder.GetMessage(), reminder.GetUserId())
+		if err != nil {
+			logger.Warn("Failed to convert reminder message to DTO", zap.Error(err))
+		} else {
+			messageDTO = dto
+		}
 	}
+
+	h.PublishToUser(reminder.GetUserId().String(), WebSocketMessage{
+		Type:    "reminder_due",
+		Payload: domain.ToReminderDTO(reminder, messageDTO),
+	})
 }
 
 func (h *WebSocketHandler) SendToUser(userID string, message WebSocketMessage) error {
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
 )
 
 type WebSocketMessage struct {
@@ -10,21 +12,119 @@ type WebSocketMessage struct {
 }
 
 type IncomingMessagePayload struct {
-	Content         string `json:"content"`
-	ChannelID       string `json:"channel_id"`
-	ParentMessageID string `json:"parent_message_id,omitempty"`
+	Content         string   `json:"content"`
+	ChannelID       string   `json:"channel_id"`
+	ParentMessageID string   `json:"parent_message_id,omitempty"`
+	AttachmentIDs   []string `json:"attachment_ids,omitempty"`
 }
 
 type OutgoingMessagePayload struct {
-	ID              string             `json:"id"`
-	Content         string             `json:"content"`
-	SenderUserID    string             `json:"sender_user_id"`
-	IntegrationID   string             `json:"integration_id"`
-	ChannelID       string             `json:"channel_id"`
-	ParentMessageID string             `json:"parent_message_id,omitempty"`
-	Timestamp       time.Time          `json:"timestamp"`
-	SenderUser      *UserDTO           `json:"sender_user,omitempty"`
-	IntegrationBot  *IntegrationBotDTO `json:"integration_bot,omitempty"`
+	ID              string                      `json:"id"`
+	Content         string                      `json:"content"`
+	ContentRichText domain.RichText             `json:"content_rich_text"`
+	IsFormatted     bool                        `json:"is_formatted"`
+	Mentions        []string                    `json:"mentions,omitempty"`
+	Attachments     []domain.AttachmentDTO      `json:"attachments,omitempty"`
+	SenderUserID    string                      `json:"sender_user_id"`
+	IntegrationID   string                      `json:"integration_id"`
+	ChannelID       string                      `json:"channel_id"`
+	ParentMessageID string                      `json:"parent_message_id,omitempty"`
+	Timestamp       time.Time                   `json:"timestamp"`
+	EditedAt        *time.Time                  `json:"edited_at,omitempty"`
+	Poll            *domain.PollDTO             `json:"poll,omitempty"`
+	Reference       *domain.MessageReferenceDTO `json:"reference,omitempty"`
+	SenderUser      *UserDTO                    `json:"sender_user,omitempty"`
+	IntegrationBot  *IntegrationBotDTO          `json:"integration_bot,omitempty"`
+}
+
+type IncomingEditMessagePayload struct {
+	MessageID string `json:"message_id"`
+	ChannelID string `json:"channel_id"`
+	Content   string `json:"content"`
+}
+
+type IncomingDeleteMessagePayload struct {
+	MessageID string `json:"message_id"`
+	ChannelID string `json:"channel_id"`
+}
+
+type OutgoingMessageDeletedPayload struct {
+	ID        string    `json:"id"`
+	ChannelID string    `json:"channel_id"`
+	DeletedBy string    `json:"deleted_by"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type OutgoingPinPayload struct {
+	ChannelID string    `json:"channel_id"`
+	MessageID string    `json:"message_id"`
+	UserID    string    `json:"user_id"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type OutgoingMessageUnfurledPayload struct {
+	ChannelID    string                  `json:"channel_id"`
+	MessageID    string                  `json:"message_id"`
+	LinkPreviews []domain.LinkPreviewDTO `json:"link_previews"`
+}
+
+// OutgoingAttachmentProcessedPayload carries an attachment whose thumbnails and metadata are ready.
+// MessageID is empty while the upload has not been sent.
+type OutgoingAttachmentProcessedPayload struct {
+	ChannelID  string               `json:"channel_id"`
+	MessageID  string               `json:"message_id,omitempty"`
+	Attachment domain.AttachmentDTO `json:"attachment"`
+}
+
+type OutgoingMemberRemovedPayload struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+	RemovedBy string `json:"removed_by"`
+	Action    string `json:"action"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+type OutgoingCommandResponsePayload struct {
+	ChannelID string `json:"channel_id"`
+	Command   string `json:"command"`
+	Text      string `json:"text"`
+}
+
+type OutgoingMemberLeftPayload struct {
+	ChannelID string `json:"channel_id"`
+	UserID    string `json:"user_id"`
+}
+
+type OutgoingChannelUpdatedPayload struct {
+	ChannelID  string `json:"channel_id"`
+	Name       string `json:"name"`
+	Topic      string `json:"topic"`
+	IsArchived bool   `json:"is_archived"`
+	UpdatedBy  string `json:"updated_by"`
+}
+
+type IncomingChannelPayload struct {
+	ChannelID string `json:"channel_id"`
+}
+
+type IncomingSetTopicPayload struct {
+	ChannelID string `json:"channel_id"`
+	Topic     string `json:"topic"`
+}
+
+type IncomingRenameChannelPayload struct {
+	ChannelID string `json:"channel_id"`
+	Name      string `json:"name"`
+}
+
+type IncomingRemoveBotPayload struct {
+	ChannelID     string `json:"channel_id"`
+	IntegrationID string `json:"integration_id"`
+}
+
+type IncomingMarkReadPayload struct {
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
 }
 
 type IncomingReactionPayload struct {
@@ -33,6 +133,46 @@ type IncomingReactionPayload struct {
 	ReactionType string `json:"reaction_type"`
 }
 
+// IncomingMessageReferencePayload forwards or quotes a message of the source channel into ChannelID.
+// For quotes, ChannelID defaults to the source channel.
+type IncomingMessageReferencePayload struct {
+	SourceChannelID string `json:"source_channel_id"`
+	SourceMessageID string `json:"source_message_id"`
+	ChannelID       string `json:"channel_id,omitempty"`
+	Content         string `json:"content"`
+	ParentMessageID string `json:"parent_message_id,omitempty"`
+}
+
+type IncomingCreatePollPayload struct {
+	ChannelID       string     `json:"channel_id"`
+	Question        string     `json:"question"`
+	Options         []string   `json:"options"`
+	MultipleChoice  bool       `json:"multiple_choice"`
+	Anonymous       bool       `json:"anonymous"`
+	ClosesAt        *time.Time `json:"closes_at,omitempty"`
+	ParentMessageID string     `json:"parent_message_id,omitempty"`
+}
+
+type IncomingVotePollPayload struct {
+	ChannelID string   `json:"channel_id"`
+	MessageID string   `json:"message_id"`
+	OptionIDs []string `json:"option_ids"`
+}
+
+// IncomingPollPayload names the poll to retract a vote from or to close
+type IncomingPollPayload struct {
+	ChannelID string `json:"channel_id"`
+	MessageID string `json:"message_id"`
+}
+
+// OutgoingPollUpdatedPayload carries the live tally of a poll. voted_by_me is never set, as the payload is shared
+// by every member; clients track their own votes.
+type OutgoingPollUpdatedPayload struct {
+	ChannelID string         `json:"channel_id"`
+	MessageID string         `json:"message_id"`
+	Poll      domain.PollDTO `json:"poll"`
+}
+
 type OutgoingReactionPayload struct {
 	ID           string    `json:"id"`
 	MessageID    string    `json:"message_id"`
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	attachmentCleanupInterval  = time.Hour
+	attachmentCleanupBatchSize = 100
+)
+
+// AttachmentJanitor periodically removes uploads that were never sent with a message and the attachments
+// of deleted messages. Rows are deleted before their contents, so running several instances is safe.
+type AttachmentJanitor struct {
+	attachmentService *AttachmentService
+	interval          time.Duration
+	logger            *logging.Logger
+}
+
+func NewAttachmentJanitor(attachmentService *AttachmentService, logger *logging.Logger) *AttachmentJanitor {
+	return &AttachmentJanitor{
+		attachmentService: attachmentService,
+		interval:          attachmentCleanupInterval,
+		logger:            logger,
+	}
+}
+
+// Run cleans up attachments until the context is cancelled
+func (j *AttachmentJanitor) Run(ctx context.Context) {
+	logger := j.logger.WithMethod("Run")
+	logger.Info("Attachment janitor started", zap.Duration("interval", j.interval))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Attachment janitor stopped")
+			return
+		case <-ticker.C:
+			j.cleanup(ctx)
+		}
+	}
+}
+
+func (j *AttachmentJanitor) cleanup(ctx context.Context) {
+	now := time.Now().UTC()
+	for {
+		deleted, err := j.attachmentService.HandleDeleteExpiredAttachments(ctx, now, attachmentCleanupBatchSize)
+		if err != nil || deleted < attachmentCleanupBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
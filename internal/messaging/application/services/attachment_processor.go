@@ -0,0 +1,229 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/media"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/storage"
+	"github.com/m1thrandir225/meridian/pkg/common"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	attachmentProcessQueueSize = 256
+	// attachmentProcessWorkers is kept low, decoding large images takes a lot of memory
+	attachmentProcessWorkers = 2
+	attachmentProcessTimeout = time.Minute
+	// attachmentProcessRetryInterval is how often uploads still pending are queued again, e.g. after a restart
+	attachmentProcessRetryInterval = 5 * time.Minute
+	attachmentProcessRetryBatch    = 100
+)
+
+// ImageProcessor generates the thumbnails and metadata of an image
+type ImageProcessor interface {
+	Process(data []byte, contentType string) (*media.ProcessedImage, error)
+}
+
+// AttachmentProcessedNotifier tells clients that the thumbnails and metadata of an attachment are ready
+type AttachmentProcessedNotifier interface {
+	BroadcastAttachmentProcessed(attachment *domain.Attachment, dto domain.AttachmentDTO)
+}
+
+// AttachmentProcessor generates thumbnails and metadata for uploaded images in the background,
+// so uploads return as soon as the file is stored.
+type AttachmentProcessor struct {
+	repo      persistence.AttachmentRepository
+	blobStore storage.BlobStore
+	images    ImageProcessor
+	urlSigner *AttachmentURLSigner
+	queue     chan uuid.UUID
+	logger    *logging.Logger
+}
+
+func NewAttachmentProcessor(repo persistence.AttachmentRepository, blobStore storage.BlobStore, images ImageProcessor, urlSigner *AttachmentURLSigner, logger *logging.Logger) *AttachmentProcessor {
+	return &AttachmentProcessor{
+		repo:      repo,
+		blobStore: blobStore,
+		images:    images,
+		urlSigner: urlSigner,
+		queue:     make(chan uuid.UUID, attachmentProcessQueueSize),
+		logger:    logger,
+	}
+}
+
+// Enqueue schedules an attachment for processing if it needs it. When the queue is full the attachment
+// is left pending and picked up again by the periodic retry.
+func (p *AttachmentProcessor) Enqueue(attachment *domain.Attachment) {
+	if p == nil || !attachment.NeedsProcessing() {
+		return
+	}
+
+	select {
+	case p.queue <- attachment.GetId():
+	default:
+		p.logger.WithMethod("Enqueue").Warn("Attachment processing queue is full, deferring attachment", zap.String("attachment_id", attachment.GetId().String()))
+	}
+}
+
+// Run processes queued attachments until the context is cancelled and notifies the clients about each result
+func (p *AttachmentProcessor) Run(ctx context.Context, notifier AttachmentProcessedNotifier) {
+	logger := p.logger.WithMethod("Run")
+	logger.Info("Attachment processor started", zap.Int("workers", attachmentProcessWorkers))
+
+	var wg sync.WaitGroup
+	for range attachmentProcessWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case attachmentID := <-p.queue:
+					p.process(ctx, attachmentID, notifier)
+				}
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(attachmentProcessRetryInterval)
+	defer ticker.Stop()
+
+	p.enqueuePending(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			logger.Info("Attachment processor stopped")
+			return
+		case <-ticker.C:
+			p.enqueuePending(ctx)
+		}
+	}
+}
+
+// enqueuePending queues attachments that were left pending, skipping those uploaded moments ago which are still on their way
+func (p *AttachmentProcessor) enqueuePending(ctx context.Context) {
+	logger := p.logger.WithMethod("enqueuePending")
+
+	attachments, err := p.repo.FindPendingProcessing(ctx, time.Now().Add(-time.Minute), attachmentProcessRetryBatch)
+	if err != nil {
+		logger.Error("Failed to find attachments pending processing", zap.Error(err))
+		return
+	}
+
+	for i := range attachments {
+		p.Enqueue(&attachments[i])
+	}
+	if len(attachments) > 0 {
+		logger.Info("Queued pending attachments", zap.Int("count", len(attachments)))
+	}
+}
+
+func (p *AttachmentProcessor) process(ctx context.Context, attachmentID uuid.UUID, notifier AttachmentProcessedNotifier) {
+	logger := p.logger.WithMethod("process")
+	logger.Info("Processing attachment", zap.String("attachment_id", attachmentID.String()))
+
+	ctx, cancel := context.WithTimeout(ctx, attachmentProcessTimeout)
+	defer cancel()
+
+	attachment, err := p.repo.FindByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			logger.Info("Attachment was deleted before processing", zap.String("attachment_id", attachmentID.String()))
+			return
+		}
+		logger.Error("Failed to find attachment", zap.Error(err))
+		return
+	}
+	if !attachment.NeedsProcessing() {
+		return
+	}
+
+	thumbnails, err := p.generate(ctx, attachment)
+	if err != nil {
+		if ctx.Err() != nil {
+			logger.Warn("Processing was interrupted, attachment stays pending", zap.Error(err))
+			return
+		}
+		logger.Warn("Failed to process image", zap.String("attachment_id", attachmentID.String()), zap.Error(err))
+		if err := attachment.FailProcessing(); err != nil {
+			logger.Error("Failed to mark processing as failed", zap.Error(err))
+			return
+		}
+	}
+
+	saved, err := p.repo.SaveProcessingResult(ctx, attachment)
+	if err != nil {
+		// The thumbnails of an attachment that was processed twice or deleted meanwhile are not needed
+		p.deleteThumbnails(ctx, thumbnails)
+		if errors.Is(err, common.ErrConflict) {
+			logger.Info("Attachment changed while processing, dropping result", zap.String("attachment_id", attachmentID.String()))
+			return
+		}
+		logger.Error("Failed to save processing result", zap.Error(err))
+		return
+	}
+
+	if notifier != nil {
+		notifier.BroadcastAttachmentProcessed(saved, p.urlSigner.ToAttachmentDTO(saved))
+	}
+	logger.Info("Attachment processed",
+		zap.String("attachment_id", attachmentID.String()),
+		zap.String("status", string(saved.GetProcessingStatus())),
+		zap.Int("thumbnails", len(saved.GetThumbnails())))
+}
+
+// generate reads an image, stores its thumbnails and records them on the attachment. The stored thumbnails are
+// returned so they can be removed again if the result cannot be saved.
+func (p *AttachmentProcessor) generate(ctx context.Context, attachment *domain.Attachment) ([]domain.AttachmentThumbnail, error) {
+	content, err := p.blobStore.Open(ctx, attachment.GetStorageKey())
+	if err != nil {
+		return nil, fmt.Errorf("error opening image: %w", err)
+	}
+	data, err := io.ReadAll(io.LimitReader(content, domain.MaxAttachmentSize))
+	content.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error reading image: %w", err)
+	}
+
+	processed, err := p.images.Process(data, attachment.GetContentType())
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails := []domain.AttachmentThumbnail{}
+	for _, thumbnail := range processed.Thumbnails {
+		key := attachment.ThumbnailStorageKey(thumbnail.Name)
+		size, err := p.blobStore.Put(ctx, key, bytes.NewReader(thumbnail.Data))
+		if err != nil {
+			p.deleteThumbnails(ctx, thumbnails)
+			return nil, fmt.Errorf("error storing %s thumbnail: %w", thumbnail.Name, err)
+		}
+		thumbnails = append(thumbnails, domain.RehydrateAttachmentThumbnail(thumbnail.Name, thumbnail.Width, thumbnail.Height, thumbnail.ContentType, size, key))
+	}
+
+	if err := attachment.CompleteProcessing(processed.Width, processed.Height, processed.BlurHash, processed.DominantColor, thumbnails); err != nil {
+		p.deleteThumbnails(ctx, thumbnails)
+		return nil, err
+	}
+	return thumbnails, nil
+}
+
+func (p *AttachmentProcessor) deleteThumbnails(ctx context.Context, thumbnails []domain.AttachmentThumbnail) {
+	for _, thumbnail := range thumbnails {
+		if err := p.blobStore.Delete(ctx, thumbnail.GetStorageKey()); err != nil {
+			p.logger.WithMethod("deleteThumbnails").Warn("Failed to delete thumbnail", zap.String("storage_key", thumbnail.GetStorageKey()), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/media"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/storage"
+	"github.com/m1thrandir225/meridian/pkg/common"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// attachmentSniffLength is how much of an upload is read to detect its content type
+const attachmentSniffLength = 512
+
+type AttachmentService struct {
+	repo        persistence.AttachmentRepository
+	channelRepo persistence.ChannelRepository
+	blobStore   storage.BlobStore
+	urlSigner   *AttachmentURLSigner
+	processor   *AttachmentProcessor
+	logger      *logging.Logger
+}
+
+func NewAttachmentService(repo persistence.AttachmentRepository, channelRepo persistence.ChannelRepository, blobStore storage.BlobStore, urlSigner *AttachmentURLSigner, processor *AttachmentProcessor, logger *logging.Logger) *AttachmentService {
+	return &AttachmentService{
+		repo:        repo,
+		channelRepo: channelRepo,
+		blobStore:   blobStore,
+		urlSigner:   urlSigner,
+		processor:   processor,
+		logger:      logger,
+	}
+}
+
+// HandleUploadAttachment stores a file uploaded to a channel. The content type is detected from the
+// file itself, the type declared by the client is not trusted. The location a photo was taken at is
+// removed before it is stored, thumbnails are generated in the background.
+func (s *AttachmentService) HandleUploadAttachment(ctx context.Context, cmd domain.UploadAttachmentCommand) (*domain.Attachment, error) {
+	logger := s.logger.WithMethod("HandleUploadAttachment")
+	logger.Info("Uploading attachment", zap.String("channel_id", cmd.ChannelID.String()), zap.Int64("size", cmd.Size))
+
+	channel, err := s.channelRepo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	head := make([]byte, attachmentSniffLength)
+	n, err := io.ReadFull(cmd.Content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		logger.Error("Failed to read upload", zap.Error(err))
+		return nil, fmt.Errorf("error reading upload: %w", err)
+	}
+	head = head[:n]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		contentType = "application/octet-stream"
+	}
+
+	content := io.LimitReader(io.MultiReader(bytes.NewReader(head), cmd.Content), cmd.Size)
+	size := cmd.Size
+
+	// Images are read whole so their metadata can be rewritten, which may make them smaller
+	if domain.IsProcessableImageType(contentType) && size <= domain.MaxAttachmentSize {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			logger.Error("Failed to read upload", zap.Error(err))
+			return nil, fmt.Errorf("error reading upload: %w", err)
+		}
+		if int64(len(data)) != size {
+			logger.Error("Upload is shorter than its declared size", zap.Int("read", len(data)))
+			return nil, errors.New("upload was incomplete")
+		}
+		data = media.StripLocation(data, contentType)
+		content = bytes.NewReader(data)
+		size = int64(len(data))
+	}
+
+	attachment, err := domain.NewAttachment(channel, cmd.UploaderID, cmd.FileName, contentType, size)
+	if err != nil {
+		logger.Error("Failed to create attachment", zap.Error(err))
+		return nil, err
+	}
+
+	written, err := s.blobStore.Put(ctx, attachment.GetStorageKey(), content)
+	if err != nil {
+		logger.Error("Failed to store attachment", zap.Error(err))
+		return nil, err
+	}
+	if written != attachment.GetSize() {
+		logger.Error("Upload is shorter than its declared size", zap.Int64("written", written))
+		s.deleteBlob(ctx, attachment)
+		return nil, errors.New("upload was incomplete")
+	}
+
+	if err := s.repo.Save(ctx, attachment); err != nil {
+		logger.Error("Failed to save attachment", zap.Error(err))
+		s.deleteBlob(ctx, attachment)
+		return nil, err
+	}
+	s.processor.Enqueue(attachment)
+
+	logger.Info("Attachment uploaded", zap.String("attachment_id", attachment.GetId().String()), zap.String("content_type", contentType))
+	return attachment, nil
+}
+
+// HandleGetAttachment returns an attachment the user may download
+func (s *AttachmentService) HandleGetAttachment(ctx context.Context, cmd domain.GetAttachmentURLCommand) (*domain.Attachment, error) {
+	logger := s.logger.WithMethod("HandleGetAttachment")
+	logger.Info("Getting attachment", zap.String("attachment_id", cmd.AttachmentID.String()))
+
+	attachment, err := s.repo.FindByID(ctx, cmd.AttachmentID)
+	if err != nil {
+		logger.Error("Failed to find attachment", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := s.channelRepo.FindById(ctx, attachment.GetChannelId())
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	// Users without access are told the attachment does not exist, so IDs cannot be probed
+	if !attachment.CanBeViewedBy(channel, cmd.UserID) {
+		logger.Info("User may not view attachment", zap.String("user_id", cmd.UserID.String()))
+		return nil, fmt.Errorf("attachment with ID %s not found: %w", cmd.AttachmentID, common.ErrNotFound)
+	}
+
+	return attachment, nil
+}
+
+// HandleOpenAttachment checks a signed download URL and opens the attachment's contents, or those of the thumbnail
+// named by the variant. The thumbnail is nil for the original file. The caller closes the reader.
+func (s *AttachmentService) HandleOpenAttachment(ctx context.Context, attachmentID uuid.UUID, variant string, expires int64, signature string) (*domain.Attachment, *domain.AttachmentThumbnail, io.ReadCloser, error) {
+	logger := s.logger.WithMethod("HandleOpenAttachment")
+	logger.Info("Opening attachment", zap.String("attachment_id", attachmentID.String()), zap.String("variant", variant))
+
+	if err := s.urlSigner.Verify(attachmentID, variant, expires, signature); err != nil {
+		logger.Info("Rejected attachment URL", zap.Error(err))
+		return nil, nil, nil, err
+	}
+
+	attachment, err := s.repo.FindByID(ctx, attachmentID)
+	if err != nil {
+		logger.Error("Failed to find attachment", zap.Error(err))
+		return nil, nil, nil, err
+	}
+
+	storageKey := attachment.GetStorageKey()
+	var thumbnail *domain.AttachmentThumbnail
+	if variant != "" {
+		thumbnail = attachment.FindThumbnail(variant)
+		if thumbnail == nil {
+			logger.Info("Attachment has no such thumbnail")
+			return nil, nil, nil, fmt.Errorf("%s thumbnail of attachment %s not found: %w", variant, attachmentID, common.ErrNotFound)
+		}
+		storageKey = thumbnail.GetStorageKey()
+	}
+
+	content, err := s.blobStore.Open(ctx, storageKey)
+	if err != nil {
+		logger.Error("Failed to open attachment", zap.Error(err))
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, nil, nil, fmt.Errorf("contents of attachment %s not found: %w", attachmentID, common.ErrNotFound)
+		}
+		return nil, nil, nil, err
+	}
+
+	return attachment, thumbnail, content, nil
+}
+
+// HandleDeleteExpiredAttachments removes up to limit uploads that were never sent and attachments of deleted
+// messages, together with their contents. It returns how many attachments were removed.
+func (s *AttachmentService) HandleDeleteExpiredAttachments(ctx context.Context, now time.Time, limit int) (int, error) {
+	logger := s.logger.WithMethod("HandleDeleteExpiredAttachments")
+
+	attachments, err := s.repo.DeleteExpired(ctx, now.Add(-domain.UnattachedUploadLifetime), limit)
+	if err != nil {
+		logger.Error("Failed to delete expired attachments", zap.Error(err))
+		return 0, err
+	}
+
+	for i := range attachments {
+		s.deleteBlob(ctx, &attachments[i])
+	}
+
+	if len(attachments) > 0 {
+		logger.Info("Expired attachments deleted", zap.Int("count", len(attachments)))
+	}
+	return len(attachments), nil
+}
+
+// ToAttachmentDTO returns the attachment as a DTO with a freshly signed download URL
+func (s *AttachmentService) ToAttachmentDTO(attachment *domain.Attachment) domain.AttachmentDTO {
+	return s.urlSigner.ToAttachmentDTO(attachment)
+}
+
+// deleteBlob removes the contents of an attachment and its thumbnails
+func (s *AttachmentService) deleteBlob(ctx context.Context, attachment *domain.Attachment) {
+	keys := []string{attachment.GetStorageKey()}
+	for _, thumbnail := range attachment.GetThumbnails() {
+		keys = append(keys, thumbnail.GetStorageKey())
+	}
+
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			s.logger.WithMethod("deleteBlob").Warn("Failed to delete attachment contents",
+				zap.String("attachment_id", attachment.GetId().String()), zap.String("storage_key", key), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+const (
+	// DefaultAttachmentURLTTL is how long a signed download URL stays valid
+	DefaultAttachmentURLTTL = 15 * time.Minute
+	// attachmentURLExpiryStep rounds expiry times up, so the same URL is handed out for a while and browsers can cache it
+	attachmentURLExpiryStep = time.Minute
+	attachmentDownloadPath  = "/api/v1/messages/attachments/%s/download"
+)
+
+var (
+	ErrInvalidAttachmentSignature = errors.New("invalid attachment signature")
+	ErrAttachmentURLExpired       = errors.New("attachment url has expired")
+)
+
+// AttachmentURLSigner issues time-limited download URLs for attachments and their thumbnails. The URLs carry an HMAC
+// of the attachment ID, variant and expiry time, so downloads need no session and can be used directly in <img> tags.
+type AttachmentURLSigner struct {
+	key []byte
+	ttl time.Duration
+}
+
+func NewAttachmentURLSigner(key []byte, ttl time.Duration) *AttachmentURLSigner {
+	if ttl <= 0 {
+		ttl = DefaultAttachmentURLTTL
+	}
+	return &AttachmentURLSigner{
+		key: key,
+		ttl: ttl,
+	}
+}
+
+// Sign returns the download URL of an attachment, relative to the API host, and when it expires.
+// The variant names a thumbnail, an empty variant is the original file.
+func (s *AttachmentURLSigner) Sign(attachmentID uuid.UUID, variant string) (string, time.Time) {
+	expiresAt := time.Now().Add(s.ttl).Truncate(attachmentURLExpiryStep).Add(attachmentURLExpiryStep).UTC()
+	expires := expiresAt.Unix()
+
+	query := url.Values{}
+	if variant != "" {
+		query.Set("variant", variant)
+	}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.signature(attachmentID, variant, expires))
+
+	return fmt.Sprintf(attachmentDownloadPath, attachmentID) + "?" + query.Encode(), expiresAt
+}
+
+// Verify checks that a download URL was issued by this signer for the attachment variant and has not expired
+func (s *AttachmentURLSigner) Verify(attachmentID uuid.UUID, variant string, expires int64, signature string) error {
+	expected := s.signature(attachmentID, variant, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidAttachmentSignature
+	}
+	if time.Now().Unix() > expires {
+		return ErrAttachmentURLExpired
+	}
+	return nil
+}
+
+func (s *AttachmentURLSigner) signature(attachmentID uuid.UUID, variant string, expires int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(attachmentID.String()))
+	mac.Write([]byte{':'})
+	if variant != "" {
+		mac.Write([]byte(variant))
+		mac.Write([]byte{':'})
+	}
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ToAttachmentDTO returns the attachment as a DTO with signed download URLs for the file and its thumbnails
+func (s *AttachmentURLSigner) ToAttachmentDTO(attachment *domain.Attachment) domain.AttachmentDTO {
+	dto := domain.ToAttachmentDTO(attachment)
+	downloadURL, expiresAt := s.Sign(attachment.GetId(), "")
+	dto.URL = downloadURL
+	dto.URLExpiresAt = &expiresAt
+	for i := range dto.Thumbnails {
+		dto.Thumbnails[i].URL, _ = s.Sign(attachment.GetId(), dto.Thumbnails[i].Name)
+	}
+	return dto
+}
+
+func (s *AttachmentURLSigner) ToAttachmentDTOs(attachments []domain.Attachment) []domain.AttachmentDTO {
+	dtos := make([]domain.AttachmentDTO, len(attachments))
+	for i := range attachments {
+		dtos[i] = s.ToAttachmentDTO(&attachments[i])
+	}
+	return dtos
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+type BookmarkService struct {
+	repo        persistence.BookmarkRepository
+	channelRepo persistence.ChannelRepository
+	logger      *logging.Logger
+}
+
+func NewBookmarkService(repo persistence.BookmarkRepository, channelRepo persistence.ChannelRepository, logger *logging.Logger) *BookmarkService {
+	return &BookmarkService{
+		repo:        repo,
+		channelRepo: channelRepo,
+		logger:      logger,
+	}
+}
+
+// HandleAddBookmark saves a message for the user
+func (s *BookmarkService) HandleAddBookmark(ctx context.Context, cmd domain.AddBookmarkCommand) (*domain.Bookmark, error) {
+	logger := s.logger.WithMethod("HandleAddBookmark")
+	logger.Info("Adding bookmark", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.channelRepo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.channelRepo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+
+	bookmark, err := domain.NewBookmark(cmd.UserID, channel, message, cmd.Note)
+	if err != nil {
+		logger.Error("Failed to create bookmark", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, bookmark); err != nil {
+		logger.Error("Failed to save bookmark", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Bookmark added", zap.String("bookmark_id", bookmark.GetId().String()))
+	return bookmark, nil
+}
+
+// HandleRemoveBookmark deletes one of the user's bookmarks
+func (s *BookmarkService) HandleRemoveBookmark(ctx context.Context, cmd domain.RemoveBookmarkCommand) error {
+	logger := s.logger.WithMethod("HandleRemoveBookmark")
+	logger.Info("Removing bookmark", zap.String("bookmark_id", cmd.BookmarkID.String()))
+
+	if err := s.repo.Delete(ctx, cmd.UserID, cmd.BookmarkID); err != nil {
+		logger.Error("Failed to delete bookmark", zap.Error(err))
+		return err
+	}
+
+	logger.Info("Bookmark removed", zap.String("bookmark_id", cmd.BookmarkID.String()))
+	return nil
+}
+
+// HandleListBookmarks returns a page of the user's visible bookmarks together with their messages
+func (s *BookmarkService) HandleListBookmarks(ctx context.Context, cmd domain.ListBookmarksCommand) (*domain.BookmarkPage, error) {
+	logger := s.logger.WithMethod("HandleListBookmarks")
+	logger.Info("Listing bookmarks", zap.String("user_id", cmd.UserID.String()))
+
+	page, err := s.repo.FindByUser(ctx, cmd.UserID, cmd.Query)
+	if err != nil {
+		logger.Error("Failed to find bookmarks", zap.Error(err))
+		return nil, err
+	}
+
+	messageIDs := make([]uuid.UUID, len(page.Bookmarks))
+	for i, bookmark := range page.Bookmarks {
+		messageIDs[i] = bookmark.GetMessageId()
+	}
+
+	messages, err := s.channelRepo.FindMessagesByIDs(ctx, messageIDs)
+	if err != nil {
+		logger.Error("Failed to load bookmarked messages", zap.Error(err))
+		return nil, err
+	}
+
+	byID := make(map[uuid.UUID]*domain.Message, len(messages))
+	for i := range messages {
+		byID[messages[i].GetId()] = &messages[i]
+	}
+	for i := range page.Bookmarks {
+		page.Bookmarks[i].SetLoadedMessage(byID[page.Bookmarks[i].GetMessageId()])
+	}
+
+	logger.Info("Bookmarks listed", zap.Int("count", len(page.Bookmarks)))
+	return page, nil
+}
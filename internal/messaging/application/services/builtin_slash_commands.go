@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+const (
+	shrugEmoticon        = `¯\_(ツ)_/¯`
+	slashInviteLifetime  = 7 * 24 * time.Hour
+	slashMaxTopicLength  = 250
+	slashTimestampFormat = "Jan 2, 15:04 MST"
+)
+
+// shrugMarkdown is shrugEmoticon with the backslash and underscores escaped, so the arm survives Markdown parsing
+const shrugMarkdown = `¯\\\_(ツ)\_/¯`
+
+// builtinSlashCommands implements the commands every channel supports out of the box
+type builtinSlashCommands struct {
+	registry        *SlashCommandRegistry
+	channelService  *ChannelService
+	messageService  *MessageService
+	reminderService *ReminderService
+}
+
+// RegisterBuiltinSlashCommands registers /help, /topic, /invite, /leave, /me, /remind and /shrug
+func RegisterBuiltinSlashCommands(registry *SlashCommandRegistry, channelService *ChannelService, messageService *MessageService, reminderService *ReminderService) error {
+	b := &builtinSlashCommands{
+		registry:        registry,
+		channelService:  channelService,
+		messageService:  messageService,
+		reminderService: reminderService,
+	}
+
+	commands := []SlashCommand{
+		{Name: "help", Usage: "/help", Description: "List the available commands", Handler: b.help},
+		{Name: "topic", Usage: "/topic [new topic]", Description: "Show or change the channel topic", Handler: b.topic},
+		{Name: "invite", Usage: "/invite [max uses]", Description: "Create an invite code valid for 7 days", Handler: b.invite},
+		{Name: "leave", Usage: "/leave", Description: "Leave the channel", Handler: b.leave},
+		{Name: "me", Usage: "/me <action>", Description: "Post an action, e.g. /me is out for lunch", Handler: b.me},
+		{Name: "remind", Usage: "/remind <30m|2h|1d|RFC 3339 time>", Description: "Remind you about the thread, or the latest message, later", Handler: b.remind},
+		{Name: "shrug", Usage: "/shrug [message]", Description: "Append " + shrugEmoticon + " to your message", Handler: b.shrug},
+	}
+	for _, command := range commands {
+		if err := registry.Register(command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinSlashCommands) help(_ context.Context, _ domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	var lines []string
+	for _, command := range b.registry.Commands() {
+		lines = append(lines, fmt.Sprintf("%s - %s", command.Usage, command.Description))
+	}
+	return domain.NewEphemeralResult(strings.Join(lines, "\n")), nil
+}
+
+func (b *builtinSlashCommands) topic(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	if invocation.Args == "" {
+		channel, err := b.channelService.HandleGetChannel(ctx, domain.GetChannelCommand{
+			ChannelID: invocation.ChannelID,
+			UserID:    invocation.UserID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !channel.IsMember(invocation.UserID) {
+			return nil, domain.ErrPermissionDenied
+		}
+		if channel.Topic == "" {
+			return domain.NewEphemeralResult("This channel has no topic."), nil
+		}
+		return domain.NewEphemeralResult("Topic: " + channel.Topic), nil
+	}
+
+	if len(invocation.Args) > slashMaxTopicLength {
+		return domain.NewEphemeralResult(fmt.Sprintf("The topic cannot be longer than %d characters.", slashMaxTopicLength)), nil
+	}
+
+	channel, err := b.channelService.HandleSetChannelTopic(ctx, domain.SetChannelTopicCommand{
+		ChannelID: invocation.ChannelID,
+		UserID:    invocation.UserID,
+		Topic:     invocation.Args,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SlashCommandResult{UpdatedChannel: channel}, nil
+}
+
+func (b *builtinSlashCommands) invite(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	var maxUses *int
+	if invocation.Args != "" {
+		uses, err := strconv.Atoi(invocation.Args)
+		if err != nil || uses <= 0 {
+			return domain.NewEphemeralResult("Usage: /invite [max uses], where max uses is a positive number."), nil
+		}
+		maxUses = &uses
+	}
+
+	_, invite, err := b.channelService.HandleCreateChannelInvite(ctx, domain.CreateChannelInviteCommand{
+		ChannelID:       invocation.ChannelID,
+		CreatedByUserID: invocation.UserID,
+		ExpiresAt:       time.Now().UTC().Add(slashInviteLifetime),
+		MaxUses:         maxUses,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	text := fmt.Sprintf("Invite code %s, valid until %s", invite.GetInviteCode(), invite.GetExpiresAt().Format(slashTimestampFormat))
+	if maxUses != nil {
+		text += fmt.Sprintf(" for %d uses", *maxUses)
+	}
+	return domain.NewEphemeralResult(text + "."), nil
+}
+
+func (b *builtinSlashCommands) leave(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	_, err := b.channelService.HandleLeaveChannel(ctx, domain.LeaveChannelCommand{
+		ChannelID: invocation.ChannelID,
+		UserID:    invocation.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SlashCommandResult{LeftChannel: true}, nil
+}
+
+func (b *builtinSlashCommands) me(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	if invocation.Args == "" {
+		return domain.NewEphemeralResult("Usage: /me <action>"), nil
+	}
+	return b.post(ctx, invocation, "_"+invocation.Args+"_")
+}
+
+func (b *builtinSlashCommands) shrug(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	return b.post(ctx, invocation, strings.TrimSpace(invocation.Args+" "+shrugMarkdown))
+}
+
+func (b *builtinSlashCommands) remind(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	remindAt, err := parseSlashRemindTime(invocation.Args, time.Now().UTC())
+	if err != nil {
+		return domain.NewEphemeralResult("Usage: /remind <30m|2h|1d|RFC 3339 time>"), nil
+	}
+
+	// In a thread the reminder is about the thread, otherwise about the latest message in the channel
+	messageID := invocation.ParentMessageID
+	if messageID == nil {
+		page, err := b.messageService.HandleListMessages(ctx, domain.ListMessagesForChannelCommand{
+			ChannelID: invocation.ChannelID,
+			UserID:    invocation.UserID,
+			Query:     domain.MessageQuery{Limit: 1},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Messages) == 0 {
+			return domain.NewEphemeralResult("There is no message to be reminded about yet."), nil
+		}
+		latestID := page.Messages[len(page.Messages)-1].GetId()
+		messageID = &latestID
+	}
+
+	reminder, err := b.reminderService.HandleSetReminder(ctx, domain.SetReminderCommand{
+		UserID:    invocation.UserID,
+		ChannelID: invocation.ChannelID,
+		MessageID: *messageID,
+		RemindAt:  remindAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewEphemeralResult("I'll remind you about this message on " + reminder.GetRemindAt().Format(slashTimestampFormat) + "."), nil
+}
+
+func (b *builtinSlashCommands) post(ctx context.Context, invocation domain.SlashCommandInvocation, text string) (*domain.SlashCommandResult, error) {
+	message, err := b.messageService.HandleMessageSent(ctx, domain.SendMessageCommand{
+		ChannelID:       invocation.ChannelID,
+		SenderUserID:    invocation.UserID,
+		Content:         domain.NewMessageContent(text),
+		ParentMessageID: invocation.ParentMessageID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SlashCommandResult{PostedMessage: message}, nil
+}
+
+// parseSlashRemindTime accepts a delay such as 30m, 2h, 1h30m or 3d, optionally prefixed with "in", or an RFC 3339 time
+func parseSlashRemindTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "in "))
+	if value == "" {
+		return time.Time{}, errors.New("missing reminder time")
+	}
+
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err == nil && count > 0 {
+			return now.AddDate(0, 0, count), nil
+		}
+	}
+	if delay, err := time.ParseDuration(value); err == nil && delay > 0 {
+		return now.Add(delay), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -2,11 +2,13 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/pkg/common"
 	"github.com/m1thrandir225/meridian/pkg/kafka"
 	"github.com/m1thrandir225/meridian/pkg/logging"
 	"go.uber.org/zap"
@@ -48,12 +50,62 @@ func (s *ChannelService) HandleGetUserChannels(ctx context.Context, cmd domain.G
 	return channels, nil
 }
 
+// HandleGetUnreadCounts returns the unread and mention counts for every channel of a user
+func (s *ChannelService) HandleGetUnreadCounts(ctx context.Context, cmd domain.GetUnreadCountsCommand) (map[uuid.UUID]domain.UnreadCounts, error) {
+	logger := s.logger.WithMethod("HandleGetUnreadCounts")
+	logger.Info("Getting unread counts", zap.String("user_id", cmd.UserID.String()))
+
+	counts, err := s.repo.FindUnreadCounts(ctx, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to get unread counts", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Unread counts retrieved", zap.Int("count", len(counts)))
+	return counts, nil
+}
+
+// HandleMarkRead advances the member's read marker to the given message.
+// The returned bool reports whether the marker moved.
+func (s *ChannelService) HandleMarkRead(ctx context.Context, cmd domain.MarkReadCommand) (*domain.Member, bool, error) {
+	logger := s.logger.WithMethod("HandleMarkRead")
+	logger.Info("Marking channel as read", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return nil, false, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, false, err
+	}
+
+	member, advanced, err := channel.MarkRead(cmd.UserID, message)
+	if err != nil {
+		logger.Error("Failed to mark channel as read", zap.Error(err))
+		return nil, false, err
+	}
+
+	if advanced {
+		if err := s.repo.UpdateReadMarker(ctx, channel.ID, member); err != nil {
+			logger.Error("Failed to update read marker", zap.Error(err))
+			return nil, false, err
+		}
+	}
+
+	logger.Info("Read marker processed", zap.Bool("advanced", advanced))
+	return member, advanced, nil
+}
+
 // HandleCreateChannel creates a new channel and publishes the events
 func (s *ChannelService) HandleCreateChannel(ctx context.Context, cmd domain.CreateChannelCommand) (*domain.Channel, error) {
 	logger := s.logger.WithMethod("HandleCreateChannel")
 	logger.Info("Creating channel")
 
-	channel, err := domain.NewChannel(cmd.Name, cmd.Topic, cmd.CreatorUserID)
+	channel, err := domain.NewChannel(cmd.Name, cmd.Topic, cmd.Visibility, cmd.CreatorUserID)
 	if err != nil {
 		logger.Error("Failed to create channel", zap.Error(err))
 		return nil, err
@@ -75,7 +127,84 @@ func (s *ChannelService) HandleCreateChannel(ctx context.Context, cmd domain.Cre
 	return channel, nil
 }
 
-// HandleGetChannel returns the channel and publishes the events
+// HandleListChannelDirectory returns a page of the public channel directory
+func (s *ChannelService) HandleListChannelDirectory(ctx context.Context, cmd domain.ListChannelDirectoryCommand) (*domain.ChannelDirectoryPage, error) {
+	logger := s.logger.WithMethod("HandleListChannelDirectory")
+	logger.Info("Listing channel directory", zap.String("search", cmd.Query.Search))
+
+	page, err := s.repo.FindDirectory(ctx, cmd.Query)
+	if err != nil {
+		logger.Error("Failed to list channel directory", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Channel directory listed", zap.Int("count", len(page.Channels)))
+	return page, nil
+}
+
+// HandleOpenDirectChannel returns the direct conversation between the user and the participants,
+// creating it if it does not exist yet. The returned bool reports whether it was created.
+func (s *ChannelService) HandleOpenDirectChannel(ctx context.Context, cmd domain.OpenDirectChannelCommand) (*domain.Channel, bool, error) {
+	logger := s.logger.WithMethod("HandleOpenDirectChannel")
+	logger.Info("Opening direct channel", zap.String("user_id", cmd.UserID.String()), zap.Int("participants", len(cmd.ParticipantIDs)))
+
+	channel, err := domain.NewDirectChannel(cmd.UserID, cmd.ParticipantIDs)
+	if err != nil {
+		logger.Error("Failed to create direct channel", zap.Error(err))
+		return nil, false, err
+	}
+
+	existing, err := s.repo.FindByDirectKey(ctx, channel.DirectKey)
+	if err == nil {
+		logger.Info("Direct channel already exists", zap.String("channel_id", existing.ID.String()))
+		return existing, false, nil
+	}
+	if !errors.Is(err, common.ErrNotFound) {
+		logger.Error("Failed to find direct channel", zap.Error(err))
+		return nil, false, err
+	}
+
+	userIDs := make([]string, 0, len(channel.Members))
+	for _, member := range channel.Members {
+		userIDs = append(userIDs, member.GetId().String())
+	}
+	usersResp, err := s.identityClient.GetUsers(ctx, userIDs)
+	if err != nil {
+		logger.Error("Failed to fetch user information", zap.Error(err))
+		return nil, false, fmt.Errorf("failed to fetch user information: %w", err)
+	}
+	if len(usersResp.Users) != len(userIDs) {
+		logger.Error("Direct channel participant not found", zap.Int("requested", len(userIDs)), zap.Int("found", len(usersResp.Users)))
+		return nil, false, fmt.Errorf("one or more participants do not exist: %w", common.ErrNotFound)
+	}
+
+	if err := s.repo.Save(ctx, channel); err != nil {
+		if errors.Is(err, common.ErrConflict) {
+			// Another request opened the same conversation concurrently
+			existing, findErr := s.repo.FindByDirectKey(ctx, channel.DirectKey)
+			if findErr != nil {
+				logger.Error("Failed to find direct channel", zap.Error(findErr))
+				return nil, false, findErr
+			}
+			return existing, false, nil
+		}
+		logger.Error("Failed to save direct channel", zap.Error(err))
+		return nil, false, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, false, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Direct channel created", zap.String("channel_id", channel.ID.String()))
+	return channel, true, nil
+}
+
+// HandleGetChannel returns the channel and publishes the events.
+// Private channels and direct conversations are reported as not found to non-members.
 func (s *ChannelService) HandleGetChannel(ctx context.Context, cmd domain.GetChannelCommand) (*domain.Channel, error) {
 	logger := s.logger.WithMethod("HandleGetChannel")
 	logger.Info("Getting channel")
@@ -86,6 +215,11 @@ func (s *ChannelService) HandleGetChannel(ctx context.Context, cmd domain.GetCha
 		return nil, err
 	}
 
+	if !channel.CanUserRead(cmd.UserID) {
+		logger.Warn("Channel is not readable by user", zap.String("channel_id", cmd.ChannelID.String()), zap.String("user_id", cmd.UserID.String()))
+		return nil, fmt.Errorf("channel with ID %s: %w", cmd.ChannelID, common.ErrNotFound)
+	}
+
 	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
 	if err != nil {
 		logger.Error("Failed to publish events", zap.Error(err))
@@ -108,9 +242,9 @@ func (s *ChannelService) HandleAddBotToChannel(ctx context.Context, cmd domain.A
 		return nil, err
 	}
 
-	if channel.CreatorUserID != cmd.RequestorID {
-		logger.Error("Only the channel creator can add a bot to the channel")
-		return nil, fmt.Errorf("only the channel creator can add a bot to the channel")
+	if err := channel.Authorize(cmd.RequestorID, domain.PermissionManageBots); err != nil {
+		logger.Error("User is not allowed to add a bot to the channel", zap.Error(err))
+		return nil, err
 	}
 
 	err = channel.AddBotMember(cmd.IntegrationID)
@@ -145,9 +279,9 @@ func (s *ChannelService) HandleRemoveBotFromChannel(ctx context.Context, cmd dom
 		return nil, err
 	}
 
-	if channel.CreatorUserID != cmd.RequestorID {
-		logger.Error("Only the channel creator can remove a bot from the channel")
-		return nil, fmt.Errorf("only the channel creator can remove a bot from the channel")
+	if err := channel.Authorize(cmd.RequestorID, domain.PermissionManageBots); err != nil {
+		logger.Error("User is not allowed to remove a bot from the channel", zap.Error(err))
+		return nil, err
 	}
 
 	err = channel.RemoveMember(cmd.IntegrationID)
@@ -183,7 +317,7 @@ func (s *ChannelService) HandleJoinChannel(ctx context.Context, cmd domain.JoinC
 		return nil, err
 	}
 
-	err = channel.AddMember(cmd.UserID)
+	err = channel.Join(cmd.UserID)
 	if err != nil {
 		logger.Error("Failed to add member to channel", zap.Error(err))
 		return nil, err
@@ -238,6 +372,245 @@ func (s *ChannelService) HandleLeaveChannel(ctx context.Context, cmd domain.Leav
 	return channel, nil
 }
 
+// HandleChangeMemberRole promotes or demotes a channel member and publishes the events
+func (s *ChannelService) HandleChangeMemberRole(ctx context.Context, cmd domain.ChangeMemberRoleCommand) (*domain.Member, error) {
+	logger := s.logger.WithMethod("HandleChangeMemberRole")
+	logger.Info("Changing member role", zap.String("channel_id", cmd.ChannelID.String()), zap.String("target_user_id", cmd.TargetUserID.String()), zap.String("role", string(cmd.Role)))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return nil, err
+	}
+
+	var member *domain.Member
+	if cmd.Promote {
+		member, err = channel.PromoteMember(cmd.RequestorID, cmd.TargetUserID, cmd.Role)
+	} else {
+		member, err = channel.DemoteMember(cmd.RequestorID, cmd.TargetUserID, cmd.Role)
+	}
+	if err != nil {
+		logger.Error("Failed to change member role", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, channel); err != nil {
+		logger.Error("Failed to save channel", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Member role changed", zap.String("channel_id", channel.ID.String()))
+	return member, nil
+}
+
+// HandleTransferOwnership hands a channel over to another member and publishes the events
+func (s *ChannelService) HandleTransferOwnership(ctx context.Context, cmd domain.TransferOwnershipCommand) (*domain.Channel, error) {
+	logger := s.logger.WithMethod("HandleTransferOwnership")
+	logger.Info("Transferring channel ownership", zap.String("channel_id", cmd.ChannelID.String()), zap.String("new_owner_id", cmd.NewOwnerID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return nil, err
+	}
+
+	err = channel.TransferOwnership(cmd.RequestorID, cmd.NewOwnerID)
+	if err != nil {
+		logger.Error("Failed to transfer channel ownership", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, channel); err != nil {
+		logger.Error("Failed to save channel", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Channel ownership transferred", zap.String("channel_id", channel.ID.String()))
+	return channel, nil
+}
+
+// HandleUserDeleted removes a deleted user from every channel and direct conversation they belong to,
+// handing over or archiving the channels they owned.
+// Channels are processed independently so one failure does not block the others.
+func (s *ChannelService) HandleUserDeleted(ctx context.Context, cmd domain.HandleUserDeletedCommand) error {
+	logger := s.logger.WithMethod("HandleUserDeleted")
+	logger.Info("Removing deleted user from channels", zap.String("user_id", cmd.UserID.String()))
+
+	channels, err := s.repo.FindChannelsWithMember(ctx, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to get user channels", zap.Error(err))
+		return err
+	}
+
+	var errs []error
+	for _, channel := range channels {
+		newOwner, archived, err := channel.RemoveDeletedUser(cmd.UserID)
+		if err != nil {
+			logger.Error("Failed to remove deleted user", zap.String("channel_id", channel.ID.String()), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := s.repo.Save(ctx, channel); err != nil {
+			logger.Error("Failed to save channel", zap.String("channel_id", channel.ID.String()), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := s.eventPub.PublishEvents(ctx, channel.GetPendingEvents()); err != nil {
+			logger.Error("Failed to publish events", zap.String("channel_id", channel.ID.String()), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		channel.ClearPendingEvents()
+
+		switch {
+		case newOwner != nil:
+			logger.Info("Channel handed over", zap.String("channel_id", channel.ID.String()), zap.String("new_owner_id", newOwner.GetId().String()))
+		case archived:
+			logger.Warn("No member can take over the channel, archived it", zap.String("channel_id", channel.ID.String()))
+		default:
+			logger.Info("Deleted user removed from channel", zap.String("channel_id", channel.ID.String()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// HandleKickMember removes a member from the channel and publishes the events
+func (s *ChannelService) HandleKickMember(ctx context.Context, cmd domain.KickMemberCommand) error {
+	logger := s.logger.WithMethod("HandleKickMember")
+	logger.Info("Kicking member", zap.String("channel_id", cmd.ChannelID.String()), zap.String("target_user_id", cmd.TargetUserID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return err
+	}
+
+	err = channel.KickMember(cmd.RequestorID, cmd.TargetUserID)
+	if err != nil {
+		logger.Error("Failed to kick member", zap.Error(err))
+		return err
+	}
+
+	if err := s.repo.Save(ctx, channel); err != nil {
+		logger.Error("Failed to save channel", zap.Error(err))
+		return err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Member kicked", zap.String("channel_id", channel.ID.String()))
+	return nil
+}
+
+// HandleBanMember bans a user from the channel, removing them if they are a member, and publishes the events
+func (s *ChannelService) HandleBanMember(ctx context.Context, cmd domain.BanMemberCommand) (*domain.ChannelBan, error) {
+	logger := s.logger.WithMethod("HandleBanMember")
+	logger.Info("Banning member", zap.String("channel_id", cmd.ChannelID.String()), zap.String("target_user_id", cmd.TargetUserID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return nil, err
+	}
+
+	ban, err := channel.BanMember(cmd.RequestorID, cmd.TargetUserID, cmd.Reason, cmd.ExpiresAt)
+	if err != nil {
+		logger.Error("Failed to ban member", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, channel); err != nil {
+		logger.Error("Failed to save channel", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Member banned", zap.String("channel_id", channel.ID.String()))
+	return ban, nil
+}
+
+// HandleUnbanMember lifts a user's ban and publishes the events
+func (s *ChannelService) HandleUnbanMember(ctx context.Context, cmd domain.UnbanMemberCommand) error {
+	logger := s.logger.WithMethod("HandleUnbanMember")
+	logger.Info("Unbanning member", zap.String("channel_id", cmd.ChannelID.String()), zap.String("target_user_id", cmd.TargetUserID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return err
+	}
+
+	err = channel.UnbanMember(cmd.RequestorID, cmd.TargetUserID)
+	if err != nil {
+		logger.Error("Failed to unban member", zap.Error(err))
+		return err
+	}
+
+	if err := s.repo.Save(ctx, channel); err != nil {
+		logger.Error("Failed to save channel", zap.Error(err))
+		return err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Member unbanned", zap.String("channel_id", channel.ID.String()))
+	return nil
+}
+
+// HandleListBans returns the active bans of a channel
+func (s *ChannelService) HandleListBans(ctx context.Context, cmd domain.ListBansCommand) ([]domain.ChannelBan, error) {
+	logger := s.logger.WithMethod("HandleListBans")
+	logger.Info("Listing channel bans", zap.String("channel_id", cmd.ChannelID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return nil, err
+	}
+
+	if err := channel.Authorize(cmd.RequestorID, domain.PermissionBanMembers); err != nil {
+		logger.Error("User is not allowed to list bans", zap.Error(err))
+		return nil, err
+	}
+
+	bans := channel.GetActiveBans()
+	logger.Info("Channel bans retrieved", zap.Int("count", len(bans)))
+	return bans, nil
+}
+
 // HandleSetChannelTopic sets the topic of a channel
 func (s *ChannelService) HandleSetChannelTopic(ctx context.Context, cmd domain.SetChannelTopicCommand) (*domain.Channel, error) {
 	logger := s.logger.WithMethod("HandleSetChannelTopic")
@@ -249,7 +622,11 @@ func (s *ChannelService) HandleSetChannelTopic(ctx context.Context, cmd domain.S
 		return nil, err
 	}
 
-	channel.SetTopic(cmd.UserID, cmd.Topic)
+	err = channel.SetTopic(cmd.UserID, cmd.Topic)
+	if err != nil {
+		logger.Error("Failed to set channel topic", zap.Error(err))
+		return nil, err
+	}
 
 	if err := s.repo.Save(ctx, channel); err != nil {
 		logger.Error("Failed to save channel", zap.Error(err))
@@ -267,6 +644,39 @@ func (s *ChannelService) HandleSetChannelTopic(ctx context.Context, cmd domain.S
 	return channel, err
 }
 
+// HandleRenameChannel renames a channel
+func (s *ChannelService) HandleRenameChannel(ctx context.Context, cmd domain.RenameChannelCommand) (*domain.Channel, error) {
+	logger := s.logger.WithMethod("HandleRenameChannel")
+	logger.Info("Renaming channel")
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to get channel", zap.Error(err))
+		return nil, err
+	}
+
+	err = channel.Rename(cmd.UserID, cmd.Name)
+	if err != nil {
+		logger.Error("Failed to rename channel", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, channel); err != nil {
+		logger.Error("Failed to save channel", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Channel renamed", zap.String("channel_id", channel.ID.String()))
+	return channel, nil
+}
+
 // HandleArchiveChannel archives a channel
 func (s *ChannelService) HandleArchiveChannel(ctx context.Context, cmd domain.ArchiveChannelCommand) (*domain.Channel, error) {
 	logger := s.logger.WithMethod("HandleArchiveChannel")
@@ -472,7 +882,7 @@ func (s *ChannelService) getChannelMembers(ctx context.Context, channel *domain.
 	integrationIDs := make([]string, 0)
 
 	for _, member := range channel.Members {
-		if member.GetRole() == "bot" {
+		if member.GetRole() == domain.RoleBot {
 			integrationIDs = append(integrationIDs, member.GetId().String())
 		} else {
 			userIDs = append(userIDs, member.GetId().String())
@@ -539,6 +949,21 @@ func (s *ChannelService) getChannelMembers(ctx context.Context, channel *domain.
 	return users, integrationBots, nil
 }
 
+// IsChannelMember reports whether the user is a member of the channel
+func (s *ChannelService) IsChannelMember(ctx context.Context, channelID, userID uuid.UUID) (bool, error) {
+	return s.repo.IsChannelMember(ctx, channelID, userID)
+}
+
+// GetChannelMemberIDs returns the IDs of the users who are members of a channel
+func (s *ChannelService) GetChannelMemberIDs(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	memberIDs, err := s.repo.FindMemberIDs(ctx, channelID)
+	if err != nil {
+		s.logger.WithMethod("GetChannelMemberIDs").Error("Failed to find channel members", zap.Error(err))
+		return nil, err
+	}
+	return memberIDs, nil
+}
+
 // ReturnChannelDTO returns a channel DTO
 func (s *ChannelService) ReturnChannelDTO(ctx context.Context, channel *domain.Channel) (*domain.ChannelDTO, error) {
 	logger := s.logger.WithMethod("ReturnChannelDTO")
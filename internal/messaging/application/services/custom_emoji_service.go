@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/storage"
+	"github.com/m1thrandir225/meridian/pkg/common"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+
+	// Registers the image decoders used to read the dimensions of uploaded emoji
+	_ "github.com/m1thrandir225/meridian/internal/messaging/infrastructure/media"
+)
+
+type CustomEmojiService struct {
+	repo      persistence.CustomEmojiRepository
+	blobStore storage.BlobStore
+	logger    *logging.Logger
+}
+
+func NewCustomEmojiService(repo persistence.CustomEmojiRepository, blobStore storage.BlobStore, logger *logging.Logger) *CustomEmojiService {
+	return &CustomEmojiService{
+		repo:      repo,
+		blobStore: blobStore,
+		logger:    logger,
+	}
+}
+
+// HandleUploadCustomEmoji registers an image under a new name. The content type is detected from the image itself.
+func (s *CustomEmojiService) HandleUploadCustomEmoji(ctx context.Context, cmd domain.UploadCustomEmojiCommand) (*domain.CustomEmoji, error) {
+	logger := s.logger.WithMethod("HandleUploadCustomEmoji")
+	logger.Info("Uploading custom emoji", zap.String("name", cmd.Name), zap.Int64("size", cmd.Size))
+
+	if err := domain.ValidateCustomEmojiName(cmd.Name); err != nil {
+		return nil, err
+	}
+	if cmd.Size > domain.MaxCustomEmojiSize {
+		return nil, domain.ErrCustomEmojiTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(cmd.Content, domain.MaxCustomEmojiSize+1))
+	if err != nil {
+		logger.Error("Failed to read upload", zap.Error(err))
+		return nil, fmt.Errorf("error reading upload: %w", err)
+	}
+	if len(data) > domain.MaxCustomEmojiSize {
+		return nil, domain.ErrCustomEmojiTooLarge
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		contentType = "application/octet-stream"
+	}
+
+	var width, height int
+	if config, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		width, height = config.Width, config.Height
+	} else if contentType != "application/octet-stream" {
+		logger.Info("Failed to read emoji dimensions", zap.String("content_type", contentType), zap.Error(err))
+		return nil, fmt.Errorf("emoji image could not be read: %w", domain.ErrCustomEmojiTypeNotAllowed)
+	}
+
+	emoji, err := domain.NewCustomEmoji(cmd.Name, cmd.CreatorID, contentType, int64(len(data)), width, height)
+	if err != nil {
+		logger.Info("Rejected custom emoji", zap.Error(err))
+		return nil, err
+	}
+
+	if _, err := s.blobStore.Put(ctx, emoji.GetStorageKey(), bytes.NewReader(data)); err != nil {
+		logger.Error("Failed to store emoji image", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, emoji); err != nil {
+		logger.Error("Failed to save custom emoji", zap.Error(err))
+		s.deleteBlob(ctx, emoji)
+		return nil, err
+	}
+
+	logger.Info("Custom emoji uploaded", zap.String("emoji_id", emoji.GetId().String()))
+	return emoji, nil
+}
+
+// HandleListCustomEmoji returns every custom emoji and alias of the workspace
+func (s *CustomEmojiService) HandleListCustomEmoji(ctx context.Context) ([]domain.CustomEmoji, error) {
+	logger := s.logger.WithMethod("HandleListCustomEmoji")
+
+	emoji, err := s.repo.FindAll(ctx)
+	if err != nil {
+		logger.Error("Failed to list custom emoji", zap.Error(err))
+		return nil, err
+	}
+	return emoji, nil
+}
+
+// HandleAddCustomEmojiAlias registers another name for an existing emoji
+func (s *CustomEmojiService) HandleAddCustomEmojiAlias(ctx context.Context, cmd domain.AddCustomEmojiAliasCommand) (*domain.CustomEmoji, error) {
+	logger := s.logger.WithMethod("HandleAddCustomEmojiAlias")
+	logger.Info("Adding custom emoji alias", zap.String("name", cmd.Name), zap.String("target", cmd.TargetName))
+
+	target, err := s.repo.FindByName(ctx, cmd.TargetName)
+	if err != nil {
+		logger.Error("Failed to find emoji", zap.Error(err))
+		return nil, err
+	}
+
+	alias, err := target.NewAlias(cmd.Name, cmd.CreatorID)
+	if err != nil {
+		logger.Info("Rejected alias", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, alias); err != nil {
+		logger.Error("Failed to save alias", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Custom emoji alias added", zap.String("emoji_id", alias.GetId().String()))
+	return alias, nil
+}
+
+// HandleDeleteCustomEmoji removes an emoji or alias. Deleting an emoji removes its aliases too. Reactions already
+// made with the emoji are kept and shown by name.
+func (s *CustomEmojiService) HandleDeleteCustomEmoji(ctx context.Context, cmd domain.DeleteCustomEmojiCommand) error {
+	logger := s.logger.WithMethod("HandleDeleteCustomEmoji")
+	logger.Info("Deleting custom emoji", zap.String("name", cmd.Name), zap.String("user_id", cmd.UserID.String()))
+
+	emoji, err := s.repo.FindByName(ctx, cmd.Name)
+	if err != nil {
+		logger.Error("Failed to find emoji", zap.Error(err))
+		return err
+	}
+
+	if !emoji.CanBeDeletedBy(cmd.UserID, cmd.IsAdmin) {
+		logger.Info("User may not delete emoji")
+		return domain.ErrCustomEmojiDeleteForbidden
+	}
+
+	if err := s.repo.Delete(ctx, emoji.GetId()); err != nil {
+		logger.Error("Failed to delete emoji", zap.Error(err))
+		return err
+	}
+	if !emoji.IsAlias() {
+		s.deleteBlob(ctx, emoji)
+	}
+
+	logger.Info("Custom emoji deleted", zap.String("emoji_id", emoji.GetId().String()))
+	return nil
+}
+
+// HandleOpenCustomEmojiImage opens the image of an emoji. The caller closes the reader.
+func (s *CustomEmojiService) HandleOpenCustomEmojiImage(ctx context.Context, emojiID uuid.UUID) (*domain.CustomEmoji, io.ReadCloser, error) {
+	logger := s.logger.WithMethod("HandleOpenCustomEmojiImage")
+
+	emoji, err := s.repo.FindByID(ctx, emojiID)
+	if err != nil {
+		logger.Error("Failed to find emoji", zap.Error(err))
+		return nil, nil, err
+	}
+	if emoji.IsAlias() {
+		return nil, nil, fmt.Errorf("emoji %s is an alias and has no image: %w", emojiID, common.ErrNotFound)
+	}
+
+	content, err := s.blobStore.Open(ctx, emoji.GetStorageKey())
+	if err != nil {
+		logger.Error("Failed to open emoji image", zap.Error(err))
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			return nil, nil, fmt.Errorf("image of emoji %s not found: %w", emojiID, common.ErrNotFound)
+		}
+		return nil, nil, err
+	}
+
+	return emoji, content, nil
+}
+
+// ResolveReactionType checks that a reaction is a Unicode emoji or a registered custom emoji and returns the
+// reaction type to store. Aliases resolve to the emoji they point to, so reactions with either are counted together.
+func (s *CustomEmojiService) ResolveReactionType(ctx context.Context, reactionType string) (string, error) {
+	if domain.IsUnicodeEmoji(reactionType) {
+		return reactionType, nil
+	}
+
+	name, ok := domain.ParseCustomEmojiReaction(reactionType)
+	if !ok {
+		return "", domain.ErrInvalidReactionType
+	}
+
+	emoji, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, common.ErrNotFound) {
+			return "", fmt.Errorf("%w: :%s: is not a custom emoji", domain.ErrInvalidReactionType, name)
+		}
+		return "", err
+	}
+	return emoji.GetReactionType(), nil
+}
+
+// ToCustomEmojiDTO returns the emoji as a DTO with the URL of its image
+func (s *CustomEmojiService) ToCustomEmojiDTO(emoji *domain.CustomEmoji) domain.CustomEmojiDTO {
+	dto := domain.ToCustomEmojiDTO(emoji)
+	dto.ImageURL = fmt.Sprintf("/api/v1/messages/emoji/%s/image", emoji.GetOriginalId())
+	return dto
+}
+
+// deleteBlob removes the image of an emoji
+func (s *CustomEmojiService) deleteBlob(ctx context.Context, emoji *domain.CustomEmoji) {
+	if err := s.blobStore.Delete(ctx, emoji.GetStorageKey()); err != nil {
+		s.logger.WithMethod("deleteBlob").Warn("Failed to delete emoji image",
+			zap.String("emoji_id", emoji.GetId().String()), zap.String("storage_key", emoji.GetStorageKey()), zap.Error(err))
+	}
+}
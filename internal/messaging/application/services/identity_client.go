@@ -68,6 +68,18 @@ func (ic *IdentityClient) GetUsers(ctx context.Context, userIDs []string) (*iden
 	return resp, nil
 }
 
+func (ic *IdentityClient) GetUsersByUsernames(ctx context.Context, usernames []string) (*identitypb.GetUsersResponse, error) {
+	req := &identitypb.GetUsersByUsernamesRequest{
+		Usernames: usernames,
+	}
+	resp, err := ic.client.GetUsersByUsernames(ctx, req)
+	if err != nil {
+		log.Printf("gRPC call to GetUsersByUsernames failed: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (ic *IdentityClient) Close() error {
 	return ic.conn.Close()
 }
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/pkg/cache"
+	"github.com/m1thrandir225/meridian/pkg/common"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	linkUnfurlQueueSize = 256
+	linkUnfurlWorkers   = 4
+	// linkUnfurlTimeout bounds the unfurling of all links of one message
+	linkUnfurlTimeout = 20 * time.Second
+	// linkPreviewCacheTTL is how long a fetched preview is reused for messages linking the same URL
+	linkPreviewCacheTTL = 24 * time.Hour
+	// linkPreviewFailureCacheTTL keeps pages without a preview from being fetched again for every message
+	linkPreviewFailureCacheTTL = time.Hour
+)
+
+// LinkPreviewFetcher reads the preview metadata of a link
+type LinkPreviewFetcher interface {
+	Fetch(ctx context.Context, url string) (*domain.LinkPreview, error)
+}
+
+// LinkPreviewNotifier tells the clients in a channel that a message's previews changed
+type LinkPreviewNotifier interface {
+	BroadcastMessageUnfurled(message *domain.Message)
+}
+
+// cachedLinkPreview is the Redis representation of a fetched preview; Found is false for pages without one
+type cachedLinkPreview struct {
+	Found       bool      `json:"found"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	SiteName    string    `json:"site_name,omitempty"`
+	ImageURL    string    `json:"image_url,omitempty"`
+	FetchedAt   time.Time `json:"fetched_at,omitempty"`
+}
+
+// LinkUnfurler fetches previews for the links in new and edited messages in the background,
+// so sending a message never waits on a third-party site.
+// Previews are cached in Redis by URL and shared between instances.
+type LinkUnfurler struct {
+	repo    persistence.ChannelRepository
+	cache   *cache.RedisCache
+	fetcher LinkPreviewFetcher
+	queue   chan domain.Message
+	logger  *logging.Logger
+}
+
+func NewLinkUnfurler(repo persistence.ChannelRepository, cache *cache.RedisCache, fetcher LinkPreviewFetcher, logger *logging.Logger) *LinkUnfurler {
+	return &LinkUnfurler{
+		repo:    repo,
+		cache:   cache,
+		fetcher: fetcher,
+		queue:   make(chan domain.Message, linkUnfurlQueueSize),
+		logger:  logger,
+	}
+}
+
+// Enqueue schedules a message for unfurling. Messages without links are only queued when they had previews
+// before an edit, so those are cleared. When the queue is full the message is skipped rather than blocking the sender.
+func (u *LinkUnfurler) Enqueue(message *domain.Message) {
+	if u == nil || message.IsDeleted() {
+		return
+	}
+	if len(message.GetContent().GetLinks()) == 0 && len(message.GetLinkPreviews()) == 0 {
+		return
+	}
+
+	select {
+	case u.queue <- *message:
+	default:
+		u.logger.WithMethod("Enqueue").Warn("Link unfurl queue is full, skipping message", zap.String("message_id", message.GetId().String()))
+	}
+}
+
+// Run unfurls queued messages until the context is cancelled and notifies the channel about each change
+func (u *LinkUnfurler) Run(ctx context.Context, notifier LinkPreviewNotifier) {
+	logger := u.logger.WithMethod("Run")
+	logger.Info("Link unfurler started", zap.Int("workers", linkUnfurlWorkers))
+
+	var wg sync.WaitGroup
+	for range linkUnfurlWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case message := <-u.queue:
+					u.unfurl(ctx, &message, notifier)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	logger.Info("Link unfurler stopped")
+}
+
+func (u *LinkUnfurler) unfurl(ctx context.Context, message *domain.Message, notifier LinkPreviewNotifier) {
+	logger := u.logger.WithMethod("unfurl")
+	logger.Info("Unfurling links", zap.String("message_id", message.GetId().String()))
+
+	ctx, cancel := context.WithTimeout(ctx, linkUnfurlTimeout)
+	defer cancel()
+
+	previews := []domain.LinkPreview{}
+	for _, url := range message.GetContent().GetLinks() {
+		if len(previews) == domain.MaxLinkPreviewsPerMessage {
+			break
+		}
+		preview, err := u.preview(ctx, url)
+		if err != nil {
+			logger.Info("No preview for link", zap.String("url", url), zap.Error(err))
+			continue
+		}
+		previews = append(previews, *preview)
+	}
+
+	if len(previews) == 0 && len(message.GetLinkPreviews()) == 0 {
+		logger.Info("Nothing to unfurl", zap.String("message_id", message.GetId().String()))
+		return
+	}
+
+	message.SetLoadedLinkPreviews(previews)
+	if err := u.repo.ReplaceLinkPreviews(ctx, message); err != nil {
+		if errors.Is(err, common.ErrConflict) {
+			logger.Info("Message changed while unfurling, dropping previews", zap.String("message_id", message.GetId().String()))
+			return
+		}
+		logger.Error("Failed to save link previews", zap.Error(err))
+		return
+	}
+
+	if notifier != nil {
+		notifier.BroadcastMessageUnfurled(message)
+	}
+	logger.Info("Links unfurled", zap.String("message_id", message.GetId().String()), zap.Int("count", len(previews)))
+}
+
+// preview returns the cached preview for url, fetching and caching it on a miss
+func (u *LinkUnfurler) preview(ctx context.Context, url string) (*domain.LinkPreview, error) {
+	logger := u.logger.WithMethod("preview")
+
+	key := linkPreviewCacheKey(url)
+	var cached cachedLinkPreview
+	if found, err := u.cache.GetWithMetrics(ctx, key, &cached); found && err == nil {
+		if !cached.Found {
+			return nil, domain.ErrNoLinkPreview
+		}
+		preview := domain.RehydrateLinkPreview(url, cached.Title, cached.Description, cached.SiteName, cached.ImageURL, cached.FetchedAt)
+		return &preview, nil
+	}
+
+	preview, err := u.fetcher.Fetch(ctx, url)
+	if err != nil {
+		// A cancelled unfurl says nothing about the page, so it is not remembered
+		if ctx.Err() == nil {
+			if err := u.cache.Set(ctx, key, cachedLinkPreview{Found: false}, linkPreviewFailureCacheTTL); err != nil {
+				logger.Warn("Failed to cache link preview failure", zap.Error(err))
+			}
+		}
+		return nil, err
+	}
+
+	cached = cachedLinkPreview{
+		Found:       true,
+		Title:       preview.GetTitle(),
+		Description: preview.GetDescription(),
+		SiteName:    preview.GetSiteName(),
+		ImageURL:    preview.GetImageUrl(),
+		FetchedAt:   preview.GetFetchedAt(),
+	}
+	if err := u.cache.Set(ctx, key, cached, linkPreviewCacheTTL); err != nil {
+		logger.Warn("Failed to cache link preview", zap.Error(err))
+	}
+	return preview, nil
+}
+
+func linkPreviewCacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return "link_preview:" + hex.EncodeToString(sum[:])
+}
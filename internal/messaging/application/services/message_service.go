@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/m1thrandir225/meridian/internal/messaging/domain"
 	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/pkg/common"
 	"github.com/m1thrandir225/meridian/pkg/kafka"
 	"github.com/m1thrandir225/meridian/pkg/logging"
 	"go.uber.org/zap"
@@ -21,36 +23,48 @@ type MessageService struct {
 	eventPub          kafka.EventPublisher
 	identityClient    *IdentityClient
 	integrationClient *IntegrationClient
+	maxPinnedMessages int
+	linkUnfurler      *LinkUnfurler
+	attachmentURLs    *AttachmentURLSigner
+	customEmoji       *CustomEmojiService
 	logger            *logging.Logger
 }
 
-func NewMessageService(repo persistence.ChannelRepository, eventPub kafka.EventPublisher, identityClient *IdentityClient, integrationClient *IntegrationClient, logger *logging.Logger) *MessageService {
+// NewMessageService creates the message service; linkUnfurler may be nil to disable link previews
+func NewMessageService(repo persistence.ChannelRepository, eventPub kafka.EventPublisher, identityClient *IdentityClient, integrationClient *IntegrationClient, maxPinnedMessages int, linkUnfurler *LinkUnfurler, attachmentURLs *AttachmentURLSigner, customEmoji *CustomEmojiService, logger *logging.Logger) *MessageService {
+	if maxPinnedMessages <= 0 {
+		maxPinnedMessages = domain.DefaultMaxPinnedMessages
+	}
 	return &MessageService{
 		repo:              repo,
 		eventPub:          eventPub,
 		identityClient:    identityClient,
 		integrationClient: integrationClient,
+		maxPinnedMessages: maxPinnedMessages,
+		linkUnfurler:      linkUnfurler,
+		attachmentURLs:    attachmentURLs,
+		customEmoji:       customEmoji,
 		logger:            logger,
 	}
 }
 
-func (s *MessageService) HandleListMessages(ctx context.Context, cmd domain.ListMessagesForChannelCommand) ([]domain.Message, error) {
+func (s *MessageService) HandleListMessages(ctx context.Context, cmd domain.ListMessagesForChannelCommand) (*domain.MessagePage, error) {
 	logger := s.logger.WithMethod("HandleListMessages")
 	logger.Info("Listing messages for channel", zap.String("channel_id", cmd.ChannelID.String()))
 
-	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	channel, err := s.loadReadableChannel(ctx, cmd.ChannelID, cmd.UserID)
 	if err != nil {
 		logger.Error("Failed to find channel", zap.Error(err))
 		return nil, err
 	}
 
-	messages, err := s.repo.FindMessages(context.Background(), cmd.ChannelID, cmd.Limit, cmd.Offset)
+	page, err := s.repo.FindMessages(ctx, cmd.ChannelID, cmd.Query)
 	if err != nil {
 		logger.Error("Failed to find messages", zap.Error(err))
 		return nil, err
 	}
 
-	channel.Messages = messages
+	channel.Messages = page.Messages
 
 	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
 	if err != nil {
@@ -59,8 +73,8 @@ func (s *MessageService) HandleListMessages(ctx context.Context, cmd domain.List
 	}
 	channel.ClearPendingEvents()
 
-	logger.Info("Messages listed", zap.Int("count", len(messages)))
-	return messages, nil
+	logger.Info("Messages listed", zap.Int("count", len(page.Messages)))
+	return page, nil
 }
 
 func (s *MessageService) HandleMessageSent(ctx context.Context, cmd domain.SendMessageCommand) (*domain.Message, error) {
@@ -73,17 +87,28 @@ func (s *MessageService) HandleMessageSent(ctx context.Context, cmd domain.SendM
 		return nil, err
 	}
 
-	//If the message is a reply, we need to load the messages in the domain
+	//If the message is a reply, the parent has to be loaded in the domain
 	if cmd.ParentMessageID != nil {
-		messages, err := s.repo.FindMessages(ctx, cmd.ChannelID, 1000, 0)
+		parent, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, *cmd.ParentMessageID)
 		if err != nil {
-			logger.Error("Failed to find messages", zap.Error(err))
-			return nil, fmt.Errorf("error finding messages: %w", err)
+			logger.Error("Failed to find parent message", zap.Error(err))
+			return nil, fmt.Errorf("error finding parent message: %w", err)
 		}
-		channel.Messages = messages
+		channel.Messages = []domain.Message{*parent}
 	}
 
-	message, err := channel.PostMessage(cmd.SenderUserID, cmd.Content, cmd.ParentMessageID)
+	s.resolveMentions(ctx, &cmd.Content)
+
+	var attachments []domain.Attachment
+	if len(cmd.AttachmentIDs) > 0 {
+		attachments, err = s.findUploads(ctx, cmd.ChannelID, cmd.AttachmentIDs)
+		if err != nil {
+			logger.Error("Failed to find uploads", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	message, err := channel.PostMessage(cmd.SenderUserID, cmd.Content, cmd.ParentMessageID, attachments)
 	if err != nil {
 		logger.Error("Failed to post message", zap.Error(err))
 		return nil, err
@@ -91,6 +116,9 @@ func (s *MessageService) HandleMessageSent(ctx context.Context, cmd domain.SendM
 
 	if err := s.repo.SaveMessage(ctx, message); err != nil {
 		logger.Error("Failed to save message", zap.Error(err))
+		if errors.Is(err, common.ErrConflict) {
+			return nil, fmt.Errorf("%w: %w", domain.ErrAttachmentUnavailable, err)
+		}
 		return nil, err
 	}
 
@@ -101,10 +129,311 @@ func (s *MessageService) HandleMessageSent(ctx context.Context, cmd domain.SendM
 	}
 	channel.ClearPendingEvents()
 
+	s.linkUnfurler.Enqueue(message)
+
 	logger.Info("Message sent", zap.String("message_id", message.GetId().String()))
 	return message, err
 }
 
+// HandleEditMessage edits the content of a message and publishes the events
+func (s *MessageService) HandleEditMessage(ctx context.Context, cmd domain.EditMessageCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleEditMessage")
+	logger.Info("Editing message", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+	channel.Messages = []domain.Message{*message}
+
+	s.resolveMentions(ctx, &cmd.Content)
+
+	edited, revision, err := channel.EditMessage(cmd.MessageID, cmd.UserID, cmd.Content)
+	if err != nil {
+		logger.Error("Failed to edit message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.UpdateMessage(ctx, edited, revision); err != nil {
+		logger.Error("Failed to update message", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	s.linkUnfurler.Enqueue(edited)
+
+	logger.Info("Message edited", zap.String("message_id", edited.GetId().String()))
+	return edited, nil
+}
+
+// HandleDeleteMessage replaces a message with a tombstone and publishes the events
+func (s *MessageService) HandleDeleteMessage(ctx context.Context, cmd domain.DeleteMessageCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleDeleteMessage")
+	logger.Info("Deleting message", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+	channel.Messages = []domain.Message{*message}
+
+	deleted, err := channel.DeleteMessage(cmd.MessageID, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to delete message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.MarkMessageDeleted(ctx, deleted); err != nil {
+		logger.Error("Failed to mark message as deleted", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Message deleted", zap.String("message_id", deleted.GetId().String()))
+	return deleted, nil
+}
+
+// HandlePinMessage pins a message to its channel, respecting the configured pin limit
+func (s *MessageService) HandlePinMessage(ctx context.Context, cmd domain.PinMessageCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandlePinMessage")
+	logger.Info("Pinning message", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+	channel.Messages = []domain.Message{*message}
+
+	pinnedCount, err := s.repo.CountPinnedMessages(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to count pinned messages", zap.Error(err))
+		return nil, err
+	}
+
+	pinned, err := channel.PinMessage(cmd.MessageID, cmd.UserID, pinnedCount, s.maxPinnedMessages)
+	if err != nil {
+		logger.Error("Failed to pin message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.PinMessageWithinLimit(ctx, pinned, s.maxPinnedMessages); err != nil {
+		logger.Error("Failed to save message pin", zap.Error(err))
+		if errors.Is(err, common.ErrConflict) {
+			return nil, fmt.Errorf("%w: %w", domain.ErrPinLimitReached, err)
+		}
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Message pinned", zap.String("message_id", pinned.GetId().String()))
+	return pinned, nil
+}
+
+// HandleUnpinMessage removes a message from its channel's pins
+func (s *MessageService) HandleUnpinMessage(ctx context.Context, cmd domain.UnpinMessageCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleUnpinMessage")
+	logger.Info("Unpinning message", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+	channel.Messages = []domain.Message{*message}
+
+	unpinned, err := channel.UnpinMessage(cmd.MessageID, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to unpin message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.UpdateMessagePin(ctx, unpinned); err != nil {
+		logger.Error("Failed to save message pin", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Message unpinned", zap.String("message_id", unpinned.GetId().String()))
+	return unpinned, nil
+}
+
+// HandleListPinnedMessages returns the pinned messages of a channel, most recently pinned first
+func (s *MessageService) HandleListPinnedMessages(ctx context.Context, cmd domain.ListPinnedMessagesCommand) ([]domain.Message, error) {
+	logger := s.logger.WithMethod("HandleListPinnedMessages")
+	logger.Info("Listing pinned messages", zap.String("channel_id", cmd.ChannelID.String()))
+
+	if _, err := s.loadReadableChannel(ctx, cmd.ChannelID, cmd.UserID); err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	messages, err := s.repo.FindPinnedMessages(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find pinned messages", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Pinned messages listed", zap.Int("count", len(messages)))
+	return messages, nil
+}
+
+// HandleGetMessageRevisions returns the edit history of a message, newest first
+func (s *MessageService) HandleGetMessageRevisions(ctx context.Context, cmd domain.GetMessageRevisionsCommand) ([]domain.MessageRevision, error) {
+	logger := s.logger.WithMethod("HandleGetMessageRevisions")
+	logger.Info("Getting message revisions", zap.String("message_id", cmd.MessageID.String()))
+
+	if _, err := s.loadReadableChannel(ctx, cmd.ChannelID, cmd.UserID); err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	if _, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID); err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+
+	revisions, err := s.repo.FindMessageRevisions(ctx, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message revisions", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Message revisions retrieved", zap.Int("count", len(revisions)))
+	return revisions, nil
+}
+
+// HandleGetThread returns the root message of a thread together with a page of its replies
+func (s *MessageService) HandleGetThread(ctx context.Context, cmd domain.GetThreadCommand) (*domain.Message, *domain.MessagePage, error) {
+	logger := s.logger.WithMethod("HandleGetThread")
+	logger.Info("Getting thread", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	if _, err := s.loadReadableChannel(ctx, cmd.ChannelID, cmd.UserID); err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, nil, err
+	}
+
+	root, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find root message", zap.Error(err))
+		return nil, nil, err
+	}
+
+	replies, err := s.repo.FindThreadReplies(ctx, cmd.ChannelID, cmd.MessageID, cmd.Query)
+	if err != nil {
+		logger.Error("Failed to find thread replies", zap.Error(err))
+		return nil, nil, err
+	}
+
+	logger.Info("Thread retrieved", zap.Int("count", len(replies.Messages)))
+	return root, replies, nil
+}
+
+// HandleListMentions returns the messages mentioning a user across all of their channels, newest first
+func (s *MessageService) HandleListMentions(ctx context.Context, cmd domain.ListMentionsCommand) (*domain.MessagePage, error) {
+	logger := s.logger.WithMethod("HandleListMentions")
+	logger.Info("Listing mentions", zap.String("user_id", cmd.UserID.String()))
+
+	page, err := s.repo.FindMentions(ctx, cmd.UserID, cmd.Query)
+	if err != nil {
+		logger.Error("Failed to find mentions", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Mentions listed", zap.Int("count", len(page.Messages)))
+	return page, nil
+}
+
+// HandleSearchMessages runs a full-text search across the channels the user is a member of.
+// from: usernames are resolved through the identity service; when none of them exist nothing can match.
+func (s *MessageService) HandleSearchMessages(ctx context.Context, cmd domain.SearchMessagesCommand) (*domain.MessageSearchPage, error) {
+	logger := s.logger.WithMethod("HandleSearchMessages")
+	logger.Info("Searching messages", zap.String("user_id", cmd.UserID.String()))
+
+	query := cmd.Query
+	if len(query.FromUsernames) > 0 {
+		resp, err := s.identityClient.GetUsersByUsernames(ctx, query.FromUsernames)
+		if err != nil {
+			logger.Error("Failed to resolve search senders", zap.Error(err))
+			return nil, err
+		}
+
+		query.FromUserIDs = make([]uuid.UUID, 0, len(resp.Users))
+		for _, user := range resp.Users {
+			userID, err := uuid.Parse(user.Id)
+			if err != nil {
+				logger.Warn("Invalid user ID in sender lookup", zap.String("user_id", user.Id), zap.Error(err))
+				continue
+			}
+			query.FromUserIDs = append(query.FromUserIDs, userID)
+		}
+
+		if len(query.FromUserIDs) == 0 {
+			logger.Info("No search senders matched", zap.Strings("usernames", query.FromUsernames))
+			return &domain.MessageSearchPage{Results: []domain.MessageSearchResult{}}, nil
+		}
+	}
+
+	page, err := s.repo.SearchMessages(ctx, cmd.UserID, query)
+	if err != nil {
+		logger.Error("Failed to search messages", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Messages searched", zap.Int("count", len(page.Results)))
+	return page, nil
+}
+
 // HandleNotificationSent sends a notification to a channel
 // Might be redundant, but keeping it for now
 // TODO: Remove this if it's redundant
@@ -135,6 +464,8 @@ func (s *MessageService) HandleNotificationSent(ctx context.Context, cmd domain.
 	}
 	channel.ClearPendingEvents()
 
+	s.linkUnfurler.Enqueue(message)
+
 	logger.Info("Notification sent", zap.String("message_id", message.GetId().String()))
 	return message, err
 }
@@ -144,19 +475,25 @@ func (s *MessageService) HandleAddReaction(ctx context.Context, cmd domain.AddRe
 	logger := s.logger.WithMethod("HandleAddReaction")
 	logger.Info("Adding reaction", zap.String("channel_id", cmd.ChannelID.String()))
 
+	reactionType, err := s.customEmoji.ResolveReactionType(ctx, cmd.ReactionType)
+	if err != nil {
+		logger.Info("Rejected reaction", zap.String("reaction_type", cmd.ReactionType), zap.Error(err))
+		return nil, err
+	}
+	cmd.ReactionType = reactionType
+
 	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
 	if err != nil {
 		logger.Error("Failed to find channel", zap.Error(err))
 		return nil, err
 	}
 
-	// FIXME:  should the channel return all the messages??
-	messages, err := s.repo.FindMessages(ctx, cmd.ChannelID, 100, 0)
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
 	if err != nil {
-		logger.Error("Failed to find messages", zap.Error(err))
+		logger.Error("Failed to find message", zap.Error(err))
 		return nil, err
 	}
-	channel.Messages = messages
+	channel.Messages = []domain.Message{*message}
 
 	newReaction, err := channel.AddReaction(cmd.MessageID, cmd.UserID, cmd.ReactionType)
 	if err != nil {
@@ -185,19 +522,27 @@ func (s *MessageService) HandleRemoveReaction(ctx context.Context, cmd domain.Re
 	logger := s.logger.WithMethod("HandleRemoveReaction")
 	logger.Info("Removing reaction", zap.String("channel_id", cmd.ChannelID.String()))
 
+	// Reactions with a custom emoji that was deleted since are removed by name
+	reactionType, err := s.customEmoji.ResolveReactionType(ctx, cmd.ReactionType)
+	if err == nil {
+		cmd.ReactionType = reactionType
+	} else if !errors.Is(err, domain.ErrInvalidReactionType) {
+		logger.Error("Failed to resolve reaction type", zap.Error(err))
+		return nil, err
+	}
+
 	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
 	if err != nil {
 		logger.Error("Failed to find channel", zap.Error(err))
 		return nil, err
 	}
 
-	messages, err := s.repo.FindMessages(ctx, cmd.ChannelID, 100, 0)
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
 	if err != nil {
-		logger.Error("Failed to find messages", zap.Error(err))
+		logger.Error("Failed to find message", zap.Error(err))
 		return nil, err
 	}
-
-	channel.Messages = messages
+	channel.Messages = []domain.Message{*message}
 
 	reaction, err := channel.RemoveReaction(cmd.MessageID, cmd.UserID, cmd.ReactionType)
 	if err != nil {
@@ -221,13 +566,410 @@ func (s *MessageService) HandleRemoveReaction(ctx context.Context, cmd domain.Re
 	return reaction, nil
 }
 
-func (s *MessageService) ToMessageDTOs(ctx context.Context, messages []domain.Message) ([]domain.MessageDTO, error) {
+// HandleForwardMessage shares a message into another channel the user belongs to and publishes the events
+func (s *MessageService) HandleForwardMessage(ctx context.Context, cmd domain.ForwardMessageCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleForwardMessage")
+	logger.Info("Forwarding message", zap.String("source_message_id", cmd.SourceMessageID.String()), zap.String("channel_id", cmd.ChannelID.String()))
+
+	sourceChannel, source, err := s.loadSourceMessage(ctx, cmd.SourceChannelID, cmd.SourceMessageID)
+	if err != nil {
+		logger.Error("Failed to find source message", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	s.resolveMentions(ctx, &cmd.Comment)
+
+	message, err := channel.ForwardMessage(cmd.SenderUserID, sourceChannel, cmd.SourceMessageID, cmd.Comment)
+	if err != nil {
+		logger.Error("Failed to forward message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.SaveMessage(ctx, message); err != nil {
+		logger.Error("Failed to save message", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	message.SetLoadedReference(message.GetReference(), source)
+	s.linkUnfurler.Enqueue(message)
+
+	logger.Info("Message forwarded", zap.String("message_id", message.GetId().String()))
+	return message, nil
+}
+
+// HandleQuoteMessage posts a reply quoting a message of a channel the user belongs to
+func (s *MessageService) HandleQuoteMessage(ctx context.Context, cmd domain.QuoteMessageCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleQuoteMessage")
+	logger.Info("Quoting message", zap.String("source_message_id", cmd.SourceMessageID.String()), zap.String("channel_id", cmd.ChannelID.String()))
+
+	sourceChannel, source, err := s.loadSourceMessage(ctx, cmd.SourceChannelID, cmd.SourceMessageID)
+	if err != nil {
+		logger.Error("Failed to find source message", zap.Error(err))
+		return nil, err
+	}
+
+	channel := sourceChannel
+	if cmd.ChannelID != cmd.SourceChannelID {
+		channel, err = s.repo.FindById(ctx, cmd.ChannelID)
+		if err != nil {
+			logger.Error("Failed to find channel", zap.Error(err))
+			return nil, err
+		}
+	}
+
+	//If the quote is a reply, the parent has to be loaded in the domain
+	if cmd.ParentMessageID != nil && (channel != sourceChannel || *cmd.ParentMessageID != source.GetId()) {
+		parent, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, *cmd.ParentMessageID)
+		if err != nil {
+			logger.Error("Failed to find parent message", zap.Error(err))
+			return nil, fmt.Errorf("error finding parent message: %w", err)
+		}
+		channel.Messages = append(channel.Messages, *parent)
+	}
+
+	s.resolveMentions(ctx, &cmd.Content)
+
+	message, err := channel.QuoteMessage(cmd.SenderUserID, sourceChannel, cmd.SourceMessageID, cmd.Content, cmd.ParentMessageID)
+	if err != nil {
+		logger.Error("Failed to quote message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.SaveMessage(ctx, message); err != nil {
+		logger.Error("Failed to save message", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	message.SetLoadedReference(message.GetReference(), source)
+	s.linkUnfurler.Enqueue(message)
+
+	logger.Info("Message quoted", zap.String("message_id", message.GetId().String()))
+	return message, nil
+}
+
+// HandleGetMessageSource returns the message forwarded or quoted by a message, if the user can read its channel
+func (s *MessageService) HandleGetMessageSource(ctx context.Context, cmd domain.GetMessageSourceCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleGetMessageSource")
+	logger.Info("Getting message source", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	if _, err := s.loadReadableChannel(ctx, cmd.ChannelID, cmd.UserID); err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+
+	reference := message.GetReference()
+	if reference == nil {
+		return nil, fmt.Errorf("message %s does not forward or quote a message: %w", cmd.MessageID, common.ErrNotFound)
+	}
+
+	isMember, err := s.repo.IsChannelMember(ctx, reference.GetSourceChannelId(), cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to check access to source channel", zap.Error(err))
+		return nil, err
+	}
+	if !isMember {
+		return nil, domain.ErrSourceAccessDenied
+	}
+
+	if message.GetSource() == nil {
+		return nil, fmt.Errorf("source of message %s no longer exists: %w", cmd.MessageID, common.ErrNotFound)
+	}
+	return message.GetSource(), nil
+}
+
+// loadReadableChannel loads a channel the user may read; private channels and direct conversations
+// are reported as not found to non-members
+func (s *MessageService) loadReadableChannel(ctx context.Context, channelID, userID uuid.UUID) (*domain.Channel, error) {
+	channel, err := s.repo.FindById(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+	if !channel.CanUserRead(userID) {
+		return nil, fmt.Errorf("channel with ID %s: %w", channelID, common.ErrNotFound)
+	}
+	return channel, nil
+}
+
+// loadSourceMessage loads a message to forward or quote, with its channel
+func (s *MessageService) loadSourceMessage(ctx context.Context, channelID, messageID uuid.UUID) (*domain.Channel, *domain.Message, error) {
+	channel, err := s.repo.FindById(ctx, channelID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source, err := s.repo.FindMessageByID(ctx, channelID, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+	channel.Messages = []domain.Message{*source}
+
+	return channel, source, nil
+}
+
+// HandleCreatePoll posts a poll to a channel and publishes the events
+func (s *MessageService) HandleCreatePoll(ctx context.Context, cmd domain.CreatePollCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleCreatePoll")
+	logger.Info("Creating poll", zap.String("channel_id", cmd.ChannelID.String()))
+
+	poll, err := domain.NewPoll(cmd.Question, cmd.Options, cmd.MultipleChoice, cmd.Anonymous, cmd.ClosesAt)
+	if err != nil {
+		logger.Info("Rejected poll", zap.Error(err))
+		return nil, err
+	}
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	if cmd.ParentMessageID != nil {
+		parent, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, *cmd.ParentMessageID)
+		if err != nil {
+			logger.Error("Failed to find parent message", zap.Error(err))
+			return nil, fmt.Errorf("error finding parent message: %w", err)
+		}
+		channel.Messages = []domain.Message{*parent}
+	}
+
+	message, err := channel.PostPoll(cmd.SenderUserID, poll, cmd.ParentMessageID)
+	if err != nil {
+		logger.Error("Failed to post poll", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.SaveMessage(ctx, message); err != nil {
+		logger.Error("Failed to save poll", zap.Error(err))
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Poll created", zap.String("message_id", message.GetId().String()))
+	return message, nil
+}
+
+// HandleVotePoll records the user's vote in a poll, replacing any earlier vote
+func (s *MessageService) HandleVotePoll(ctx context.Context, cmd domain.VotePollCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleVotePoll")
+	logger.Info("Voting in poll", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+	channel.Messages = []domain.Message{*message}
+
+	voted, err := channel.VotePoll(cmd.MessageID, cmd.UserID, cmd.OptionIDs)
+	if err != nil {
+		logger.Error("Failed to vote in poll", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.ReplacePollVotes(ctx, voted, cmd.UserID); err != nil {
+		logger.Error("Failed to save poll votes", zap.Error(err))
+		if errors.Is(err, common.ErrConflict) {
+			return nil, domain.ErrPollClosed
+		}
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Poll vote recorded", zap.String("message_id", voted.GetId().String()))
+	return voted, nil
+}
+
+// HandleRetractPollVote removes the user's vote from a poll
+func (s *MessageService) HandleRetractPollVote(ctx context.Context, cmd domain.RetractPollVoteCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleRetractPollVote")
+	logger.Info("Retracting poll vote", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+	channel.Messages = []domain.Message{*message}
+
+	retracted, err := channel.RetractPollVote(cmd.MessageID, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to retract poll vote", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.ReplacePollVotes(ctx, retracted, cmd.UserID); err != nil {
+		logger.Error("Failed to save poll votes", zap.Error(err))
+		if errors.Is(err, common.ErrConflict) {
+			return nil, domain.ErrPollClosed
+		}
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Poll vote retracted", zap.String("message_id", retracted.GetId().String()))
+	return retracted, nil
+}
+
+// HandleClosePoll closes a poll before its closing time
+func (s *MessageService) HandleClosePoll(ctx context.Context, cmd domain.ClosePollCommand) (*domain.Message, error) {
+	logger := s.logger.WithMethod("HandleClosePoll")
+	logger.Info("Closing poll", zap.String("channel_id", cmd.ChannelID.String()), zap.String("message_id", cmd.MessageID.String()))
+
+	channel, err := s.repo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.repo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+	channel.Messages = []domain.Message{*message}
+
+	closed, err := channel.ClosePoll(cmd.MessageID, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to close poll", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.ClosePoll(ctx, closed); err != nil {
+		logger.Error("Failed to save poll", zap.Error(err))
+		if errors.Is(err, common.ErrConflict) {
+			return nil, domain.ErrPollClosed
+		}
+		return nil, err
+	}
+
+	err = s.eventPub.PublishEvents(ctx, channel.GetPendingEvents())
+	if err != nil {
+		logger.Error("Failed to publish events", zap.Error(err))
+		return nil, err
+	}
+	channel.ClearPendingEvents()
+
+	logger.Info("Poll closed", zap.String("message_id", closed.GetId().String()))
+	return closed, nil
+}
+
+// HandleCloseDuePolls closes polls whose closing time has passed and returns them, ready to be broadcast.
+// Polls closed by another instance in the meantime are skipped.
+func (s *MessageService) HandleCloseDuePolls(ctx context.Context, now time.Time, limit int) ([]domain.Message, error) {
+	logger := s.logger.WithMethod("HandleCloseDuePolls")
+
+	messageIDs, err := s.repo.FindPollsDueToClose(ctx, now, limit)
+	if err != nil {
+		logger.Error("Failed to find polls due to close", zap.Error(err))
+		return nil, err
+	}
+	if len(messageIDs) == 0 {
+		return []domain.Message{}, nil
+	}
+
+	messages, err := s.repo.FindMessagesByIDs(ctx, messageIDs)
+	if err != nil {
+		logger.Error("Failed to load poll messages", zap.Error(err))
+		return nil, err
+	}
+
+	closedPolls := []domain.Message{}
+	for _, message := range messages {
+		channel, err := s.repo.FindById(ctx, message.GetChannelId())
+		if err != nil {
+			logger.Error("Failed to find channel", zap.String("channel_id", message.GetChannelId().String()), zap.Error(err))
+			continue
+		}
+		channel.Messages = []domain.Message{message}
+
+		closed, err := channel.ClosePollAtDeadline(message.GetId(), now)
+		if err != nil {
+			logger.Warn("Failed to close poll", zap.String("message_id", message.GetId().String()), zap.Error(err))
+			continue
+		}
+
+		if err := s.repo.ClosePoll(ctx, closed); err != nil {
+			if !errors.Is(err, common.ErrConflict) {
+				logger.Error("Failed to save poll", zap.String("message_id", message.GetId().String()), zap.Error(err))
+			}
+			continue
+		}
+
+		if err := s.eventPub.PublishEvents(ctx, channel.GetPendingEvents()); err != nil {
+			logger.Error("Failed to publish events", zap.Error(err))
+		}
+		channel.ClearPendingEvents()
+
+		closedPolls = append(closedPolls, *closed)
+	}
+
+	logger.Info("Polls closed at their closing time", zap.Int("count", len(closedPolls)))
+	return closedPolls, nil
+}
+
+func (s *MessageService) ToMessageDTOs(ctx context.Context, messages []domain.Message, viewerID uuid.UUID) ([]domain.MessageDTO, error) {
 	logger := s.logger.WithMethod("ToMessageDTOs")
 	logger.Info("Converting messages to DTOs", zap.Int("count", len(messages)))
 
 	dtos := make([]domain.MessageDTO, len(messages))
 	for i, message := range messages {
-		dto, err := s.ToMessageDTO(ctx, &message)
+		dto, err := s.ToMessageDTO(ctx, &message, viewerID)
 		if err != nil {
 			return nil, err
 		}
@@ -236,8 +978,8 @@ func (s *MessageService) ToMessageDTOs(ctx context.Context, messages []domain.Me
 	return dtos, nil
 }
 
-// ToMessageDTO returns the message as a DTO
-func (s *MessageService) ToMessageDTO(ctx context.Context, message *domain.Message) (*domain.MessageDTO, error) {
+// ToMessageDTO returns the message as a DTO for the given viewer, uuid.Nil when it is sent to a whole channel
+func (s *MessageService) ToMessageDTO(ctx context.Context, message *domain.Message, viewerID uuid.UUID) (*domain.MessageDTO, error) {
 	senderUserID := message.GetSenderUserId()
 	integrationID := message.GetIntegrationId()
 
@@ -246,7 +988,9 @@ func (s *MessageService) ToMessageDTO(ctx context.Context, message *domain.Messa
 		if err != nil {
 			return nil, err
 		}
-		dto := domain.ToMessageDTO(message, user, nil)
+		dto := domain.ToMessageDTO(message, user, nil, viewerID)
+		dto.Attachments = s.attachmentURLs.ToAttachmentDTOs(message.GetAttachments())
+		s.addReferencePreview(ctx, message, &dto, viewerID)
 		return &dto, nil
 	}
 	if integrationID != nil {
@@ -254,13 +998,73 @@ func (s *MessageService) ToMessageDTO(ctx context.Context, message *domain.Messa
 		if err != nil {
 			return nil, err
 		}
-		dto := domain.ToMessageDTO(message, nil, integrationBot)
+		dto := domain.ToMessageDTO(message, nil, integrationBot, viewerID)
+		s.addReferencePreview(ctx, message, &dto, viewerID)
 		return &dto, nil
 	}
 
 	return nil, fmt.Errorf("message has no sender user or integration id")
 }
 
+// ToMessagePreviewDTO returns a forwarded or quoted message as it is embedded in the message that references it
+func (s *MessageService) ToMessagePreviewDTO(ctx context.Context, source *domain.Message) domain.MessagePreviewDTO {
+	var sender *domain.User
+	if source.GetSenderUserId() != nil {
+		user, err := s.getSenderUser(ctx, source.GetSenderUserId().String())
+		if err != nil {
+			s.logger.WithMethod("ToMessagePreviewDTO").Warn("Failed to load sender of source message", zap.Error(err))
+		} else {
+			sender = user
+		}
+	}
+	return domain.ToMessagePreviewDTO(source, sender)
+}
+
+// addReferencePreview embeds the source of a forwarded or quoting message when the viewer can read its channel.
+// Sources in the same channel are readable by everyone who sees the message. For other channels, a message sent to a
+// whole channel (uuid.Nil viewer) leaves the access open and clients load the preview themselves.
+func (s *MessageService) addReferencePreview(ctx context.Context, message *domain.Message, dto *domain.MessageDTO, viewerID uuid.UUID) {
+	reference := message.GetReference()
+	if reference == nil || dto.Reference == nil {
+		return
+	}
+
+	accessible := reference.GetSourceChannelId() == message.GetChannelId()
+	if !accessible {
+		if viewerID == uuid.Nil {
+			return
+		}
+		isMember, err := s.repo.IsChannelMember(ctx, reference.GetSourceChannelId(), viewerID)
+		if err != nil {
+			s.logger.WithMethod("addReferencePreview").Warn("Failed to check access to source channel", zap.Error(err))
+			return
+		}
+		accessible = isMember
+	}
+
+	dto.Reference.Accessible = &accessible
+	if accessible && message.GetSource() != nil {
+		preview := s.ToMessagePreviewDTO(ctx, message.GetSource())
+		dto.Reference.Preview = &preview
+	}
+}
+
+// findUploads loads the uploads to send with a message; the channel checks they belong to the sender
+func (s *MessageService) findUploads(ctx context.Context, channelID uuid.UUID, attachmentIDs []uuid.UUID) ([]domain.Attachment, error) {
+	if len(attachmentIDs) > domain.MaxAttachmentsPerMessage {
+		return nil, domain.ErrTooManyAttachments
+	}
+
+	attachments, err := s.repo.FindUnsentAttachments(ctx, channelID, attachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) != len(attachmentIDs) {
+		return nil, domain.ErrAttachmentUnavailable
+	}
+	return attachments, nil
+}
+
 // getSenderUser returns the user with information from the identity service
 func (s *MessageService) getSenderUser(ctx context.Context, userID string) (*domain.User, error) {
 	pbUser, err := s.identityClient.GetUserByID(ctx, userID)
@@ -278,6 +1082,34 @@ func (s *MessageService) getSenderUser(ctx context.Context, userID string) (*dom
 	return user, nil
 }
 
+// resolveMentions looks up the @usernames in the content with a single identity call and stores the matching user IDs.
+// A failed lookup is logged and leaves the message without mentions rather than failing the send.
+func (s *MessageService) resolveMentions(ctx context.Context, content *domain.MessageContent) {
+	logger := s.logger.WithMethod("resolveMentions")
+
+	usernames := content.GetMentionedUsernames()
+	if len(usernames) == 0 {
+		return
+	}
+
+	resp, err := s.identityClient.GetUsersByUsernames(ctx, usernames)
+	if err != nil {
+		logger.Warn("Failed to resolve mentions", zap.Error(err))
+		return
+	}
+
+	mentions := make(map[string]uuid.UUID, len(resp.Users))
+	for _, user := range resp.Users {
+		userID, err := uuid.Parse(user.Id)
+		if err != nil {
+			logger.Warn("Invalid user ID in mention lookup", zap.String("user_id", user.Id), zap.Error(err))
+			continue
+		}
+		mentions[strings.ToLower(user.Username)] = userID
+	}
+	content.SetResolvedMentions(mentions)
+}
+
 // getSenderIntegrationBot returns the integration bot with information from the integration service
 func (s *MessageService) getSenderIntegrationBot(ctx context.Context, id string) (*domain.IntegrationBot, error) {
 	pbIntegration, err := s.integrationClient.GetIntegration(ctx, id)
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+const pollCloseBatchSize = 100
+
+// PollNotifier delivers the final tally of a poll to the members of its channel
+type PollNotifier interface {
+	BroadcastPollUpdated(message *domain.Message)
+}
+
+// PollCloser periodically closes polls whose closing time has passed and broadcasts their results.
+// Polls are closed with a conditional update, so running several instances closes each one once.
+type PollCloser struct {
+	messageService *MessageService
+	notifier       PollNotifier
+	interval       time.Duration
+	logger         *logging.Logger
+}
+
+func NewPollCloser(messageService *MessageService, notifier PollNotifier, interval time.Duration, logger *logging.Logger) *PollCloser {
+	if interval <= 0 {
+		interval = DefaultScheduledDispatchInterval
+	}
+	return &PollCloser{
+		messageService: messageService,
+		notifier:       notifier,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+// Run closes due polls until the context is cancelled
+func (c *PollCloser) Run(ctx context.Context) {
+	logger := c.logger.WithMethod("Run")
+	logger.Info("Poll closer started", zap.Duration("interval", c.interval))
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Poll closer stopped")
+			return
+		case <-ticker.C:
+			c.closeDue(ctx)
+		}
+	}
+}
+
+func (c *PollCloser) closeDue(ctx context.Context) {
+	now := time.Now().UTC()
+	for {
+		polls, err := c.messageService.HandleCloseDuePolls(ctx, now, pollCloseBatchSize)
+		if err != nil {
+			return
+		}
+
+		if c.notifier != nil {
+			for i := range polls {
+				c.notifier.BroadcastPollUpdated(&polls[i])
+			}
+		}
+
+		if len(polls) < pollCloseBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+const reminderDispatchBatchSize = 100
+
+// ReminderNotifier delivers a fired reminder to the user's connected devices
+type ReminderNotifier interface {
+	BroadcastReminderDue(reminder *domain.Reminder)
+}
+
+// ReminderDispatcher periodically fires due reminders and notifies their users.
+// Like scheduled messages, reminders are claimed in the database, so running several instances fires each one once.
+// Users who are offline find fired reminders through the REST API.
+type ReminderDispatcher struct {
+	reminderService *ReminderService
+	notifier        ReminderNotifier
+	interval        time.Duration
+	logger          *logging.Logger
+}
+
+func NewReminderDispatcher(reminderService *ReminderService, notifier ReminderNotifier, interval time.Duration, logger *logging.Logger) *ReminderDispatcher {
+	if interval <= 0 {
+		interval = DefaultScheduledDispatchInterval
+	}
+	return &ReminderDispatcher{
+		reminderService: reminderService,
+		notifier:        notifier,
+		interval:        interval,
+		logger:          logger,
+	}
+}
+
+// Run fires due reminders until the context is cancelled
+func (d *ReminderDispatcher) Run(ctx context.Context) {
+	logger := d.logger.WithMethod("Run")
+	logger.Info("Reminder dispatcher started", zap.Duration("interval", d.interval))
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Reminder dispatcher stopped")
+			return
+		case <-ticker.C:
+			d.dispatchDue(ctx)
+		}
+	}
+}
+
+func (d *ReminderDispatcher) dispatchDue(ctx context.Context) {
+	now := time.Now().UTC()
+	for {
+		reminders, err := d.reminderService.HandleFireDueReminders(ctx, now, reminderDispatchBatchSize)
+		if err != nil {
+			return
+		}
+
+		if d.notifier != nil {
+			for i := range reminders {
+				d.notifier.BroadcastReminderDue(&reminders[i])
+			}
+		}
+
+		if len(reminders) < reminderDispatchBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
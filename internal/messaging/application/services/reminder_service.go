@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+type ReminderService struct {
+	repo        persistence.ReminderRepository
+	channelRepo persistence.ChannelRepository
+	logger      *logging.Logger
+}
+
+func NewReminderService(repo persistence.ReminderRepository, channelRepo persistence.ChannelRepository, logger *logging.Logger) *ReminderService {
+	return &ReminderService{
+		repo:        repo,
+		channelRepo: channelRepo,
+		logger:      logger,
+	}
+}
+
+// HandleSetReminder sets a reminder about a message for the user
+func (s *ReminderService) HandleSetReminder(ctx context.Context, cmd domain.SetReminderCommand) (*domain.Reminder, error) {
+	logger := s.logger.WithMethod("HandleSetReminder")
+	logger.Info("Setting reminder", zap.String("message_id", cmd.MessageID.String()), zap.Time("remind_at", cmd.RemindAt))
+
+	channel, err := s.channelRepo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	message, err := s.channelRepo.FindMessageByID(ctx, cmd.ChannelID, cmd.MessageID)
+	if err != nil {
+		logger.Error("Failed to find message", zap.Error(err))
+		return nil, err
+	}
+
+	count, err := s.repo.CountPending(ctx, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to count reminders", zap.Error(err))
+		return nil, err
+	}
+	if count >= domain.MaxPendingReminders {
+		logger.Warn("Reminder limit reached", zap.String("user_id", cmd.UserID.String()))
+		return nil, domain.ErrReminderLimit
+	}
+
+	reminder, err := domain.NewReminder(cmd.UserID, channel, message, cmd.RemindAt)
+	if err != nil {
+		logger.Error("Failed to create reminder", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, reminder); err != nil {
+		logger.Error("Failed to save reminder", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Reminder set", zap.String("reminder_id", reminder.GetId().String()))
+	return reminder, nil
+}
+
+// HandleSnoozeReminder moves a pending or fired reminder to a later time
+func (s *ReminderService) HandleSnoozeReminder(ctx context.Context, cmd domain.SnoozeReminderCommand) (*domain.Reminder, error) {
+	logger := s.logger.WithMethod("HandleSnoozeReminder")
+	logger.Info("Snoozing reminder", zap.String("reminder_id", cmd.ReminderID.String()), zap.Time("remind_at", cmd.RemindAt))
+
+	reminder, err := s.repo.FindByID(ctx, cmd.UserID, cmd.ReminderID)
+	if err != nil {
+		logger.Error("Failed to find reminder", zap.Error(err))
+		return nil, err
+	}
+
+	if err := reminder.Snooze(cmd.RemindAt); err != nil {
+		logger.Error("Failed to snooze reminder", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.UpdateRemindAt(ctx, reminder); err != nil {
+		logger.Error("Failed to save reminder", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.attachMessages(ctx, []*domain.Reminder{reminder}); err != nil {
+		logger.Error("Failed to load reminder message", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Reminder snoozed", zap.String("reminder_id", reminder.GetId().String()))
+	return reminder, nil
+}
+
+// HandleDeleteReminder removes one of the user's reminders, pending or fired
+func (s *ReminderService) HandleDeleteReminder(ctx context.Context, cmd domain.DeleteReminderCommand) error {
+	logger := s.logger.WithMethod("HandleDeleteReminder")
+	logger.Info("Deleting reminder", zap.String("reminder_id", cmd.ReminderID.String()))
+
+	if err := s.repo.Delete(ctx, cmd.UserID, cmd.ReminderID); err != nil {
+		logger.Error("Failed to delete reminder", zap.Error(err))
+		return err
+	}
+
+	logger.Info("Reminder deleted", zap.String("reminder_id", cmd.ReminderID.String()))
+	return nil
+}
+
+// HandleListReminders returns the user's reminders together with their messages, soonest first
+func (s *ReminderService) HandleListReminders(ctx context.Context, cmd domain.ListRemindersCommand) ([]domain.Reminder, error) {
+	logger := s.logger.WithMethod("HandleListReminders")
+	logger.Info("Listing reminders", zap.String("user_id", cmd.UserID.String()))
+
+	reminders, err := s.repo.FindByUser(ctx, cmd.UserID, cmd.Status)
+	if err != nil {
+		logger.Error("Failed to find reminders", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.attachMessages(ctx, reminderPointers(reminders)); err != nil {
+		logger.Error("Failed to load reminder messages", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Reminders listed", zap.Int("count", len(reminders)))
+	return reminders, nil
+}
+
+// HandleFireDueReminders marks due reminders as fired and returns them with their messages, ready to be delivered
+func (s *ReminderService) HandleFireDueReminders(ctx context.Context, now time.Time, limit int) ([]domain.Reminder, error) {
+	logger := s.logger.WithMethod("HandleFireDueReminders")
+
+	reminders, err := s.repo.ClaimDue(ctx, now, limit)
+	if err != nil {
+		logger.Error("Failed to claim due reminders", zap.Error(err))
+		return nil, err
+	}
+	if len(reminders) == 0 {
+		return reminders, nil
+	}
+
+	if err := s.attachMessages(ctx, reminderPointers(reminders)); err != nil {
+		// The reminders have fired already; deliver them without their messages rather than not at all
+		logger.Warn("Failed to load reminder messages", zap.Error(err))
+	}
+
+	logger.Info("Reminders fired", zap.Int("count", len(reminders)))
+	return reminders, nil
+}
+
+func (s *ReminderService) attachMessages(ctx context.Context, reminders []*domain.Reminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	messageIDs := make([]uuid.UUID, len(reminders))
+	for i, reminder := range reminders {
+		messageIDs[i] = reminder.GetMessageId()
+	}
+
+	messages, err := s.channelRepo.FindMessagesByIDs(ctx, messageIDs)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uuid.UUID]*domain.Message, len(messages))
+	for i := range messages {
+		byID[messages[i].GetId()] = &messages[i]
+	}
+	for _, reminder := range reminders {
+		reminder.SetLoadedMessage(byID[reminder.GetMessageId()])
+	}
+	return nil
+}
+
+func reminderPointers(reminders []domain.Reminder) []*domain.Reminder {
+	pointers := make([]*domain.Reminder, len(reminders))
+	for i := range reminders {
+		pointers[i] = &reminders[i]
+	}
+	return pointers
+}
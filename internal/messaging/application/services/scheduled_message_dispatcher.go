@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultScheduledDispatchInterval = 15 * time.Second
+	scheduledDispatchBatchSize       = 50
+	scheduledSendTimeout             = 30 * time.Second
+	// A claim older than this belongs to a dispatcher that stopped mid-send
+	scheduledClaimLease = 5 * time.Minute
+)
+
+// MessageBroadcaster delivers a newly posted message to the connected clients of its channel
+type MessageBroadcaster interface {
+	BroadcastMessage(message *domain.Message)
+}
+
+// ScheduledMessageDispatcher periodically posts scheduled messages that are due.
+// Every messaging instance runs one; messages are claimed in the database before sending,
+// so an instance never posts a message another instance has already picked up.
+type ScheduledMessageDispatcher struct {
+	repo           persistence.ScheduledMessageRepository
+	messageService *MessageService
+	broadcaster    MessageBroadcaster
+	interval       time.Duration
+	logger         *logging.Logger
+}
+
+func NewScheduledMessageDispatcher(repo persistence.ScheduledMessageRepository, messageService *MessageService, broadcaster MessageBroadcaster, interval time.Duration, logger *logging.Logger) *ScheduledMessageDispatcher {
+	if interval <= 0 {
+		interval = DefaultScheduledDispatchInterval
+	}
+	return &ScheduledMessageDispatcher{
+		repo:           repo,
+		messageService: messageService,
+		broadcaster:    broadcaster,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+// Run dispatches due messages until the context is cancelled
+func (d *ScheduledMessageDispatcher) Run(ctx context.Context) {
+	logger := d.logger.WithMethod("Run")
+	logger.Info("Scheduled message dispatcher started", zap.Duration("interval", d.interval))
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Scheduled message dispatcher stopped")
+			return
+		case <-ticker.C:
+			d.dispatchDue(ctx)
+		}
+	}
+}
+
+func (d *ScheduledMessageDispatcher) dispatchDue(ctx context.Context) {
+	logger := d.logger.WithMethod("dispatchDue")
+
+	now := time.Now().UTC()
+	failed, err := d.repo.FailStaleClaims(ctx, now.Add(-scheduledClaimLease), "delivery was interrupted; check the channel before rescheduling")
+	if err != nil {
+		logger.Error("Failed to release stale claims", zap.Error(err))
+	} else if failed > 0 {
+		logger.Warn("Failed stale scheduled message claims", zap.Int64("count", failed))
+	}
+
+	for {
+		messages, err := d.repo.ClaimDue(ctx, now, scheduledDispatchBatchSize)
+		if err != nil {
+			logger.Error("Failed to claim due scheduled messages", zap.Error(err))
+			return
+		}
+
+		for i := range messages {
+			d.send(ctx, &messages[i])
+		}
+
+		if len(messages) < scheduledDispatchBatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// send posts a claimed message through the regular send flow, so the usual events and broadcasts go out
+func (d *ScheduledMessageDispatcher) send(ctx context.Context, scheduled *domain.ScheduledMessage) {
+	logger := d.logger.WithMethod("send")
+
+	sendCtx, cancel := context.WithTimeout(ctx, scheduledSendTimeout)
+	defer cancel()
+
+	message, err := d.messageService.HandleMessageSent(sendCtx, scheduled.ToSendCommand())
+	if err != nil {
+		logger.Error("Failed to send scheduled message", zap.String("scheduled_message_id", scheduled.GetId().String()), zap.Error(err))
+		if err := d.repo.MarkFailed(ctx, scheduled.GetId(), err.Error()); err != nil {
+			logger.Error("Failed to mark scheduled message as failed", zap.Error(err))
+		}
+		return
+	}
+
+	if err := d.repo.MarkSent(ctx, scheduled.GetId()); err != nil {
+		logger.Error("Failed to remove sent scheduled message", zap.String("scheduled_message_id", scheduled.GetId().String()), zap.Error(err))
+	}
+
+	if d.broadcaster != nil {
+		d.broadcaster.BroadcastMessage(message)
+	}
+	logger.Info("Scheduled message sent", zap.String("scheduled_message_id", scheduled.GetId().String()), zap.String("message_id", message.GetId().String()))
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/internal/messaging/infrastructure/persistence"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+type ScheduledMessageService struct {
+	repo        persistence.ScheduledMessageRepository
+	channelRepo persistence.ChannelRepository
+	logger      *logging.Logger
+}
+
+func NewScheduledMessageService(repo persistence.ScheduledMessageRepository, channelRepo persistence.ChannelRepository, logger *logging.Logger) *ScheduledMessageService {
+	return &ScheduledMessageService{
+		repo:        repo,
+		channelRepo: channelRepo,
+		logger:      logger,
+	}
+}
+
+// HandleScheduleMessage stores a message to be posted to the channel at the requested time
+func (s *ScheduledMessageService) HandleScheduleMessage(ctx context.Context, cmd domain.ScheduleMessageCommand) (*domain.ScheduledMessage, error) {
+	logger := s.logger.WithMethod("HandleScheduleMessage")
+	logger.Info("Scheduling message", zap.String("channel_id", cmd.ChannelID.String()), zap.Time("scheduled_for", cmd.ScheduledFor))
+
+	channel, err := s.channelRepo.FindById(ctx, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find channel", zap.Error(err))
+		return nil, err
+	}
+
+	if cmd.ParentMessageID != nil {
+		if _, err := s.channelRepo.FindMessageByID(ctx, cmd.ChannelID, *cmd.ParentMessageID); err != nil {
+			logger.Error("Failed to find parent message", zap.Error(err))
+			return nil, fmt.Errorf("error finding parent message: %w", err)
+		}
+	}
+
+	count, err := s.repo.CountByUser(ctx, cmd.UserID)
+	if err != nil {
+		logger.Error("Failed to count scheduled messages", zap.Error(err))
+		return nil, err
+	}
+	if count >= domain.MaxPendingScheduledMessages {
+		logger.Warn("Scheduled message limit reached", zap.String("user_id", cmd.UserID.String()))
+		return nil, domain.ErrScheduledMessageLimit
+	}
+
+	message, err := domain.NewScheduledMessage(channel, cmd.UserID, cmd.ContentText, cmd.ParentMessageID, cmd.ScheduledFor)
+	if err != nil {
+		logger.Error("Failed to create scheduled message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Save(ctx, message); err != nil {
+		logger.Error("Failed to save scheduled message", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Message scheduled", zap.String("scheduled_message_id", message.GetId().String()))
+	return message, nil
+}
+
+// HandleUpdateScheduledMessage changes the content and time of a scheduled message that has not been sent yet
+func (s *ScheduledMessageService) HandleUpdateScheduledMessage(ctx context.Context, cmd domain.UpdateScheduledMessageCommand) (*domain.ScheduledMessage, error) {
+	logger := s.logger.WithMethod("HandleUpdateScheduledMessage")
+	logger.Info("Updating scheduled message", zap.String("scheduled_message_id", cmd.ScheduledMessageID.String()))
+
+	message, err := s.repo.FindByID(ctx, cmd.UserID, cmd.ScheduledMessageID)
+	if err != nil {
+		logger.Error("Failed to find scheduled message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := message.Update(cmd.ContentText, cmd.ScheduledFor); err != nil {
+		logger.Error("Failed to update scheduled message", zap.Error(err))
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, message); err != nil {
+		logger.Error("Failed to save scheduled message", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Scheduled message updated", zap.String("scheduled_message_id", message.GetId().String()))
+	return message, nil
+}
+
+// HandleCancelScheduledMessage deletes a scheduled message that has not been sent yet
+func (s *ScheduledMessageService) HandleCancelScheduledMessage(ctx context.Context, cmd domain.CancelScheduledMessageCommand) error {
+	logger := s.logger.WithMethod("HandleCancelScheduledMessage")
+	logger.Info("Cancelling scheduled message", zap.String("scheduled_message_id", cmd.ScheduledMessageID.String()))
+
+	message, err := s.repo.FindByID(ctx, cmd.UserID, cmd.ScheduledMessageID)
+	if err != nil {
+		logger.Error("Failed to find scheduled message", zap.Error(err))
+		return err
+	}
+
+	if err := message.EnsureCancellable(); err != nil {
+		logger.Error("Failed to cancel scheduled message", zap.Error(err))
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, cmd.UserID, cmd.ScheduledMessageID); err != nil {
+		logger.Error("Failed to delete scheduled message", zap.Error(err))
+		return err
+	}
+
+	logger.Info("Scheduled message cancelled", zap.String("scheduled_message_id", cmd.ScheduledMessageID.String()))
+	return nil
+}
+
+// HandleListScheduledMessages returns the user's unsent scheduled messages, soonest first
+func (s *ScheduledMessageService) HandleListScheduledMessages(ctx context.Context, cmd domain.ListScheduledMessagesCommand) ([]domain.ScheduledMessage, error) {
+	logger := s.logger.WithMethod("HandleListScheduledMessages")
+	logger.Info("Listing scheduled messages", zap.String("user_id", cmd.UserID.String()))
+
+	messages, err := s.repo.FindByUser(ctx, cmd.UserID, cmd.ChannelID)
+	if err != nil {
+		logger.Error("Failed to find scheduled messages", zap.Error(err))
+		return nil, err
+	}
+
+	logger.Info("Scheduled messages listed", zap.Int("count", len(messages)))
+	return messages, nil
+}
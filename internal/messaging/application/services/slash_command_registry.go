@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// SlashCommandHandler runs a slash command. Returned errors are failures of the command itself;
+// usage mistakes should be answered with an ephemeral result instead.
+type SlashCommandHandler func(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error)
+
+// SlashCommand is a command users can run by starting a message with /Name
+type SlashCommand struct {
+	Name        string
+	Usage       string
+	Description string
+	Handler     SlashCommandHandler
+}
+
+// SlashCommandRegistry holds the commands available in every channel.
+// Built-in commands are registered at startup; integrations can register their own through Register.
+type SlashCommandRegistry struct {
+	mu       sync.RWMutex
+	commands map[string]SlashCommand
+	logger   *logging.Logger
+}
+
+func NewSlashCommandRegistry(logger *logging.Logger) *SlashCommandRegistry {
+	return &SlashCommandRegistry{
+		commands: make(map[string]SlashCommand),
+		logger:   logger,
+	}
+}
+
+// Register adds a command; names are case-insensitive and cannot be registered twice
+func (r *SlashCommandRegistry) Register(command SlashCommand) error {
+	name := strings.ToLower(command.Name)
+	if _, _, ok := domain.ParseSlashCommand("/" + name); !ok {
+		return fmt.Errorf("invalid slash command name %q", command.Name)
+	}
+	if command.Handler == nil {
+		return fmt.Errorf("slash command /%s has no handler", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.commands[name]; exists {
+		return fmt.Errorf("slash command /%s is already registered", name)
+	}
+	command.Name = name
+	r.commands[name] = command
+	return nil
+}
+
+// Commands returns the registered commands sorted by name
+func (r *SlashCommandRegistry) Commands() []SlashCommand {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	commands := make([]SlashCommand, 0, len(r.commands))
+	for _, command := range r.commands {
+		commands = append(commands, command)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// Execute runs the invoked command
+func (r *SlashCommandRegistry) Execute(ctx context.Context, invocation domain.SlashCommandInvocation) (*domain.SlashCommandResult, error) {
+	logger := r.logger.WithMethod("Execute")
+	logger.Info("Executing slash command", zap.String("command", invocation.Name), zap.String("channel_id", invocation.ChannelID.String()))
+
+	r.mu.RLock()
+	command, ok := r.commands[invocation.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("/%s: %w", invocation.Name, domain.ErrUnknownSlashCommand)
+	}
+
+	result, err := command.Handler(ctx, invocation)
+	if err != nil {
+		logger.Error("Slash command failed", zap.String("command", invocation.Name), zap.Error(err))
+		return nil, err
+	}
+	if result == nil {
+		result = &domain.SlashCommandResult{}
+	}
+
+	logger.Info("Slash command executed", zap.String("command", invocation.Name))
+	return result, nil
+}
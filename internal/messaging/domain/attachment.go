@@ -0,0 +1,379 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxAttachmentSize is the largest file that can be uploaded, in bytes
+	MaxAttachmentSize = 25 << 20
+	// MaxAttachmentsPerMessage is how many files can be sent with one message
+	MaxAttachmentsPerMessage = 10
+	// UnattachedUploadLifetime is how long an upload that was never sent with a message is kept
+	UnattachedUploadLifetime = 24 * time.Hour
+
+	maxAttachmentFileNameLength = 255
+	defaultAttachmentFileName   = "file"
+)
+
+var (
+	ErrAttachmentTooLarge       = fmt.Errorf("file is larger than %d MB", MaxAttachmentSize>>20)
+	ErrAttachmentTypeNotAllowed = errors.New("file type is not allowed")
+	ErrTooManyAttachments       = fmt.Errorf("a message can have at most %d attachments", MaxAttachmentsPerMessage)
+	// ErrAttachmentUnavailable is returned when an upload does not exist, belongs to someone else or was already sent
+	ErrAttachmentUnavailable = errors.New("attachment is not available")
+	// ErrAttachmentProcessed is returned when the result of processing an attachment is recorded twice
+	ErrAttachmentProcessed = errors.New("attachment was already processed")
+)
+
+// AttachmentProcessingStatus tracks the thumbnails and metadata generated for images in the background
+type AttachmentProcessingStatus string
+
+const (
+	// AttachmentProcessingNone is the status of files that are not processed, such as documents
+	AttachmentProcessingNone    AttachmentProcessingStatus = "none"
+	AttachmentProcessingPending AttachmentProcessingStatus = "pending"
+	AttachmentProcessingReady   AttachmentProcessingStatus = "ready"
+	// AttachmentProcessingFailed is the status of images that could not be decoded. They can still be downloaded.
+	AttachmentProcessingFailed AttachmentProcessingStatus = "failed"
+)
+
+// allowedAttachmentTypes are the content types accepted for uploads, as detected from the file contents.
+// Types a browser would render as a page, such as HTML and SVG, are never accepted.
+var allowedAttachmentTypes = map[string]bool{
+	"image/jpeg":         true,
+	"image/png":          true,
+	"image/gif":          true,
+	"image/webp":         true,
+	"image/bmp":          true,
+	"application/pdf":    true,
+	"text/plain":         true,
+	"application/zip":    true,
+	"application/x-gzip": true,
+	"audio/mpeg":         true,
+	"audio/wave":         true,
+	"application/ogg":    true,
+	"video/mp4":          true,
+	"video/webm":         true,
+}
+
+// IsAllowedAttachmentType reports whether files of the given content type can be uploaded
+func IsAllowedAttachmentType(contentType string) bool {
+	return allowedAttachmentTypes[contentType]
+}
+
+// IsProcessableImageType reports whether thumbnails and metadata are generated for files of the given content type
+func IsProcessableImageType(contentType string) bool {
+	return IsAllowedAttachmentType(contentType) && strings.HasPrefix(contentType, "image/")
+}
+
+// AttachmentThumbnail is a scaled down copy of an image attachment
+type AttachmentThumbnail struct {
+	name        string
+	width       int
+	height      int
+	contentType string
+	size        int64
+	storageKey  string
+}
+
+// For external usage
+func RehydrateAttachmentThumbnail(name string, width, height int, contentType string, size int64, storageKey string) AttachmentThumbnail {
+	return AttachmentThumbnail{
+		name:        name,
+		width:       width,
+		height:      height,
+		contentType: contentType,
+		size:        size,
+		storageKey:  storageKey,
+	}
+}
+
+func (t *AttachmentThumbnail) GetName() string {
+	return t.name
+}
+
+func (t *AttachmentThumbnail) GetWidth() int {
+	return t.width
+}
+
+func (t *AttachmentThumbnail) GetHeight() int {
+	return t.height
+}
+
+func (t *AttachmentThumbnail) GetContentType() string {
+	return t.contentType
+}
+
+func (t *AttachmentThumbnail) GetSize() int64 {
+	return t.size
+}
+
+// GetStorageKey returns the key of the thumbnail in the blob store
+func (t *AttachmentThumbnail) GetStorageKey() string {
+	return t.storageKey
+}
+
+// Attachment is a file uploaded to a channel. It is linked to a message once the message is sent.
+type Attachment struct {
+	id          uuid.UUID
+	channelId   uuid.UUID
+	uploaderId  uuid.UUID
+	messageId   *uuid.UUID // nil until the upload is sent with a message
+	fileName    string
+	contentType string
+	size        int64
+	storageKey  string
+	createdAt   time.Time
+
+	// Image metadata, set once processing is done
+	processingStatus AttachmentProcessingStatus
+	width            int
+	height           int
+	blurHash         string
+	dominantColor    string
+	thumbnails       []AttachmentThumbnail
+}
+
+// NewAttachment records an upload to a channel. The uploader must be a member of the channel and
+// the channel must not be archived. Images start out pending processing.
+func NewAttachment(channel *Channel, uploaderID uuid.UUID, fileName, contentType string, size int64) (*Attachment, error) {
+	if channel.findMember(uploaderID) == nil {
+		return nil, errors.New("user is not a member of the channel")
+	}
+	if channel.IsArchived {
+		return nil, errors.New("files cannot be uploaded to an archived channel")
+	}
+	if size <= 0 {
+		return nil, errors.New("file is empty")
+	}
+	if size > MaxAttachmentSize {
+		return nil, ErrAttachmentTooLarge
+	}
+	if !IsAllowedAttachmentType(contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentTypeNotAllowed, contentType)
+	}
+
+	processingStatus := AttachmentProcessingNone
+	if IsProcessableImageType(contentType) {
+		processingStatus = AttachmentProcessingPending
+	}
+
+	id := uuid.New()
+	return &Attachment{
+		id:          id,
+		channelId:   channel.ID,
+		uploaderId:  uploaderID,
+		fileName:    sanitizeAttachmentFileName(fileName),
+		contentType: contentType,
+		size:        size,
+		storageKey:  channel.ID.String() + "/" + id.String(),
+		createdAt:   time.Now().UTC(),
+
+		processingStatus: processingStatus,
+	}, nil
+}
+
+// For external usage
+func RehydrateAttachment(id, channelId, uploaderId uuid.UUID, messageId *uuid.UUID, fileName, contentType string, size int64, storageKey string, createdAt time.Time, processingStatus AttachmentProcessingStatus, width, height int, blurHash, dominantColor string, thumbnails []AttachmentThumbnail) Attachment {
+	return Attachment{
+		id:               id,
+		channelId:        channelId,
+		uploaderId:       uploaderId,
+		messageId:        messageId,
+		fileName:         fileName,
+		contentType:      contentType,
+		size:             size,
+		storageKey:       storageKey,
+		createdAt:        createdAt,
+		processingStatus: processingStatus,
+		width:            width,
+		height:           height,
+		blurHash:         blurHash,
+		dominantColor:    dominantColor,
+		thumbnails:       thumbnails,
+	}
+}
+
+// sanitizeAttachmentFileName keeps only the base name of an uploaded file, without control characters
+func sanitizeAttachmentFileName(fileName string) string {
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	fileName = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, fileName))
+
+	if fileName == "" || fileName == "." || fileName == "/" || fileName == ".." {
+		return defaultAttachmentFileName
+	}
+	if utf8.RuneCountInString(fileName) > maxAttachmentFileNameLength {
+		runes := []rune(fileName)
+		fileName = string(runes[:maxAttachmentFileNameLength])
+	}
+	return fileName
+}
+
+func (a *Attachment) GetId() uuid.UUID {
+	return a.id
+}
+
+func (a *Attachment) GetChannelId() uuid.UUID {
+	return a.channelId
+}
+
+func (a *Attachment) GetUploaderId() uuid.UUID {
+	return a.uploaderId
+}
+
+func (a *Attachment) GetMessageId() *uuid.UUID {
+	return a.messageId
+}
+
+func (a *Attachment) GetFileName() string {
+	return a.fileName
+}
+
+func (a *Attachment) GetContentType() string {
+	return a.contentType
+}
+
+func (a *Attachment) GetSize() int64 {
+	return a.size
+}
+
+// GetStorageKey returns the key of the file contents in the blob store
+func (a *Attachment) GetStorageKey() string {
+	return a.storageKey
+}
+
+func (a *Attachment) GetCreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Attachment) GetProcessingStatus() AttachmentProcessingStatus {
+	return a.processingStatus
+}
+
+// GetWidth returns the width of an image as displayed, 0 until it is processed
+func (a *Attachment) GetWidth() int {
+	return a.width
+}
+
+// GetHeight returns the height of an image as displayed, 0 until it is processed
+func (a *Attachment) GetHeight() int {
+	return a.height
+}
+
+// GetBlurHash returns the BlurHash placeholder of an image
+func (a *Attachment) GetBlurHash() string {
+	return a.blurHash
+}
+
+// GetDominantColor returns the most common color of an image as #rrggbb
+func (a *Attachment) GetDominantColor() string {
+	return a.dominantColor
+}
+
+// GetThumbnails returns the thumbnails of an image, from smallest to largest. Images smaller than a
+// thumbnail size have no thumbnail of that size.
+func (a *Attachment) GetThumbnails() []AttachmentThumbnail {
+	return a.thumbnails
+}
+
+// FindThumbnail returns the thumbnail with the given name, or nil
+func (a *Attachment) FindThumbnail(name string) *AttachmentThumbnail {
+	for i := range a.thumbnails {
+		if a.thumbnails[i].name == name {
+			return &a.thumbnails[i]
+		}
+	}
+	return nil
+}
+
+// ThumbnailStorageKey returns the key a thumbnail of the attachment is stored under in the blob store
+func (a *Attachment) ThumbnailStorageKey(name string) string {
+	return a.storageKey + "_" + name
+}
+
+// NeedsProcessing reports whether thumbnails and metadata still have to be generated for the attachment
+func (a *Attachment) NeedsProcessing() bool {
+	return a.processingStatus == AttachmentProcessingPending
+}
+
+// CompleteProcessing records the metadata and thumbnails generated for an image
+func (a *Attachment) CompleteProcessing(width, height int, blurHash, dominantColor string, thumbnails []AttachmentThumbnail) error {
+	if !a.NeedsProcessing() {
+		return ErrAttachmentProcessed
+	}
+	if width <= 0 || height <= 0 {
+		return errors.New("image dimensions must be positive")
+	}
+
+	a.processingStatus = AttachmentProcessingReady
+	a.width = width
+	a.height = height
+	a.blurHash = blurHash
+	a.dominantColor = dominantColor
+	a.thumbnails = thumbnails
+	return nil
+}
+
+// FailProcessing records that an image could not be processed, so it is served without thumbnails
+func (a *Attachment) FailProcessing() error {
+	if !a.NeedsProcessing() {
+		return ErrAttachmentProcessed
+	}
+	a.processingStatus = AttachmentProcessingFailed
+	return nil
+}
+
+// IsImage reports whether the attachment is an image a browser can display
+func (a *Attachment) IsImage() bool {
+	return strings.HasPrefix(a.contentType, "image/")
+}
+
+// CanBeViewedBy reports whether a user may download the attachment. Sent attachments are visible to the
+// members of the channel, uploads that were not sent yet only to the uploader.
+func (a *Attachment) CanBeViewedBy(channel *Channel, userID uuid.UUID) bool {
+	if channel.ID != a.channelId || channel.findMember(userID) == nil {
+		return false
+	}
+	return a.messageId != nil || a.uploaderId == userID
+}
+
+// AttachUploads links files the sender uploaded to the channel to a message being posted
+func (m *Message) AttachUploads(attachments []Attachment) error {
+	if len(attachments) > MaxAttachmentsPerMessage {
+		return ErrTooManyAttachments
+	}
+	if m.senderUserId == nil {
+		return errors.New("only messages sent by users can have attachments")
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for i := range attachments {
+		attachment := &attachments[i]
+		if seen[attachment.id] {
+			return fmt.Errorf("attachment %s is listed more than once", attachment.id)
+		}
+		seen[attachment.id] = true
+
+		if attachment.uploaderId != *m.senderUserId || attachment.channelId != m.channelId || attachment.messageId != nil {
+			return fmt.Errorf("%w: %s", ErrAttachmentUnavailable, attachment.id)
+		}
+		attachment.messageId = &m.id
+	}
+
+	m.attachments = attachments
+	return nil
+}
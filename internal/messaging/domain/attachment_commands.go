@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type UploadAttachmentCommand struct {
+	ChannelID  uuid.UUID
+	UploaderID uuid.UUID
+	FileName   string
+	Size       int64
+	Content    io.Reader
+}
+
+func (c UploadAttachmentCommand) CommandName() string {
+	return "UploadAttachment"
+}
+
+type GetAttachmentURLCommand struct {
+	AttachmentID uuid.UUID
+	UserID       uuid.UUID
+}
+
+func (c GetAttachmentURLCommand) CommandName() string {
+	return "GetAttachmentURL"
+}
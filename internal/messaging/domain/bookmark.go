@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultBookmarkPageSize = 25
+	MaxBookmarkPageSize     = 100
+	MaxBookmarkNoteLength   = 500
+)
+
+// Bookmark is a message a user saved for later, optionally with a personal note
+type Bookmark struct {
+	id        uuid.UUID
+	userId    uuid.UUID
+	channelId uuid.UUID
+	messageId uuid.UUID
+	note      string
+	createdAt time.Time
+	message   *Message
+}
+
+// NewBookmark saves a message for a user. The user must be a member of the channel the message was posted in.
+func NewBookmark(userID uuid.UUID, channel *Channel, message *Message, note string) (*Bookmark, error) {
+	if channel.findMember(userID) == nil {
+		return nil, errors.New("user is not a member of the channel")
+	}
+	if message.GetChannelId() != channel.ID {
+		return nil, errors.New("message does not belong to this channel")
+	}
+	if message.IsDeleted() {
+		return nil, errors.New("deleted messages cannot be bookmarked")
+	}
+	if len(note) > MaxBookmarkNoteLength {
+		return nil, fmt.Errorf("bookmark note cannot be longer than %d characters", MaxBookmarkNoteLength)
+	}
+
+	return &Bookmark{
+		id:        uuid.New(),
+		userId:    userID,
+		channelId: channel.ID,
+		messageId: message.GetId(),
+		note:      note,
+		createdAt: time.Now().UTC(),
+		message:   message,
+	}, nil
+}
+
+// For external usage
+func RehydrateBookmark(id, userId, channelId, messageId uuid.UUID, note string, createdAt time.Time) Bookmark {
+	return Bookmark{
+		id:        id,
+		userId:    userId,
+		channelId: channelId,
+		messageId: messageId,
+		note:      note,
+		createdAt: createdAt,
+	}
+}
+
+func (b *Bookmark) GetId() uuid.UUID {
+	return b.id
+}
+
+func (b *Bookmark) GetUserId() uuid.UUID {
+	return b.userId
+}
+
+func (b *Bookmark) GetChannelId() uuid.UUID {
+	return b.channelId
+}
+
+func (b *Bookmark) GetMessageId() uuid.UUID {
+	return b.messageId
+}
+
+func (b *Bookmark) GetNote() string {
+	return b.note
+}
+
+func (b *Bookmark) GetCreatedAt() time.Time {
+	return b.createdAt
+}
+
+// GetMessage returns the bookmarked message when it has been loaded
+func (b *Bookmark) GetMessage() *Message {
+	return b.message
+}
+
+func (b *Bookmark) SetLoadedMessage(message *Message) {
+	b.message = message
+}
+
+// BookmarkQuery describes a page of a user's bookmarks; Before is the ID of the last bookmark of the previous page
+type BookmarkQuery struct {
+	Limit  int
+	Before *uuid.UUID
+}
+
+// BookmarkPage is a page of bookmarks, most recently saved first.
+// Bookmarks of deleted messages and of channels the user has left are not included.
+// NextCursor is set when more bookmarks follow and is passed back as Before.
+type BookmarkPage struct {
+	Bookmarks  []Bookmark
+	NextCursor *uuid.UUID
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "github.com/google/uuid"
+
+type AddBookmarkCommand struct {
+	UserID    uuid.UUID
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	Note      string
+}
+
+func (c AddBookmarkCommand) CommandName() string {
+	return "AddBookmark"
+}
+
+type RemoveBookmarkCommand struct {
+	UserID     uuid.UUID
+	BookmarkID uuid.UUID
+}
+
+func (c RemoveBookmarkCommand) CommandName() string {
+	return "RemoveBookmark"
+}
+
+type ListBookmarksCommand struct {
+	UserID uuid.UUID
+	Query  BookmarkQuery
+}
+
+func (c ListBookmarksCommand) CommandName() string {
+	return "ListBookmarks"
+}
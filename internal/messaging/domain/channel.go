@@ -11,11 +11,19 @@ import (
 	"github.com/m1thrandir225/meridian/pkg/common"
 )
 
+var (
+	ErrNotChannelMember = errors.New("user is not a member of the channel")
+	ErrChannelArchived  = errors.New("channel is archived")
+)
+
 // Channel represents a chat channel in the system
 // It is the aggregate root for the channel domain
 // It contains all the information about a channel, including its members, messages, and invites
 type Channel struct {
 	ID              uuid.UUID
+	Kind            ChannelKind
+	DirectKey       string
+	Visibility      ChannelVisibility
 	Name            string
 	Topic           string
 	CreationTime    time.Time
@@ -23,6 +31,7 @@ type Channel struct {
 	Members         []Member
 	Messages        []Message
 	Invites         []ChannelInvite
+	Bans            []ChannelBan
 	LastMessageTime time.Time
 	IsArchived      bool
 	Version         int64
@@ -30,17 +39,22 @@ type Channel struct {
 }
 
 // NewChannel creates a new channel
-func NewChannel(name, topic string, creatorUserID uuid.UUID) (*Channel, error) {
+func NewChannel(name, topic string, visibility ChannelVisibility, creatorUserID uuid.UUID) (*Channel, error) {
 	if name == "" {
 		return nil, errors.New("channel name cannot be empty")
 	}
+	if visibility != ChannelVisibilityPublic && visibility != ChannelVisibilityPrivate {
+		return nil, fmt.Errorf("invalid channel visibility %q", visibility)
+	}
 
 	now := time.Now().UTC()
 	channelID := uuid.New()
-	creator := newMember(creatorUserID, "owner", now, now)
+	creator := newMember(creatorUserID, RoleOwner, now, now)
 
 	channel := &Channel{
 		ID:              channelID,
+		Kind:            ChannelKindChannel,
+		Visibility:      visibility,
 		Name:            name,
 		Topic:           topic,
 		CreatorUserID:   creatorUserID,
@@ -48,6 +62,7 @@ func NewChannel(name, topic string, creatorUserID uuid.UUID) (*Channel, error) {
 		Members:         []Member{creator},
 		Messages:        []Message{},
 		Invites:         []ChannelInvite{},
+		Bans:            []ChannelBan{},
 		LastMessageTime: now,
 		IsArchived:      false,
 		Version:         1,
@@ -71,13 +86,19 @@ func (c *Channel) ClearPendingEvents() {
 
 // AddMember adds a member to a channel
 func (c *Channel) AddMember(userID uuid.UUID) error {
+	if c.IsDirect() {
+		return ErrDirectChannel
+	}
+	if c.IsBanned(userID) {
+		return ErrUserBanned
+	}
 	for _, member := range c.Members {
 		if member.GetId() == userID {
 			return errors.New("user is already a member of the channel")
 		}
 	}
 	now := time.Now().UTC()
-	member := newMember(userID, "member", now, now)
+	member := newMember(userID, RoleMember, now, now)
 	c.Members = append(c.Members, member)
 
 	c.addEvent(CreateUserJoinedChannelEvent(c, member))
@@ -85,33 +106,93 @@ func (c *Channel) AddMember(userID uuid.UUID) error {
 	return nil
 }
 
+// Join adds a user to a public channel.
+// Private channels can only be entered by accepting an invite.
+func (c *Channel) Join(userID uuid.UUID) error {
+	if !c.IsPublic() {
+		return ErrPrivateChannel
+	}
+	return c.AddMember(userID)
+}
+
 // RemoveMember removes a member from a channel
+// When the owner leaves, ownership passes to the longest-standing admin or member.
+// Direct conversations are identified by their member set, so nobody can leave them.
 func (c *Channel) RemoveMember(memberID uuid.UUID) error {
-	found := false
-	var searchMember Member
+	if c.IsDirect() {
+		return ErrDirectChannel
+	}
+	searchMember, found := c.removeMember(memberID)
+	if !found {
+		return errors.New("member not apart of the channel")
+	}
+	c.Version++
+	c.addEvent(CreateUserLeftChannelEvent(c, searchMember.GetId()))
+
+	if searchMember.GetRole() == RoleOwner {
+		if successor := c.longestStandingMember(memberID); successor != nil {
+			successor.setRole(RoleOwner)
+			c.addEvent(CreateChannelOwnershipTransferredEvent(c, memberID, successor.GetId(), memberID))
+		}
+	}
+
+	return nil
+}
+
+// removeMember drops a member from the member list without recording any event
+func (c *Channel) removeMember(memberID uuid.UUID) (Member, bool) {
 	for i, member := range c.Members {
 		if member.GetId() == memberID {
 			lastIdx := len(c.Members) - 1
-			searchMember = member
 			c.Members[i] = c.Members[lastIdx]
 			c.Members = c.Members[:lastIdx]
-			found = true
-			break
+			return member, true
 		}
 	}
-	if !found {
-		return errors.New("member not apart of the channel")
-	}
-	c.Version++
-	c.addEvent(CreateUserLeftChannelEvent(c, searchMember.GetId()))
+	return Member{}, false
+}
 
+func (c *Channel) findMember(userID uuid.UUID) *Member {
+	for i := range c.Members {
+		if c.Members[i].GetId() == userID {
+			return &c.Members[i]
+		}
+	}
 	return nil
 }
 
+// IsMember reports whether the user is a member of the channel
+func (c *Channel) IsMember(userID uuid.UUID) bool {
+	return c.findMember(userID) != nil
+}
+
+// MarkRead advances a member's read marker to the given message.
+// The marker never moves backwards; the returned bool reports whether it advanced.
+// Read markers are private to the member, so the channel version is not bumped and no event is recorded.
+func (c *Channel) MarkRead(userID uuid.UUID, message *Message) (*Member, bool, error) {
+	member := c.findMember(userID)
+	if member == nil {
+		return nil, false, errors.New("user is not a member of the channel")
+	}
+
+	if message.GetChannelId() != c.ID {
+		return nil, false, errors.New("message does not belong to this channel")
+	}
+
+	if !message.GetCreatedAt().After(member.GetLastRead()) {
+		return member, false, nil
+	}
+
+	messageID := message.GetId()
+	member.setLastRead(message.GetCreatedAt())
+	member.setLastReadMessageId(&messageID)
+	return member, true, nil
+}
+
 // ArchiveChannel archives a channel
 func (c *Channel) ArchiveChannel(userID uuid.UUID) error {
-	if c.CreatorUserID != userID {
-		return errors.New("only the channel owner can archive it")
+	if err := c.Authorize(userID, PermissionArchiveChannel); err != nil {
+		return err
 	}
 
 	c.IsArchived = true
@@ -123,8 +204,8 @@ func (c *Channel) ArchiveChannel(userID uuid.UUID) error {
 
 // UnarchiveChannel unarchives a channel
 func (c *Channel) UnarchiveChannel(userId uuid.UUID) error {
-	if c.CreatorUserID != userId {
-		return errors.New("only the channel owner can archive it")
+	if err := c.Authorize(userId, PermissionArchiveChannel); err != nil {
+		return err
 	}
 
 	c.IsArchived = false
@@ -136,8 +217,11 @@ func (c *Channel) UnarchiveChannel(userId uuid.UUID) error {
 
 // SetTopic sets the topic of a channel
 func (c *Channel) SetTopic(userID uuid.UUID, topic string) error {
-	if c.CreatorUserID != userID {
-		return errors.New("user does not have permission to do this action")
+	if c.IsDirect() {
+		return ErrDirectChannel
+	}
+	if err := c.Authorize(userID, PermissionSetTopic); err != nil {
+		return err
 	}
 
 	c.Topic = topic
@@ -147,6 +231,25 @@ func (c *Channel) SetTopic(userID uuid.UUID, topic string) error {
 	return nil
 }
 
+// Rename changes the name of a channel
+func (c *Channel) Rename(userID uuid.UUID, name string) error {
+	if c.IsDirect() {
+		return ErrDirectChannel
+	}
+	if name == "" {
+		return errors.New("channel name cannot be empty")
+	}
+	if err := c.Authorize(userID, PermissionRenameChannel); err != nil {
+		return err
+	}
+	oldName := c.Name
+	c.Name = name
+	c.Version++
+
+	c.addEvent(CreateChannelRenamedEvent(c, oldName, userID))
+	return nil
+}
+
 // canUserPostMessage checks if a user is allowed to post a message
 func (c *Channel) canUserPostMessage(userID uuid.UUID) bool {
 	for _, member := range c.Members {
@@ -157,10 +260,14 @@ func (c *Channel) canUserPostMessage(userID uuid.UUID) bool {
 	return false
 }
 
-// PostMessage posts a message to a channel
-func (c *Channel) PostMessage(senderUserID uuid.UUID, content MessageContent, parentMessageID *uuid.UUID) (*Message, error) {
+// PostMessage posts a message to a channel. The uploads are bound before the message is recorded,
+// so the MessageSent event carries them.
+func (c *Channel) PostMessage(senderUserID uuid.UUID, content MessageContent, parentMessageID *uuid.UUID, attachments []Attachment) (*Message, error) {
 	if !c.canUserPostMessage(senderUserID) {
-		return nil, errors.New("user is not allowed to post in this channel")
+		return nil, ErrNotChannelMember
+	}
+	if c.IsArchived {
+		return nil, ErrChannelArchived
 	}
 
 	if parentMessageID != nil {
@@ -182,6 +289,8 @@ func (c *Channel) PostMessage(senderUserID uuid.UUID, content MessageContent, pa
 		return nil, err
 	}
 
+	content.setMentions(c.filterMemberMentions(content.GetMentions()))
+
 	message := newMessage(
 		messageID,
 		c.ID,
@@ -193,10 +302,17 @@ func (c *Channel) PostMessage(senderUserID uuid.UUID, content MessageContent, pa
 		now,
 	)
 
+	if len(attachments) > 0 {
+		if err := message.AttachUploads(attachments); err != nil {
+			return nil, err
+		}
+	}
+
 	c.Messages = append(c.Messages, message)
 	c.LastMessageTime = now
 	c.Version++
 	c.addEvent(CreateMessageSentEvent(c, &message))
+	c.addMentionEvents(&message, senderUserID, content.GetMentions())
 	return &message, nil
 }
 
@@ -226,6 +342,132 @@ func (c *Channel) PostNotification(integrationID uuid.UUID, content MessageConte
 	return &message, nil
 }
 
+// filterMemberMentions drops mentioned users who are not members of the channel, and duplicates
+func (c *Channel) filterMemberMentions(mentions []uuid.UUID) []uuid.UUID {
+	filtered := []uuid.UUID{}
+	seen := make(map[uuid.UUID]bool)
+	for _, userID := range mentions {
+		if seen[userID] || !c.canUserPostMessage(userID) {
+			continue
+		}
+		seen[userID] = true
+		filtered = append(filtered, userID)
+	}
+	return filtered
+}
+
+// addMentionEvents records a UserMentioned event for every mentioned user except the author
+func (c *Channel) addMentionEvents(message *Message, mentionedBy uuid.UUID, mentions []uuid.UUID) {
+	for _, userID := range mentions {
+		if userID == mentionedBy {
+			continue
+		}
+		c.addEvent(CreateUserMentionedEvent(c, message, userID, mentionedBy))
+	}
+}
+
+// findMessage returns the loaded message with the given ID, or nil
+func (c *Channel) findMessage(messageID uuid.UUID) *Message {
+	for i := range c.Messages {
+		if c.Messages[i].GetId() == messageID {
+			return &c.Messages[i]
+		}
+	}
+	return nil
+}
+
+// EditMessage replaces the content of a message and records the previous revision
+func (c *Channel) EditMessage(messageID, userID uuid.UUID, content MessageContent) (*Message, *MessageRevision, error) {
+	targetMessage := c.findMessage(messageID)
+	if targetMessage == nil {
+		return nil, nil, errors.New("message not found")
+	}
+
+	if targetMessage.IsDeleted() {
+		return nil, nil, errors.New("message has been deleted")
+	}
+
+	// Removed, kicked and banned users keep their messages but can no longer change them
+	if !c.IsMember(userID) {
+		return nil, nil, ErrNotChannelMember
+	}
+
+	sender := targetMessage.GetSenderUserId()
+	if sender == nil || *sender != userID {
+		return nil, nil, errors.New("only the sender can edit this message")
+	}
+
+	if targetMessage.GetPoll() != nil {
+		return nil, nil, errors.New("polls cannot be edited")
+	}
+
+	if targetMessage.GetContent().GetText() == content.GetText() {
+		return nil, nil, errors.New("message content is unchanged")
+	}
+
+	now := time.Now().UTC()
+	revisionID, err := uuid.NewV7()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision := newMessageRevision(
+		revisionID,
+		messageID,
+		*targetMessage.GetContent(),
+		userID,
+		now,
+	)
+
+	content.setMentions(c.filterMemberMentions(content.GetMentions()))
+
+	// Only users who were not mentioned before are notified about the edit
+	previouslyMentioned := make(map[uuid.UUID]bool)
+	for _, mentioned := range targetMessage.GetContent().GetMentions() {
+		previouslyMentioned[mentioned] = true
+	}
+	newMentions := []uuid.UUID{}
+	for _, mentioned := range content.GetMentions() {
+		if !previouslyMentioned[mentioned] {
+			newMentions = append(newMentions, mentioned)
+		}
+	}
+
+	targetMessage.setContent(content)
+	targetMessage.setEditedAt(&now)
+	c.Version++
+
+	c.addEvent(CreateMessageEditedEvent(c, targetMessage, &revision))
+	c.addMentionEvents(targetMessage, userID, newMentions)
+	return targetMessage, &revision, nil
+}
+
+// DeleteMessage replaces a message with a tombstone
+// Senders can delete their own messages, members with the delete any message permission can delete any message
+func (c *Channel) DeleteMessage(messageID, userID uuid.UUID) (*Message, error) {
+	targetMessage := c.findMessage(messageID)
+	if targetMessage == nil {
+		return nil, errors.New("message not found")
+	}
+
+	if targetMessage.IsDeleted() {
+		return nil, errors.New("message has already been deleted")
+	}
+
+	sender := targetMessage.GetSenderUserId()
+	isSender := sender != nil && *sender == userID
+	if !isSender && c.Authorize(userID, PermissionDeleteAnyMessage) != nil {
+		return nil, errors.New("user does not have permission to delete this message")
+	}
+
+	now := time.Now().UTC()
+	targetMessage.markDeleted(userID, now)
+	c.Version++
+
+	c.addEvent(CreateMessageDeletedEvent(c, targetMessage))
+	return targetMessage, nil
+}
+
 // AddReaction adds a reaction to a message
 func (c *Channel) AddReaction(messageID, userID uuid.UUID, reactionType string) (*Reaction, error) {
 	if !c.canUserPostMessage(userID) {
@@ -243,6 +485,10 @@ func (c *Channel) AddReaction(messageID, userID uuid.UUID, reactionType string)
 		return nil, errors.New("message not found")
 	}
 
+	if targetMessage.IsDeleted() {
+		return nil, errors.New("cannot react to a deleted message")
+	}
+
 	for _, reaction := range targetMessage.GetReactions() {
 		if reaction.GetUserId() == userID && reaction.GetReactionType() == reactionType {
 			return nil, errors.New("user already added this reaction")
@@ -308,6 +554,9 @@ func (c *Channel) RemoveReaction(messageID, userID uuid.UUID, reactionType strin
 
 // AddBotMember adds a bot to a channel
 func (c *Channel) AddBotMember(integrationID uuid.UUID) error {
+	if c.IsDirect() {
+		return ErrDirectChannel
+	}
 	for _, member := range c.Members {
 		if member.GetId() == integrationID {
 			return errors.New("bot is already a member of the channel")
@@ -315,7 +564,7 @@ func (c *Channel) AddBotMember(integrationID uuid.UUID) error {
 	}
 
 	now := time.Now().UTC()
-	member := newMember(integrationID, "bot", now, now)
+	member := newMember(integrationID, RoleBot, now, now)
 	c.Members = append(c.Members, member)
 
 	c.addEvent(CreateBotJoinedChannelEvent(c, member))
@@ -325,16 +574,15 @@ func (c *Channel) AddBotMember(integrationID uuid.UUID) error {
 
 // CreateInvite creates a new invite for a channelj
 func (c *Channel) CreateInvite(createdByUserID uuid.UUID, expiresAt time.Time, maxUses *int) (*ChannelInvite, error) {
-	isMember := false
-	for _, member := range c.Members {
-		if member.GetId() == createdByUserID {
-			isMember = true
-			break
-		}
+	if c.IsDirect() {
+		return nil, ErrDirectChannel
 	}
-	if !isMember {
+	if c.findMember(createdByUserID) == nil {
 		return nil, errors.New("user is not a member of the channel")
 	}
+	if err := c.Authorize(createdByUserID, PermissionCreateInvites); err != nil {
+		return nil, err
+	}
 	inviteCode, err := c.generateInviteCode()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate invite code: %w", err)
@@ -358,6 +606,12 @@ func (c *Channel) CreateInvite(createdByUserID uuid.UUID, expiresAt time.Time, m
 
 // AcceptInvite accepts an invite to a channel and adds the user to the channel
 func (c *Channel) AcceptInvite(inviteCode string, userID uuid.UUID) error {
+	if c.IsDirect() {
+		return ErrDirectChannel
+	}
+	if c.IsBanned(userID) {
+		return ErrUserBanned
+	}
 	var targetInvite *ChannelInvite
 	for i := range c.Invites {
 		if c.Invites[i].GetInviteCode() == inviteCode {
@@ -396,7 +650,7 @@ func (c *Channel) AcceptInvite(inviteCode string, userID uuid.UUID) error {
 
 // DeactivateInvite deactivates an invite to a channel
 func (c *Channel) DeactivateInvite(inviteID uuid.UUID, userID uuid.UUID) error {
-	if c.CreatorUserID != userID {
+	if c.Authorize(userID, PermissionManageInvites) != nil {
 		var inviteCreatorID uuid.UUID
 		for _, invite := range c.Invites {
 			if invite.GetID() == inviteID {
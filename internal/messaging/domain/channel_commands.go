@@ -21,6 +21,7 @@ func (c GetUserChannelsCommand) CommandName() string {
 type CreateChannelCommand struct {
 	Name          string
 	Topic         string
+	Visibility    ChannelVisibility
 	CreatorUserID uuid.UUID
 }
 
@@ -28,8 +29,26 @@ func (c CreateChannelCommand) CommandName() string {
 	return "CreateChannel"
 }
 
+type OpenDirectChannelCommand struct {
+	UserID         uuid.UUID
+	ParticipantIDs []uuid.UUID
+}
+
+func (c OpenDirectChannelCommand) CommandName() string {
+	return "OpenDirectChannel"
+}
+
+type ListChannelDirectoryCommand struct {
+	Query ChannelDirectoryQuery
+}
+
+func (c ListChannelDirectoryCommand) CommandName() string {
+	return "ListChannelDirectory"
+}
+
 type GetChannelCommand struct {
 	ChannelID uuid.UUID
+	UserID    uuid.UUID
 }
 
 func (c GetChannelCommand) CommandName() string {
@@ -45,6 +64,77 @@ func (c JoinChannelCommand) CommandName() string {
 	return "JoinChannel"
 }
 
+type ChangeMemberRoleCommand struct {
+	ChannelID    uuid.UUID
+	RequestorID  uuid.UUID
+	TargetUserID uuid.UUID
+	Role         MemberRole
+	Promote      bool
+}
+
+func (c ChangeMemberRoleCommand) CommandName() string {
+	return "ChangeMemberRole"
+}
+
+type TransferOwnershipCommand struct {
+	ChannelID   uuid.UUID
+	RequestorID uuid.UUID
+	NewOwnerID  uuid.UUID
+}
+
+func (c TransferOwnershipCommand) CommandName() string {
+	return "TransferOwnership"
+}
+
+type HandleUserDeletedCommand struct {
+	UserID uuid.UUID
+}
+
+func (c HandleUserDeletedCommand) CommandName() string {
+	return "HandleUserDeleted"
+}
+
+type KickMemberCommand struct {
+	ChannelID    uuid.UUID
+	RequestorID  uuid.UUID
+	TargetUserID uuid.UUID
+}
+
+func (c KickMemberCommand) CommandName() string {
+	return "KickMember"
+}
+
+type BanMemberCommand struct {
+	ChannelID    uuid.UUID
+	RequestorID  uuid.UUID
+	TargetUserID uuid.UUID
+	Reason       string
+	ExpiresAt    *time.Time
+}
+
+func (c BanMemberCommand) CommandName() string {
+	return "BanMember"
+}
+
+type UnbanMemberCommand struct {
+	ChannelID    uuid.UUID
+	RequestorID  uuid.UUID
+	TargetUserID uuid.UUID
+}
+
+func (c UnbanMemberCommand) CommandName() string {
+	return "UnbanMember"
+}
+
+type ListBansCommand struct {
+	ChannelID   uuid.UUID
+	RequestorID uuid.UUID
+}
+
+func (c ListBansCommand) CommandName() string {
+	return "ListBans"
+}
+
 type LeaveChannelCommand struct {
 	ChannelID uuid.UUID
 	UserID    uuid.UUID
@@ -59,12 +149,111 @@ type SendMessageCommand struct {
 	SenderUserID    uuid.UUID
 	Content         MessageContent
 	ParentMessageID *uuid.UUID
+	AttachmentIDs   []uuid.UUID
 }
 
 func (c SendMessageCommand) CommandName() string {
 	return "SendMessage"
 }
 
+// ForwardMessageCommand shares a message of the source channel into ChannelID with an optional comment
+type ForwardMessageCommand struct {
+	ChannelID       uuid.UUID
+	SenderUserID    uuid.UUID
+	SourceChannelID uuid.UUID
+	SourceMessageID uuid.UUID
+	Comment         MessageContent
+}
+
+func (c ForwardMessageCommand) CommandName() string {
+	return "ForwardMessage"
+}
+
+// QuoteMessageCommand posts Content to ChannelID, quoting a message of the source channel
+type QuoteMessageCommand struct {
+	ChannelID       uuid.UUID
+	SenderUserID    uuid.UUID
+	SourceChannelID uuid.UUID
+	SourceMessageID uuid.UUID
+	Content         MessageContent
+	ParentMessageID *uuid.UUID
+}
+
+func (c QuoteMessageCommand) CommandName() string {
+	return "QuoteMessage"
+}
+
+// GetMessageSourceCommand returns the message forwarded or quoted by a message, if the user can read it
+type GetMessageSourceCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c GetMessageSourceCommand) CommandName() string {
+	return "GetMessageSource"
+}
+
+type EditMessageCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	Content   MessageContent
+}
+
+func (c EditMessageCommand) CommandName() string {
+	return "EditMessage"
+}
+
+type DeleteMessageCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c DeleteMessageCommand) CommandName() string {
+	return "DeleteMessage"
+}
+
+type PinMessageCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c PinMessageCommand) CommandName() string {
+	return "PinMessage"
+}
+
+type UnpinMessageCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c UnpinMessageCommand) CommandName() string {
+	return "UnpinMessage"
+}
+
+type ListPinnedMessagesCommand struct {
+	ChannelID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c ListPinnedMessagesCommand) CommandName() string {
+	return "ListPinnedMessages"
+}
+
+type GetMessageRevisionsCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c GetMessageRevisionsCommand) CommandName() string {
+	return "GetMessageRevisions"
+}
+
 type SendNotificationCommand struct {
 	ChannelID     uuid.UUID
 	IntegrationID uuid.UUID
@@ -107,6 +296,16 @@ func (c SetChannelTopicCommand) CommandName() string {
 	return "SetChannelTopic"
 }
 
+type RenameChannelCommand struct {
+	ChannelID uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+}
+
+func (c RenameChannelCommand) CommandName() string {
+	return "RenameChannel"
+}
+
 type ArchiveChannelCommand struct {
 	ChannelID uuid.UUID
 	UserID    uuid.UUID
@@ -127,14 +326,61 @@ func (c UnarchiveChannelCommand) CommandName() string {
 
 type ListMessagesForChannelCommand struct {
 	ChannelID uuid.UUID
-	Limit     int
-	Offset    int
+	UserID    uuid.UUID
+	Query     MessageQuery
 }
 
 func (c ListMessagesForChannelCommand) CommandName() string {
 	return "ListMessagesForChannel"
 }
 
+type GetThreadCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	Query     MessageQuery
+}
+
+func (c GetThreadCommand) CommandName() string {
+	return "GetThread"
+}
+
+type ListMentionsCommand struct {
+	UserID uuid.UUID
+	Query  MessageQuery
+}
+
+func (c ListMentionsCommand) CommandName() string {
+	return "ListMentions"
+}
+
+type SearchMessagesCommand struct {
+	UserID uuid.UUID
+	Query  MessageSearchQuery
+}
+
+func (c SearchMessagesCommand) CommandName() string {
+	return "SearchMessages"
+}
+
+type MarkReadCommand struct {
+	ChannelID uuid.UUID
+	UserID    uuid.UUID
+	MessageID uuid.UUID
+}
+
+func (c MarkReadCommand) CommandName() string {
+	return "MarkRead"
+}
+
+type GetUnreadCountsCommand struct {
+	UserID uuid.UUID
+}
+
+func (c GetUnreadCountsCommand) CommandName() string {
+	return "GetUnreadCounts"
+}
+
 type CommandResult interface {
 	IsSuccess() bool
 	GetError() error
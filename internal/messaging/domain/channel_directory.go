@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultDirectoryPageSize = 25
+	MaxDirectoryPageSize     = 100
+)
+
+// ChannelDirectoryQuery describes a page of the public channel directory.
+// Search matches the channel name or topic; Before is the ID of the last channel of the previous page.
+type ChannelDirectoryQuery struct {
+	Search string
+	Limit  int
+	Before *uuid.UUID
+}
+
+// ChannelDirectoryEntry is a read model of a public channel as listed in the directory
+type ChannelDirectoryEntry struct {
+	ID              uuid.UUID
+	Name            string
+	Topic           string
+	MembersCount    int
+	CreationTime    time.Time
+	LastMessageTime time.Time
+}
+
+// ChannelDirectoryPage is a page of directory entries ordered by most recent activity.
+// NextCursor is set when more entries follow and is passed back as Before.
+type ChannelDirectoryPage struct {
+	Channels   []ChannelDirectoryEntry
+	NextCursor *uuid.UUID
+}
@@ -12,6 +12,8 @@ type ChannelCreatedEvent struct {
 	ChannelName   string
 	CreatorUserID string
 	Topic         string
+	Kind          string
+	Visibility    string
 }
 
 type UserJoinedChannelEvent struct {
@@ -21,6 +23,41 @@ type UserJoinedChannelEvent struct {
 	JoinedAt time.Time
 }
 
+type MemberRoleChangedEvent struct {
+	common.BaseDomainEvent
+	UserID    string
+	OldRole   string
+	NewRole   string
+	ChangedBy string
+}
+
+type ChannelOwnershipTransferredEvent struct {
+	common.BaseDomainEvent
+	PreviousOwnerID string
+	NewOwnerID      string
+	TransferredBy   string
+}
+
+type MemberKickedEvent struct {
+	common.BaseDomainEvent
+	UserID   string
+	KickedBy string
+}
+
+type MemberBannedEvent struct {
+	common.BaseDomainEvent
+	UserID    string
+	BannedBy  string
+	Reason    string
+	ExpiresAt *time.Time
+}
+
+type MemberUnbannedEvent struct {
+	common.BaseDomainEvent
+	UserID     string
+	UnbannedBy string
+}
+
 type UserLeftChannelEvent struct {
 	common.BaseDomainEvent
 	UserID string
@@ -34,6 +71,38 @@ type MessageSentEvent struct {
 	Content         MessageContent
 	Timestamp       time.Time
 	ParentMessageID *string
+	Attachments     []MessageSentAttachment
+}
+
+type MessageSentAttachment struct {
+	AttachmentID string
+	FileName     string
+	ContentType  string
+	Size         int64
+}
+
+type MessageEditedEvent struct {
+	common.BaseDomainEvent
+	MessageID       string
+	EditedBy        string
+	Content         MessageContent
+	PreviousContent MessageContent
+	EditedAt        time.Time
+}
+
+type MessageDeletedEvent struct {
+	common.BaseDomainEvent
+	MessageID string
+	DeletedBy string
+	DeletedAt time.Time
+}
+
+type UserMentionedEvent struct {
+	common.BaseDomainEvent
+	MessageID       string
+	MentionedUserID string
+	MentionedBy     string
+	Timestamp       time.Time
 }
 
 type NotificationSentEvent struct {
@@ -76,6 +145,79 @@ type ChannelTopicChangedEvent struct {
 	ChangedBy string
 }
 
+type ChannelRenamedEvent struct {
+	common.BaseDomainEvent
+	OldName   string
+	Name      string
+	RenamedBy string
+}
+
+type PinAddedEvent struct {
+	common.BaseDomainEvent
+	MessageID string
+	PinnedBy  string
+	PinnedAt  time.Time
+}
+
+type PinRemovedEvent struct {
+	common.BaseDomainEvent
+	MessageID  string
+	UnpinnedBy string
+}
+
+type MessageForwardedEvent struct {
+	common.BaseDomainEvent
+	MessageID       string
+	ForwardedBy     string
+	SourceMessageID string
+	SourceChannelID string
+	Timestamp       time.Time
+}
+
+type PollCreatedEvent struct {
+	common.BaseDomainEvent
+	MessageID      string
+	CreatedBy      string
+	Question       string
+	Options        []string
+	MultipleChoice bool
+	Anonymous      bool
+	ClosesAt       *time.Time
+	CreatedAt      time.Time
+}
+
+// PollVotedEvent is published when a user votes or changes their vote. UserID is empty for anonymous polls.
+type PollVotedEvent struct {
+	common.BaseDomainEvent
+	MessageID string
+	UserID    string
+	OptionIDs []string
+	VotedAt   time.Time
+}
+
+// PollVoteRetractedEvent is published when a user takes back their vote. UserID is empty for anonymous polls.
+type PollVoteRetractedEvent struct {
+	common.BaseDomainEvent
+	MessageID string
+	UserID    string
+}
+
+type PollOptionResult struct {
+	OptionID string
+	Text     string
+	Votes    int
+}
+
+// PollClosedEvent carries the final results of a poll. ClosedBy is nil when the poll closed at its closing time.
+type PollClosedEvent struct {
+	common.BaseDomainEvent
+	MessageID  string
+	ClosedBy   *string
+	ClosedAt   time.Time
+	VoterCount int
+	Results    []PollOptionResult
+}
+
 type BotJoinedChannelEvent struct {
 	common.BaseDomainEvent
 	ChannelID uuid.UUID
@@ -116,6 +258,58 @@ func CreateChannelCreatedEvent(channel *Channel) ChannelCreatedEvent {
 		ChannelName:     channel.Name,
 		CreatorUserID:   channel.CreatorUserID.String(),
 		Topic:           channel.Topic,
+		Kind:            string(channel.Kind),
+		Visibility:      string(channel.Visibility),
+	}
+}
+
+func CreateMemberRoleChangedEvent(channel *Channel, userID uuid.UUID, oldRole, newRole MemberRole, changedBy uuid.UUID) MemberRoleChangedEvent {
+	base := common.NewBaseDomainEvent("MemberRoleChanged", channel.ID, channel.Version, "Channel")
+	return MemberRoleChangedEvent{
+		BaseDomainEvent: base,
+		UserID:          userID.String(),
+		OldRole:         string(oldRole),
+		NewRole:         string(newRole),
+		ChangedBy:       changedBy.String(),
+	}
+}
+
+func CreateChannelOwnershipTransferredEvent(channel *Channel, previousOwnerID, newOwnerID, transferredBy uuid.UUID) ChannelOwnershipTransferredEvent {
+	base := common.NewBaseDomainEvent("ChannelOwnershipTransferred", channel.ID, channel.Version, "Channel")
+	return ChannelOwnershipTransferredEvent{
+		BaseDomainEvent: base,
+		PreviousOwnerID: previousOwnerID.String(),
+		NewOwnerID:      newOwnerID.String(),
+		TransferredBy:   transferredBy.String(),
+	}
+}
+
+func CreateMemberKickedEvent(channel *Channel, userID, kickedBy uuid.UUID) MemberKickedEvent {
+	base := common.NewBaseDomainEvent("MemberKicked", channel.ID, channel.Version, "Channel")
+	return MemberKickedEvent{
+		BaseDomainEvent: base,
+		UserID:          userID.String(),
+		KickedBy:        kickedBy.String(),
+	}
+}
+
+func CreateMemberBannedEvent(channel *Channel, ban *ChannelBan) MemberBannedEvent {
+	base := common.NewBaseDomainEvent("MemberBanned", channel.ID, channel.Version, "Channel")
+	return MemberBannedEvent{
+		BaseDomainEvent: base,
+		UserID:          ban.GetUserId().String(),
+		BannedBy:        ban.GetBannedBy().String(),
+		Reason:          ban.GetReason(),
+		ExpiresAt:       ban.GetExpiresAt(),
+	}
+}
+
+func CreateMemberUnbannedEvent(channel *Channel, userID, unbannedBy uuid.UUID) MemberUnbannedEvent {
+	base := common.NewBaseDomainEvent("MemberUnbanned", channel.ID, channel.Version, "Channel")
+	return MemberUnbannedEvent{
+		BaseDomainEvent: base,
+		UserID:          userID.String(),
+		UnbannedBy:      unbannedBy.String(),
 	}
 }
 
@@ -124,7 +318,7 @@ func CreateUserJoinedChannelEvent(channel *Channel, member Member) UserJoinedCha
 	return UserJoinedChannelEvent{
 		BaseDomainEvent: base,
 		UserID:          member.GetId().String(),
-		Role:            member.GetRole(),
+		Role:            string(member.GetRole()),
 		JoinedAt:        member.GetJoinedAt(),
 	}
 }
@@ -158,6 +352,16 @@ func CreateMessageSentEvent(channel *Channel, message *Message) MessageSentEvent
 		parentMessageIDStr = &id
 	}
 
+	var attachments []MessageSentAttachment
+	for _, attachment := range message.GetAttachments() {
+		attachments = append(attachments, MessageSentAttachment{
+			AttachmentID: attachment.GetId().String(),
+			FileName:     attachment.GetFileName(),
+			ContentType:  attachment.GetContentType(),
+			Size:         attachment.GetSize(),
+		})
+	}
+
 	return MessageSentEvent{
 		BaseDomainEvent: base,
 		MessageID:       message.GetId().String(),
@@ -166,6 +370,43 @@ func CreateMessageSentEvent(channel *Channel, message *Message) MessageSentEvent
 		Content:         *message.GetContent(),
 		Timestamp:       message.GetCreatedAt(),
 		ParentMessageID: parentMessageIDStr,
+		Attachments:     attachments,
+	}
+}
+
+func CreateMessageEditedEvent(channel *Channel, message *Message, revision *MessageRevision) MessageEditedEvent {
+	base := common.NewBaseDomainEvent("MessageEdited", channel.ID, channel.Version, "Channel")
+
+	return MessageEditedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		EditedBy:        revision.GetEditedBy().String(),
+		Content:         *message.GetContent(),
+		PreviousContent: *revision.GetPreviousContent(),
+		EditedAt:        revision.GetEditedAt(),
+	}
+}
+
+func CreateMessageDeletedEvent(channel *Channel, message *Message) MessageDeletedEvent {
+	base := common.NewBaseDomainEvent("MessageDeleted", channel.ID, channel.Version, "Channel")
+
+	return MessageDeletedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		DeletedBy:       message.GetDeletedBy().String(),
+		DeletedAt:       *message.GetDeletedAt(),
+	}
+}
+
+func CreateUserMentionedEvent(channel *Channel, message *Message, mentionedUserID, mentionedBy uuid.UUID) UserMentionedEvent {
+	base := common.NewBaseDomainEvent("UserMentioned", channel.ID, channel.Version, "Channel")
+
+	return UserMentionedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		MentionedUserID: mentionedUserID.String(),
+		MentionedBy:     mentionedBy.String(),
+		Timestamp:       message.GetCreatedAt(),
 	}
 }
 
@@ -215,6 +456,137 @@ func CreateChannelTopicChangedEvent(channel *Channel, changedBy uuid.UUID) Chann
 	}
 }
 
+func CreateChannelRenamedEvent(channel *Channel, oldName string, renamedBy uuid.UUID) ChannelRenamedEvent {
+	base := common.NewBaseDomainEvent("ChannelRenamed", channel.ID, channel.Version, "Channel")
+
+	return ChannelRenamedEvent{
+		BaseDomainEvent: base,
+		OldName:         oldName,
+		Name:            channel.Name,
+		RenamedBy:       renamedBy.String(),
+	}
+}
+
+func CreatePinAddedEvent(channel *Channel, message *Message) PinAddedEvent {
+	base := common.NewBaseDomainEvent("PinAdded", channel.ID, channel.Version, "Channel")
+
+	return PinAddedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		PinnedBy:        message.GetPinnedBy().String(),
+		PinnedAt:        *message.GetPinnedAt(),
+	}
+}
+
+func CreatePinRemovedEvent(channel *Channel, messageID, unpinnedBy uuid.UUID) PinRemovedEvent {
+	base := common.NewBaseDomainEvent("PinRemoved", channel.ID, channel.Version, "Channel")
+
+	return PinRemovedEvent{
+		BaseDomainEvent: base,
+		MessageID:       messageID.String(),
+		UnpinnedBy:      unpinnedBy.String(),
+	}
+}
+
+func CreateMessageForwardedEvent(channel *Channel, message *Message) MessageForwardedEvent {
+	base := common.NewBaseDomainEvent("MessageForwarded", channel.ID, channel.Version, "Channel")
+
+	reference := message.GetReference()
+	return MessageForwardedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		ForwardedBy:     message.GetSenderUserId().String(),
+		SourceMessageID: reference.GetSourceMessageId().String(),
+		SourceChannelID: reference.GetSourceChannelId().String(),
+		Timestamp:       message.GetCreatedAt(),
+	}
+}
+
+func CreatePollCreatedEvent(channel *Channel, message *Message) PollCreatedEvent {
+	base := common.NewBaseDomainEvent("PollCreated", channel.ID, channel.Version, "Channel")
+
+	poll := message.GetPoll()
+	options := make([]string, len(poll.GetOptions()))
+	for i, option := range poll.GetOptions() {
+		options[i] = option.GetText()
+	}
+
+	return PollCreatedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		CreatedBy:       message.GetSenderUserId().String(),
+		Question:        poll.GetQuestion(),
+		Options:         options,
+		MultipleChoice:  poll.IsMultipleChoice(),
+		Anonymous:       poll.IsAnonymous(),
+		ClosesAt:        poll.GetClosesAt(),
+		CreatedAt:       message.GetCreatedAt(),
+	}
+}
+
+func CreatePollVotedEvent(channel *Channel, message *Message, userID uuid.UUID) PollVotedEvent {
+	base := common.NewBaseDomainEvent("PollVoted", channel.ID, channel.Version, "Channel")
+
+	poll := message.GetPoll()
+	optionIDs := []string{}
+	for _, optionID := range poll.GetUserVotes(userID) {
+		optionIDs = append(optionIDs, optionID.String())
+	}
+
+	event := PollVotedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		OptionIDs:       optionIDs,
+		VotedAt:         time.Now().UTC(),
+	}
+	if !poll.IsAnonymous() {
+		event.UserID = userID.String()
+	}
+	return event
+}
+
+func CreatePollVoteRetractedEvent(channel *Channel, message *Message, userID uuid.UUID) PollVoteRetractedEvent {
+	base := common.NewBaseDomainEvent("PollVoteRetracted", channel.ID, channel.Version, "Channel")
+
+	event := PollVoteRetractedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+	}
+	if !message.GetPoll().IsAnonymous() {
+		event.UserID = userID.String()
+	}
+	return event
+}
+
+func CreatePollClosedEvent(channel *Channel, message *Message) PollClosedEvent {
+	base := common.NewBaseDomainEvent("PollClosed", channel.ID, channel.Version, "Channel")
+
+	poll := message.GetPoll()
+	var closedBy *string
+	if poll.GetClosedBy() != nil {
+		id := poll.GetClosedBy().String()
+		closedBy = &id
+	}
+
+	results := []PollOptionResult{}
+	for _, tally := range poll.GetTallies() {
+		results = append(results, PollOptionResult{
+			OptionID: tally.Option.GetId().String(),
+			Text:     tally.Option.GetText(),
+			Votes:    tally.Count,
+		})
+	}
+
+	return PollClosedEvent{
+		BaseDomainEvent: base,
+		MessageID:       message.GetId().String(),
+		ClosedBy:        closedBy,
+		ClosedAt:        *poll.GetClosedAt(),
+		VoterCount:      poll.GetVoterCount(),
+		Results:         results,
+	}
+}
+
 func CreateChannelArchivedEvent(channel *Channel, archivedBy uuid.UUID) ChannelArchivedEvent {
 	base := common.NewBaseDomainEvent("ChannelArchived", channel.ID, channel.Version, "Channel")
 
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// successorRoles lists, in order of preference, the roles that can inherit ownership automatically
+var successorRoles = []MemberRole{RoleAdmin, RoleModerator, RoleMember}
+
+func (c *Channel) findOwner() *Member {
+	for i := range c.Members {
+		if c.Members[i].GetRole() == RoleOwner {
+			return &c.Members[i]
+		}
+	}
+	return nil
+}
+
+// TransferOwnership hands the channel over to another member.
+// Only the current owner can transfer ownership; they stay on as an admin.
+func (c *Channel) TransferOwnership(actorID, newOwnerID uuid.UUID) error {
+	owner := c.findOwner()
+	if owner == nil || owner.GetId() != actorID {
+		return ErrPermissionDenied
+	}
+
+	if actorID == newOwnerID {
+		return errors.New("user already owns the channel")
+	}
+
+	newOwner := c.findMember(newOwnerID)
+	if newOwner == nil {
+		return errors.New("user is not a member of the channel")
+	}
+	if newOwner.GetRole() == RoleBot {
+		return errors.New("a bot cannot own a channel")
+	}
+
+	c.Version++
+	c.transferOwnership(owner, newOwner, RoleAdmin, actorID)
+	return nil
+}
+
+// RemoveDeletedUser drops a deleted account from the channel, direct conversations included.
+// An owner hands over to the longest-standing admin, falling back to moderators and then members.
+// When nobody can take over, the channel is archived rather than left without an owner.
+// It returns the new owner, if ownership moved, and whether the channel was archived.
+func (c *Channel) RemoveDeletedUser(userID uuid.UUID) (*Member, bool, error) {
+	removed, found := c.removeMember(userID)
+	if !found {
+		return nil, false, ErrNotChannelMember
+	}
+	c.Version++
+	c.addEvent(CreateUserLeftChannelEvent(c, userID))
+
+	if removed.GetRole() != RoleOwner {
+		return nil, false, nil
+	}
+
+	if successor := c.longestStandingMember(userID); successor != nil {
+		successor.setRole(RoleOwner)
+		c.addEvent(CreateChannelOwnershipTransferredEvent(c, userID, successor.GetId(), userID))
+		return successor, false, nil
+	}
+
+	if c.IsArchived {
+		return nil, false, nil
+	}
+	c.IsArchived = true
+	c.addEvent(CreateChannelArchivedEvent(c, userID))
+	return nil, true, nil
+}
+
+// longestStandingMember returns the earliest joined member holding the most senior successor role
+func (c *Channel) longestStandingMember(excludedID uuid.UUID) *Member {
+	for _, role := range successorRoles {
+		var candidate *Member
+		for i := range c.Members {
+			member := &c.Members[i]
+			if member.GetId() == excludedID || member.GetRole() != role {
+				continue
+			}
+			if candidate == nil || member.GetJoinedAt().Before(candidate.GetJoinedAt()) {
+				candidate = member
+			}
+		}
+		if candidate != nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// transferOwnership swaps the roles and records the event; callers bump the version
+func (c *Channel) transferOwnership(owner, newOwner *Member, previousOwnerRole MemberRole, transferredBy uuid.UUID) {
+	previousOwnerID := owner.GetId()
+	owner.setRole(previousOwnerRole)
+	newOwner.setRole(RoleOwner)
+
+	c.addEvent(CreateChannelOwnershipTransferredEvent(c, previousOwnerID, newOwner.GetId(), transferredBy))
+}
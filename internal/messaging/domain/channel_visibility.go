@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ChannelVisibility controls whether a channel can be discovered and joined freely
+type ChannelVisibility string
+
+const (
+	ChannelVisibilityPublic  ChannelVisibility = "public"
+	ChannelVisibilityPrivate ChannelVisibility = "private"
+)
+
+var ErrPrivateChannel = errors.New("private channels can only be joined with an invite")
+
+// ParseChannelVisibility validates a visibility value, defaulting to public when empty
+func ParseChannelVisibility(value string) (ChannelVisibility, error) {
+	switch ChannelVisibility(value) {
+	case "":
+		return ChannelVisibilityPublic, nil
+	case ChannelVisibilityPublic, ChannelVisibilityPrivate:
+		return ChannelVisibility(value), nil
+	default:
+		return "", fmt.Errorf("invalid channel visibility %q", value)
+	}
+}
+
+// IsPublic reports whether the channel is listed in the directory and open to join
+func (c *Channel) IsPublic() bool {
+	return c.Visibility == ChannelVisibilityPublic
+}
+
+// CanUserRead reports whether the user may see the channel and its messages.
+// Public channels are readable by anyone; private channels and direct conversations only by their members.
+func (c *Channel) CanUserRead(userID uuid.UUID) bool {
+	return (c.IsPublic() && !c.IsDirect()) || c.IsMember(userID)
+}
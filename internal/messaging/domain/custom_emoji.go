@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxCustomEmojiSize is the largest custom emoji image that can be uploaded, in bytes
+	MaxCustomEmojiSize = 256 << 10
+	// MaxCustomEmojiDimension is the largest width or height of a custom emoji image, in pixels
+	MaxCustomEmojiDimension = 512
+
+	minCustomEmojiNameLength = 2
+	maxCustomEmojiNameLength = 32
+)
+
+var (
+	ErrInvalidCustomEmojiName     = fmt.Errorf("emoji names must be %d to %d lowercase letters, digits, '_', '-' or '+'", minCustomEmojiNameLength, maxCustomEmojiNameLength)
+	ErrCustomEmojiTooLarge        = fmt.Errorf("emoji images cannot be larger than %d KB or %dx%d pixels", MaxCustomEmojiSize>>10, MaxCustomEmojiDimension, MaxCustomEmojiDimension)
+	ErrCustomEmojiTypeNotAllowed  = errors.New("emoji images must be PNG, GIF, JPEG or WebP")
+	ErrCustomEmojiDeleteForbidden = errors.New("only the creator of an emoji or an admin can delete it")
+)
+
+var customEmojiNamePattern = regexp.MustCompile(`^[a-z0-9_+-]+$`)
+
+// customEmojiTypes are the image types accepted for custom emoji, as detected from the file contents
+var customEmojiTypes = map[string]bool{
+	"image/png":  true,
+	"image/gif":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+}
+
+// ValidateCustomEmojiName checks that a name can be used for a custom emoji, written :name: in reactions
+func ValidateCustomEmojiName(name string) error {
+	if len(name) < minCustomEmojiNameLength || len(name) > maxCustomEmojiNameLength || !customEmojiNamePattern.MatchString(name) {
+		return ErrInvalidCustomEmojiName
+	}
+	return nil
+}
+
+// CustomEmoji is an image registered under a name for the whole workspace, usable as a reaction.
+// An alias is another name for an existing emoji and has no image of its own.
+type CustomEmoji struct {
+	id           uuid.UUID
+	name         string
+	aliasForId   *uuid.UUID // nil for emoji with their own image
+	aliasForName string
+	creatorId    uuid.UUID
+	contentType  string
+	size         int64
+	storageKey   string
+	createdAt    time.Time
+}
+
+// NewCustomEmoji registers an uploaded image under a name
+func NewCustomEmoji(name string, creatorID uuid.UUID, contentType string, size int64, width, height int) (*CustomEmoji, error) {
+	if err := ValidateCustomEmojiName(name); err != nil {
+		return nil, err
+	}
+	if !customEmojiTypes[contentType] {
+		return nil, fmt.Errorf("%w: %s", ErrCustomEmojiTypeNotAllowed, contentType)
+	}
+	if size <= 0 {
+		return nil, errors.New("emoji image is empty")
+	}
+	if size > MaxCustomEmojiSize || width > MaxCustomEmojiDimension || height > MaxCustomEmojiDimension {
+		return nil, ErrCustomEmojiTooLarge
+	}
+
+	id := uuid.New()
+	return &CustomEmoji{
+		id:          id,
+		name:        name,
+		creatorId:   creatorID,
+		contentType: contentType,
+		size:        size,
+		storageKey:  "emoji/" + id.String(),
+		createdAt:   time.Now().UTC(),
+	}, nil
+}
+
+// NewAlias adds another name for the emoji. Aliases of aliases point to the original emoji.
+func (e *CustomEmoji) NewAlias(name string, creatorID uuid.UUID) (*CustomEmoji, error) {
+	if err := ValidateCustomEmojiName(name); err != nil {
+		return nil, err
+	}
+	if name == e.name {
+		return nil, errors.New("an alias needs a different name than the emoji")
+	}
+
+	return &CustomEmoji{
+		id:           uuid.New(),
+		name:         name,
+		aliasForId:   e.GetOriginalId(),
+		aliasForName: e.GetOriginalName(),
+		creatorId:    creatorID,
+		createdAt:    time.Now().UTC(),
+	}, nil
+}
+
+// For external usage
+func RehydrateCustomEmoji(id uuid.UUID, name string, aliasForId *uuid.UUID, aliasForName string, creatorId uuid.UUID, contentType string, size int64, storageKey string, createdAt time.Time) CustomEmoji {
+	return CustomEmoji{
+		id:           id,
+		name:         name,
+		aliasForId:   aliasForId,
+		aliasForName: aliasForName,
+		creatorId:    creatorId,
+		contentType:  contentType,
+		size:         size,
+		storageKey:   storageKey,
+		createdAt:    createdAt,
+	}
+}
+
+func (e *CustomEmoji) GetId() uuid.UUID {
+	return e.id
+}
+
+func (e *CustomEmoji) GetName() string {
+	return e.name
+}
+
+// GetAliasForId returns the ID of the emoji this is an alias of, or nil
+func (e *CustomEmoji) GetAliasForId() *uuid.UUID {
+	return e.aliasForId
+}
+
+// GetAliasForName returns the name of the emoji this is an alias of, or an empty string
+func (e *CustomEmoji) GetAliasForName() string {
+	return e.aliasForName
+}
+
+func (e *CustomEmoji) IsAlias() bool {
+	return e.aliasForId != nil
+}
+
+// GetOriginalId returns the ID of the emoji that has the image, which is this emoji unless it is an alias
+func (e *CustomEmoji) GetOriginalId() *uuid.UUID {
+	if e.aliasForId != nil {
+		return e.aliasForId
+	}
+	return &e.id
+}
+
+// GetOriginalName returns the name of the emoji that has the image, which is this emoji unless it is an alias
+func (e *CustomEmoji) GetOriginalName() string {
+	if e.aliasForId != nil {
+		return e.aliasForName
+	}
+	return e.name
+}
+
+func (e *CustomEmoji) GetCreatorId() uuid.UUID {
+	return e.creatorId
+}
+
+func (e *CustomEmoji) GetContentType() string {
+	return e.contentType
+}
+
+func (e *CustomEmoji) GetSize() int64 {
+	return e.size
+}
+
+// GetStorageKey returns the key of the image in the blob store, empty for aliases
+func (e *CustomEmoji) GetStorageKey() string {
+	return e.storageKey
+}
+
+func (e *CustomEmoji) GetCreatedAt() time.Time {
+	return e.createdAt
+}
+
+// GetReactionType returns the reaction stored when someone reacts with this emoji. Reactions with an alias
+// are stored as the original emoji, so they are counted together.
+func (e *CustomEmoji) GetReactionType() string {
+	return CustomEmojiReaction(e.GetOriginalName())
+}
+
+// CanBeDeletedBy reports whether a user may delete the emoji: its creator or a workspace admin
+func (e *CustomEmoji) CanBeDeletedBy(userID uuid.UUID, isAdmin bool) bool {
+	return isAdmin || e.creatorId == userID
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type UploadCustomEmojiCommand struct {
+	Name      string
+	CreatorID uuid.UUID
+	Size      int64
+	Content   io.Reader
+}
+
+func (c UploadCustomEmojiCommand) CommandName() string {
+	return "UploadCustomEmoji"
+}
+
+type AddCustomEmojiAliasCommand struct {
+	Name       string
+	TargetName string
+	CreatorID  uuid.UUID
+}
+
+func (c AddCustomEmojiAliasCommand) CommandName() string {
+	return "AddCustomEmojiAlias"
+}
+
+type DeleteCustomEmojiCommand struct {
+	Name    string
+	UserID  uuid.UUID
+	IsAdmin bool
+}
+
+func (c DeleteCustomEmojiCommand) CommandName() string {
+	return "DeleteCustomEmoji"
+}
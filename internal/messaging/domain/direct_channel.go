@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChannelKind distinguishes regular channels from direct conversations
+type ChannelKind string
+
+const (
+	ChannelKindChannel     ChannelKind = "channel"
+	ChannelKindDirect      ChannelKind = "dm"
+	ChannelKindGroupDirect ChannelKind = "group_dm"
+)
+
+// MaxGroupDirectMembers is the largest member set a group direct conversation may have
+const MaxGroupDirectMembers = 9
+
+var ErrDirectChannel = errors.New("operation not allowed on a direct conversation")
+
+// NewDirectChannel creates a 1:1 or group direct conversation between the creator and the participants.
+// Direct conversations have no name or topic and are identified by their member set.
+func NewDirectChannel(creatorUserID uuid.UUID, participantIDs []uuid.UUID) (*Channel, error) {
+	memberIDs := directMemberSet(creatorUserID, participantIDs)
+	if len(memberIDs) < 2 {
+		return nil, errors.New("a direct conversation needs at least one other participant")
+	}
+	if len(memberIDs) > MaxGroupDirectMembers {
+		return nil, errors.New("too many participants for a direct conversation")
+	}
+
+	kind := ChannelKindDirect
+	if len(memberIDs) > 2 {
+		kind = ChannelKindGroupDirect
+	}
+
+	now := time.Now().UTC()
+	members := make([]Member, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		members = append(members, newMember(id, RoleMember, now, now))
+	}
+
+	channel := &Channel{
+		ID:              uuid.New(),
+		Kind:            kind,
+		DirectKey:       DirectChannelKey(memberIDs),
+		Visibility:      ChannelVisibilityPrivate,
+		CreatorUserID:   creatorUserID,
+		CreationTime:    now,
+		Members:         members,
+		Messages:        []Message{},
+		Invites:         []ChannelInvite{},
+		Bans:            []ChannelBan{},
+		LastMessageTime: now,
+		IsArchived:      false,
+		Version:         1,
+	}
+
+	channel.addEvent(CreateChannelCreatedEvent(channel))
+	return channel, nil
+}
+
+// DirectChannelKey returns the deterministic key identifying a direct conversation by its member set
+func DirectChannelKey(memberIDs []uuid.UUID) string {
+	ids := make([]string, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		ids = append(ids, id.String())
+	}
+	sort.Strings(ids)
+
+	sum := sha256.Sum256([]byte(strings.Join(ids, ",")))
+	return hex.EncodeToString(sum[:])
+}
+
+// directMemberSet returns the distinct members of a direct conversation, including the creator
+func directMemberSet(creatorUserID uuid.UUID, participantIDs []uuid.UUID) []uuid.UUID {
+	seen := map[uuid.UUID]bool{creatorUserID: true}
+	memberIDs := []uuid.UUID{creatorUserID}
+	for _, id := range participantIDs {
+		if id == uuid.Nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		memberIDs = append(memberIDs, id)
+	}
+	return memberIDs
+}
+
+// IsDirect reports whether the channel is a direct conversation
+func (c *Channel) IsDirect() bool {
+	return c.Kind == ChannelKindDirect || c.Kind == ChannelKindGroupDirect
+}
@@ -2,10 +2,14 @@ package domain
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type ChannelDTO struct {
 	ID              string              `json:"id"`
+	Kind            string              `json:"kind"`
+	Visibility      string              `json:"visibility"`
 	Name            string              `json:"name"`
 	Topic           string              `json:"topic"`
 	CreatorUserID   string              `json:"creator_user_id"`
@@ -13,6 +17,8 @@ type ChannelDTO struct {
 	LastMessageTime time.Time           `json:"last_message_time"`
 	IsArchived      bool                `json:"is_archived"`
 	MembersCount    int                 `json:"members_count"`
+	UnreadCount     int                 `json:"unread_count"`
+	MentionCount    int                 `json:"mention_count"`
 	Members         []UserDTO           `json:"members"`
 	IntegrationBOts []IntegrationBotDTO `json:"bots"`
 }
@@ -31,6 +37,8 @@ func ToChannelDTO(channel *Channel, members []*User, integrationBots []*Integrat
 
 	return ChannelDTO{
 		ID:              channel.ID.String(),
+		Kind:            string(channel.Kind),
+		Visibility:      string(channel.Visibility),
 		Name:            channel.Name,
 		Topic:           channel.Topic,
 		CreatorUserID:   channel.CreatorUserID.String(),
@@ -43,17 +51,308 @@ func ToChannelDTO(channel *Channel, members []*User, integrationBots []*Integrat
 	}
 }
 
+type ChannelDirectoryEntryDTO struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Topic           string    `json:"topic"`
+	MembersCount    int       `json:"members_count"`
+	CreationTime    time.Time `json:"creation_time"`
+	LastMessageTime time.Time `json:"last_message_time"`
+}
+
+type ChannelDirectoryDTO struct {
+	Channels   []ChannelDirectoryEntryDTO `json:"channels"`
+	NextCursor *string                    `json:"next_cursor"`
+}
+
+func ToChannelDirectoryDTO(page *ChannelDirectoryPage) ChannelDirectoryDTO {
+	channels := make([]ChannelDirectoryEntryDTO, len(page.Channels))
+	for i, entry := range page.Channels {
+		channels[i] = ChannelDirectoryEntryDTO{
+			ID:              entry.ID.String(),
+			Name:            entry.Name,
+			Topic:           entry.Topic,
+			MembersCount:    entry.MembersCount,
+			CreationTime:    entry.CreationTime,
+			LastMessageTime: entry.LastMessageTime,
+		}
+	}
+
+	dto := ChannelDirectoryDTO{Channels: channels}
+	if page.NextCursor != nil {
+		next := page.NextCursor.String()
+		dto.NextCursor = &next
+	}
+	return dto
+}
+
+type MemberDTO struct {
+	ChannelID string    `json:"channel_id"`
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+func ToMemberDTO(channelID string, member *Member) MemberDTO {
+	return MemberDTO{
+		ChannelID: channelID,
+		UserID:    member.GetId().String(),
+		Role:      string(member.GetRole()),
+		JoinedAt:  member.GetJoinedAt(),
+	}
+}
+
+type ChannelBanDTO struct {
+	ChannelID string     `json:"channel_id"`
+	UserID    string     `json:"user_id"`
+	BannedBy  string     `json:"banned_by"`
+	Reason    string     `json:"reason"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func ToChannelBanDTO(channelID string, ban *ChannelBan) ChannelBanDTO {
+	return ChannelBanDTO{
+		ChannelID: channelID,
+		UserID:    ban.GetUserId().String(),
+		BannedBy:  ban.GetBannedBy().String(),
+		Reason:    ban.GetReason(),
+		ExpiresAt: ban.GetExpiresAt(),
+		CreatedAt: ban.GetCreatedAt(),
+	}
+}
+
+type ReadMarkerDTO struct {
+	ChannelID         string    `json:"channel_id"`
+	UserID            string    `json:"user_id"`
+	LastReadMessageID *string   `json:"last_read_message_id"`
+	LastReadAt        time.Time `json:"last_read_at"`
+}
+
+func ToReadMarkerDTO(channelID string, member *Member) ReadMarkerDTO {
+	var lastReadMessageId *string
+	if member.GetLastReadMessageId() != nil {
+		id := member.GetLastReadMessageId().String()
+		lastReadMessageId = &id
+	}
+
+	return ReadMarkerDTO{
+		ChannelID:         channelID,
+		UserID:            member.GetId().String(),
+		LastReadMessageID: lastReadMessageId,
+		LastReadAt:        member.GetLastRead(),
+	}
+}
+
 type MessageDTO struct {
-	ID              string             `json:"id"`
-	ChannelID       string             `json:"channel_id"`
-	SenderUserID    *string            `json:"sender_user_id,omitempty"`
-	IntegrationID   *string            `json:"integration_id,omitempty"`
-	ContentText     string             `json:"content_text"`
-	CreatedAt       time.Time          `json:"created_at"`
-	ParentMessageID *string            `json:"parent_message_id,omitempty"`
-	SenderUser      *UserDTO           `json:"sender_user,omitempty"`
-	IntegrationBot  *IntegrationBotDTO `json:"integration_bot,omitempty"`
-	Reactions       []ReactionDTO      `json:"reactions,omitempty"`
+	ID              string               `json:"id"`
+	ChannelID       string               `json:"channel_id"`
+	SenderUserID    *string              `json:"sender_user_id,omitempty"`
+	IntegrationID   *string              `json:"integration_id,omitempty"`
+	ContentText     string               `json:"content_text"`
+	ContentRichText RichText             `json:"content_rich_text"`
+	IsFormatted     bool                 `json:"is_formatted"`
+	Mentions        []string             `json:"mentions,omitempty"`
+	CreatedAt       time.Time            `json:"created_at"`
+	EditedAt        *time.Time           `json:"edited_at,omitempty"`
+	IsDeleted       bool                 `json:"is_deleted"`
+	DeletedAt       *time.Time           `json:"deleted_at,omitempty"`
+	IsPinned        bool                 `json:"is_pinned"`
+	PinnedAt        *time.Time           `json:"pinned_at,omitempty"`
+	PinnedBy        *string              `json:"pinned_by,omitempty"`
+	ParentMessageID *string              `json:"parent_message_id,omitempty"`
+	ReplyCount      int                  `json:"reply_count"`
+	LastReplyAt     *time.Time           `json:"last_reply_at,omitempty"`
+	Participants    []string             `json:"participants,omitempty"`
+	SenderUser      *UserDTO             `json:"sender_user,omitempty"`
+	IntegrationBot  *IntegrationBotDTO   `json:"integration_bot,omitempty"`
+	Reactions       []ReactionCountDTO   `json:"reactions,omitempty"`
+	LinkPreviews    []LinkPreviewDTO     `json:"link_previews,omitempty"`
+	Attachments     []AttachmentDTO      `json:"attachments,omitempty"`
+	Poll            *PollDTO             `json:"poll,omitempty"`
+	Reference       *MessageReferenceDTO `json:"reference,omitempty"`
+}
+
+// MessageReferenceDTO describes the message a message forwards or quotes. Accessible and Preview are only set once the
+// viewer is known: Preview holds the source when the viewer can read its channel. Both are left out of live events
+// for sources in other channels, and clients load the preview separately.
+type MessageReferenceDTO struct {
+	Kind            MessageReferenceKind `json:"kind"`
+	SourceMessageID string               `json:"source_message_id"`
+	SourceChannelID string               `json:"source_channel_id"`
+	Accessible      *bool                `json:"accessible,omitempty"`
+	Preview         *MessagePreviewDTO   `json:"preview,omitempty"`
+}
+
+// MessagePreviewDTO is the part of a source message that is embedded in a forward or quote
+type MessagePreviewDTO struct {
+	ID              string     `json:"id"`
+	ChannelID       string     `json:"channel_id"`
+	SenderUserID    *string    `json:"sender_user_id,omitempty"`
+	IntegrationID   *string    `json:"integration_id,omitempty"`
+	SenderUser      *UserDTO   `json:"sender_user,omitempty"`
+	ContentText     string     `json:"content_text"`
+	ContentRichText RichText   `json:"content_rich_text"`
+	IsFormatted     bool       `json:"is_formatted"`
+	AttachmentCount int        `json:"attachment_count"`
+	IsPoll          bool       `json:"is_poll"`
+	CreatedAt       time.Time  `json:"created_at"`
+	EditedAt        *time.Time `json:"edited_at,omitempty"`
+	IsDeleted       bool       `json:"is_deleted"`
+}
+
+// ToMessageReferenceDTO returns the reference of a message without its preview, or nil when there is none
+func ToMessageReferenceDTO(message *Message) *MessageReferenceDTO {
+	reference := message.GetReference()
+	if reference == nil {
+		return nil
+	}
+	return &MessageReferenceDTO{
+		Kind:            reference.GetKind(),
+		SourceMessageID: reference.GetSourceMessageId().String(),
+		SourceChannelID: reference.GetSourceChannelId().String(),
+	}
+}
+
+func ToMessagePreviewDTO(source *Message, sender *User) MessagePreviewDTO {
+	var senderId, integrationId *string
+	if source.GetSenderUserId() != nil {
+		sId := source.GetSenderUserId().String()
+		senderId = &sId
+	}
+	if source.GetIntegrationId() != nil {
+		iId := source.GetIntegrationId().String()
+		integrationId = &iId
+	}
+
+	var senderUser *UserDTO
+	if sender != nil {
+		user := ToUserDTO(sender)
+		senderUser = &user
+	}
+
+	return MessagePreviewDTO{
+		ID:              source.GetId().String(),
+		ChannelID:       source.GetChannelId().String(),
+		SenderUserID:    senderId,
+		IntegrationID:   integrationId,
+		SenderUser:      senderUser,
+		ContentText:     source.GetContent().GetText(),
+		ContentRichText: source.GetContent().GetRichText(),
+		IsFormatted:     source.GetContent().GetIsFormatted(),
+		AttachmentCount: len(source.GetAttachments()),
+		IsPoll:          source.GetPoll() != nil,
+		CreatedAt:       source.GetCreatedAt(),
+		EditedAt:        source.GetEditedAt(),
+		IsDeleted:       source.IsDeleted(),
+	}
+}
+
+type LinkPreviewDTO struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	SiteName    string `json:"site_name,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+}
+
+func ToLinkPreviewDTOs(previews []LinkPreview) []LinkPreviewDTO {
+	dtos := make([]LinkPreviewDTO, len(previews))
+	for i, preview := range previews {
+		dtos[i] = LinkPreviewDTO{
+			URL:         preview.GetUrl(),
+			Title:       preview.GetTitle(),
+			Description: preview.GetDescription(),
+			SiteName:    preview.GetSiteName(),
+			ImageURL:    preview.GetImageUrl(),
+		}
+	}
+	return dtos
+}
+
+// AttachmentDTO describes a file sent with a message. URL is a signed download link valid until URLExpiresAt.
+// Images carry their dimensions, placeholders and thumbnails once processing is ready.
+type AttachmentDTO struct {
+	ID               string                   `json:"id"`
+	FileName         string                   `json:"file_name"`
+	ContentType      string                   `json:"content_type"`
+	Size             int64                    `json:"size"`
+	URL              string                   `json:"url,omitempty"`
+	URLExpiresAt     *time.Time               `json:"url_expires_at,omitempty"`
+	ProcessingStatus string                   `json:"processing_status"`
+	Width            int                      `json:"width,omitempty"`
+	Height           int                      `json:"height,omitempty"`
+	BlurHash         string                   `json:"blur_hash,omitempty"`
+	DominantColor    string                   `json:"dominant_color,omitempty"`
+	Thumbnails       []AttachmentThumbnailDTO `json:"thumbnails,omitempty"`
+	CreatedAt        time.Time                `json:"created_at"`
+}
+
+// AttachmentThumbnailDTO is a scaled down copy of an image. URL is signed like the URL of the original.
+type AttachmentThumbnailDTO struct {
+	Name        string `json:"name"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	URL         string `json:"url,omitempty"`
+}
+
+func ToAttachmentDTO(attachment *Attachment) AttachmentDTO {
+	var thumbnails []AttachmentThumbnailDTO
+	for _, thumbnail := range attachment.GetThumbnails() {
+		thumbnails = append(thumbnails, AttachmentThumbnailDTO{
+			Name:        thumbnail.GetName(),
+			Width:       thumbnail.GetWidth(),
+			Height:      thumbnail.GetHeight(),
+			ContentType: thumbnail.GetContentType(),
+		})
+	}
+
+	return AttachmentDTO{
+		ID:               attachment.GetId().String(),
+		FileName:         attachment.GetFileName(),
+		ContentType:      attachment.GetContentType(),
+		Size:             attachment.GetSize(),
+		ProcessingStatus: string(attachment.GetProcessingStatus()),
+		Width:            attachment.GetWidth(),
+		Height:           attachment.GetHeight(),
+		BlurHash:         attachment.GetBlurHash(),
+		DominantColor:    attachment.GetDominantColor(),
+		Thumbnails:       thumbnails,
+		CreatedAt:        attachment.GetCreatedAt(),
+	}
+}
+
+func ToAttachmentDTOs(attachments []Attachment) []AttachmentDTO {
+	dtos := make([]AttachmentDTO, len(attachments))
+	for i := range attachments {
+		dtos[i] = ToAttachmentDTO(&attachments[i])
+	}
+	return dtos
+}
+
+type MessagePageDTO struct {
+	Messages   []MessageDTO `json:"messages"`
+	NextCursor *string      `json:"next_cursor"`
+	PrevCursor *string      `json:"prev_cursor"`
+}
+
+func ToMessagePageDTO(page *MessagePage, messages []MessageDTO) MessagePageDTO {
+	dto := MessagePageDTO{Messages: messages}
+	if page.NextCursor != nil {
+		next := page.NextCursor.String()
+		dto.NextCursor = &next
+	}
+	if page.PrevCursor != nil {
+		prev := page.PrevCursor.String()
+		dto.PrevCursor = &prev
+	}
+	return dto
+}
+
+type ThreadDTO struct {
+	Root    MessageDTO     `json:"root"`
+	Replies MessagePageDTO `json:"replies"`
 }
 
 type IntegrationBotDTO struct {
@@ -90,7 +389,9 @@ func ToUserDTO(user *User) UserDTO {
 	}
 }
 
-func ToMessageDTO(message *Message, sender *User, integration *IntegrationBot) MessageDTO {
+// ToMessageDTO returns the message as seen by viewerID, which decides ReactedByMe. Messages broadcast to a
+// whole channel are converted with uuid.Nil.
+func ToMessageDTO(message *Message, sender *User, integration *IntegrationBot, viewerID uuid.UUID) MessageDTO {
 	var senderId, integrationId, parentId *string
 	if message.GetSenderUserId() != nil {
 		sId := message.GetSenderUserId().String()
@@ -107,9 +408,21 @@ func ToMessageDTO(message *Message, sender *User, integration *IntegrationBot) M
 		parentId = &pId
 	}
 
-	reactionsDTO := make([]ReactionDTO, len(message.GetReactions()))
-	for i, reaction := range message.GetReactions() {
-		reactionsDTO[i] = ToReactionDTO(reaction)
+	var pinnedBy *string
+	if message.GetPinnedBy() != nil {
+		pBy := message.GetPinnedBy().String()
+		pinnedBy = &pBy
+	}
+
+	var mentions []string
+	for _, mentionedId := range message.GetContent().GetMentions() {
+		mentions = append(mentions, mentionedId.String())
+	}
+
+	thread := message.GetThread()
+	var participants []string
+	for _, participantId := range thread.GetParticipantIds() {
+		participants = append(participants, participantId.String())
 	}
 
 	var integrationBot *IntegrationBotDTO
@@ -130,14 +443,157 @@ func ToMessageDTO(message *Message, sender *User, integration *IntegrationBot) M
 		SenderUserID:    senderId,
 		IntegrationID:   integrationId,
 		ContentText:     message.GetContent().GetText(),
+		ContentRichText: message.GetContent().GetRichText(),
+		IsFormatted:     message.GetContent().GetIsFormatted(),
+		Mentions:        mentions,
 		CreatedAt:       message.GetCreatedAt(),
+		EditedAt:        message.GetEditedAt(),
+		IsDeleted:       message.IsDeleted(),
+		DeletedAt:       message.GetDeletedAt(),
+		IsPinned:        message.IsPinned(),
+		PinnedAt:        message.GetPinnedAt(),
+		PinnedBy:        pinnedBy,
 		ParentMessageID: parentId,
-		Reactions:       reactionsDTO,
+		ReplyCount:      thread.GetReplyCount(),
+		LastReplyAt:     thread.GetLastReplyAt(),
+		Participants:    participants,
+		Reactions:       ToReactionCountDTOs(message.GetReactions(), viewerID),
+		LinkPreviews:    ToLinkPreviewDTOs(message.GetLinkPreviews()),
+		Attachments:     ToAttachmentDTOs(message.GetAttachments()),
+		Poll:            ToPollDTO(message.GetPoll(), viewerID),
+		Reference:       ToMessageReferenceDTO(message),
 		SenderUser:      senderUser,
 		IntegrationBot:  integrationBot,
 	}
 }
 
+type BookmarkDTO struct {
+	ID        string      `json:"id"`
+	ChannelID string      `json:"channel_id"`
+	MessageID string      `json:"message_id"`
+	Note      string      `json:"note"`
+	CreatedAt time.Time   `json:"created_at"`
+	Message   *MessageDTO `json:"message,omitempty"`
+}
+
+type BookmarkPageDTO struct {
+	Bookmarks  []BookmarkDTO `json:"bookmarks"`
+	NextCursor *string       `json:"next_cursor"`
+}
+
+func ToBookmarkDTO(bookmark *Bookmark, message *MessageDTO) BookmarkDTO {
+	return BookmarkDTO{
+		ID:        bookmark.GetId().String(),
+		ChannelID: bookmark.GetChannelId().String(),
+		MessageID: bookmark.GetMessageId().String(),
+		Note:      bookmark.GetNote(),
+		CreatedAt: bookmark.GetCreatedAt(),
+		Message:   message,
+	}
+}
+
+func ToBookmarkPageDTO(page *BookmarkPage, bookmarks []BookmarkDTO) BookmarkPageDTO {
+	dto := BookmarkPageDTO{Bookmarks: bookmarks}
+	if page.NextCursor != nil {
+		next := page.NextCursor.String()
+		dto.NextCursor = &next
+	}
+	return dto
+}
+
+type MessageSearchResultDTO struct {
+	Message MessageDTO `json:"message"`
+	Snippet string     `json:"snippet"`
+	Rank    float32    `json:"rank"`
+}
+
+type MessageSearchPageDTO struct {
+	Results    []MessageSearchResultDTO `json:"results"`
+	NextCursor *string                  `json:"next_cursor"`
+}
+
+func ToMessageSearchPageDTO(page *MessageSearchPage, messages []MessageDTO) MessageSearchPageDTO {
+	dto := MessageSearchPageDTO{Results: make([]MessageSearchResultDTO, len(page.Results))}
+	for i, result := range page.Results {
+		dto.Results[i] = MessageSearchResultDTO{
+			Message: messages[i],
+			Snippet: result.Snippet,
+			Rank:    result.Rank,
+		}
+	}
+	if page.NextCursor != nil {
+		next := page.NextCursor.Encode()
+		dto.NextCursor = &next
+	}
+	return dto
+}
+
+type ScheduledMessageDTO struct {
+	ID              string    `json:"id"`
+	ChannelID       string    `json:"channel_id"`
+	ParentMessageID *string   `json:"parent_message_id"`
+	ContentText     string    `json:"content_text"`
+	ScheduledFor    time.Time `json:"scheduled_for"`
+	Status          string    `json:"status"`
+	FailureReason   string    `json:"failure_reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func ToScheduledMessageDTO(message *ScheduledMessage) ScheduledMessageDTO {
+	dto := ScheduledMessageDTO{
+		ID:            message.GetId().String(),
+		ChannelID:     message.GetChannelId().String(),
+		ContentText:   message.GetContentText(),
+		ScheduledFor:  message.GetScheduledFor(),
+		Status:        string(message.GetStatus()),
+		FailureReason: message.GetFailureReason(),
+		CreatedAt:     message.GetCreatedAt(),
+		UpdatedAt:     message.GetUpdatedAt(),
+	}
+	if message.GetParentMessageId() != nil {
+		parentID := message.GetParentMessageId().String()
+		dto.ParentMessageID = &parentID
+	}
+	return dto
+}
+
+type ReminderDTO struct {
+	ID        string      `json:"id"`
+	ChannelID string      `json:"channel_id"`
+	MessageID string      `json:"message_id"`
+	RemindAt  time.Time   `json:"remind_at"`
+	Status    string      `json:"status"`
+	FiredAt   *time.Time  `json:"fired_at,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	Message   *MessageDTO `json:"message,omitempty"`
+}
+
+func ToReminderDTO(reminder *Reminder, message *MessageDTO) ReminderDTO {
+	return ReminderDTO{
+		ID:        reminder.GetId().String(),
+		ChannelID: reminder.GetChannelId().String(),
+		MessageID: reminder.GetMessageId().String(),
+		RemindAt:  reminder.GetRemindAt(),
+		Status:    string(reminder.GetStatus()),
+		FiredAt:   reminder.GetFiredAt(),
+		CreatedAt: reminder.GetCreatedAt(),
+		Message:   message,
+	}
+}
+
+type SlashCommandDTO struct {
+	Name        string `json:"name"`
+	Usage       string `json:"usage"`
+	Description string `json:"description"`
+}
+
+// SlashCommandResponseDTO answers a command that did not post a message; Text is only shown to the invoker
+type SlashCommandResponseDTO struct {
+	Command string `json:"command"`
+	Text    string `json:"text"`
+}
+
 type ReactionDTO struct {
 	ID           string    `json:"id"`
 	MessageID    string    `json:"message_id"`
@@ -156,6 +612,126 @@ func ToReactionDTO(reaction Reaction) ReactionDTO {
 	}
 }
 
+// ReactionCountDTO is how many users reacted to a message with one reaction type
+type ReactionCountDTO struct {
+	ReactionType string `json:"reaction_type"`
+	Count        int    `json:"count"`
+	ReactedByMe  bool   `json:"reacted_by_me"`
+}
+
+// ToReactionCountDTOs groups reactions by type, in the order each type was first used
+func ToReactionCountDTOs(reactions []Reaction, viewerID uuid.UUID) []ReactionCountDTO {
+	counts := []ReactionCountDTO{}
+	indexByType := make(map[string]int)
+	for _, reaction := range reactions {
+		index, ok := indexByType[reaction.GetReactionType()]
+		if !ok {
+			index = len(counts)
+			indexByType[reaction.GetReactionType()] = index
+			counts = append(counts, ReactionCountDTO{ReactionType: reaction.GetReactionType()})
+		}
+		counts[index].Count++
+		if viewerID != uuid.Nil && reaction.GetUserId() == viewerID {
+			counts[index].ReactedByMe = true
+		}
+	}
+	return counts
+}
+
+// PollDTO is a poll with its current tallies. Voters are left out of anonymous polls.
+type PollDTO struct {
+	Question       string          `json:"question"`
+	Options        []PollOptionDTO `json:"options"`
+	MultipleChoice bool            `json:"multiple_choice"`
+	Anonymous      bool            `json:"anonymous"`
+	VoterCount     int             `json:"voter_count"`
+	ClosesAt       *time.Time      `json:"closes_at,omitempty"`
+	ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+	IsClosed       bool            `json:"is_closed"`
+}
+
+type PollOptionDTO struct {
+	ID        string   `json:"id"`
+	Text      string   `json:"text"`
+	VoteCount int      `json:"vote_count"`
+	Voters    []string `json:"voters,omitempty"`
+	VotedByMe bool     `json:"voted_by_me"`
+}
+
+// ToPollDTO returns the poll as seen by the viewer, or nil when there is no poll
+func ToPollDTO(poll *Poll, viewerID uuid.UUID) *PollDTO {
+	if poll == nil {
+		return nil
+	}
+
+	options := []PollOptionDTO{}
+	for _, tally := range poll.GetTallies() {
+		option := PollOptionDTO{
+			ID:        tally.Option.GetId().String(),
+			Text:      tally.Option.GetText(),
+			VoteCount: tally.Count,
+		}
+		for _, voterID := range tally.Voters {
+			if !poll.IsAnonymous() {
+				option.Voters = append(option.Voters, voterID.String())
+			}
+			if viewerID != uuid.Nil && voterID == viewerID {
+				option.VotedByMe = true
+			}
+		}
+		options = append(options, option)
+	}
+
+	return &PollDTO{
+		Question:       poll.GetQuestion(),
+		Options:        options,
+		MultipleChoice: poll.IsMultipleChoice(),
+		Anonymous:      poll.IsAnonymous(),
+		VoterCount:     poll.GetVoterCount(),
+		ClosesAt:       poll.GetClosesAt(),
+		ClosedAt:       poll.GetClosedAt(),
+		IsClosed:       poll.IsClosed(time.Now().UTC()),
+	}
+}
+
+// CustomEmojiDTO describes a custom emoji. Aliases name the emoji they stand for in AliasFor and share its image.
+type CustomEmojiDTO struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	AliasFor  string    `json:"alias_for,omitempty"`
+	ImageURL  string    `json:"image_url"`
+	CreatorID string    `json:"creator_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func ToCustomEmojiDTO(emoji *CustomEmoji) CustomEmojiDTO {
+	return CustomEmojiDTO{
+		ID:        emoji.GetId().String(),
+		Name:      emoji.GetName(),
+		AliasFor:  emoji.GetAliasForName(),
+		CreatorID: emoji.GetCreatorId().String(),
+		CreatedAt: emoji.GetCreatedAt(),
+	}
+}
+
+type MessageRevisionDTO struct {
+	ID                  string    `json:"id"`
+	MessageID           string    `json:"message_id"`
+	PreviousContentText string    `json:"previous_content_text"`
+	EditedBy            string    `json:"edited_by"`
+	EditedAt            time.Time `json:"edited_at"`
+}
+
+func ToMessageRevisionDTO(revision MessageRevision) MessageRevisionDTO {
+	return MessageRevisionDTO{
+		ID:                  revision.GetId().String(),
+		MessageID:           revision.GetMessageId().String(),
+		PreviousContentText: revision.GetPreviousContent().GetText(),
+		EditedBy:            revision.GetEditedBy().String(),
+		EditedAt:            revision.GetEditedAt(),
+	}
+}
+
 type ChannelInviteDTO struct {
 	ID              string    `json:"id"`
 	ChannelID       string    `json:"channel_id"`
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxReactionTypeLength is the longest reaction that can be stored, in bytes
+const MaxReactionTypeLength = 100
+
+// ErrInvalidReactionType is returned for reactions that are neither a Unicode emoji nor a registered custom emoji
+var ErrInvalidReactionType = errors.New("reaction must be an emoji or a custom emoji like :name:")
+
+const (
+	zeroWidthJoiner   = '\u200D'
+	variationText     = '\uFE0E'
+	variationEmoji    = '\uFE0F'
+	combiningKeycap   = '\u20E3'
+	tagTerminator     = '\U000E007F'
+	regionalIndicator = '\U0001F1E6'
+)
+
+// pictographicRanges approximates the Unicode Extended_Pictographic property: the code points emoji are built from
+var pictographicRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00A9, Stride: 1},
+		{Lo: 0x00AE, Hi: 0x00AE, Stride: 1},
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21A9, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x23CF, Hi: 0x23CF, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x23F8, Hi: 0x23FA, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25AB, Stride: 1},
+		{Lo: 0x25B6, Hi: 0x25B6, Stride: 1},
+		{Lo: 0x25C0, Hi: 0x25C0, Stride: 1},
+		{Lo: 0x25FB, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B07, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1F1E5, Stride: 1},
+		{Lo: 0x1F200, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1FAFF, Stride: 1},
+	},
+}
+
+// IsUnicodeEmoji reports whether s is a single emoji: a pictograph with optional variation selector, skin tone and
+// tags, a flag, a keycap, or several of those joined into one emoji with zero width joiners
+func IsUnicodeEmoji(s string) bool {
+	if s == "" || len(s) > MaxReactionTypeLength || !utf8.ValidString(s) {
+		return false
+	}
+
+	runes := []rune(s)
+	i := 0
+	for {
+		next, ok := scanEmojiElement(runes, i)
+		if !ok {
+			return false
+		}
+		i = next
+		if i == len(runes) {
+			return true
+		}
+		if runes[i] != zeroWidthJoiner {
+			return false
+		}
+		i++
+	}
+}
+
+// scanEmojiElement reads one emoji element starting at i and returns the index after it
+func scanEmojiElement(runes []rune, i int) (int, bool) {
+	if i >= len(runes) {
+		return i, false
+	}
+	r := runes[i]
+
+	switch {
+	case isRegionalIndicator(r):
+		// Flags are pairs of regional indicators
+		if i+1 < len(runes) && isRegionalIndicator(runes[i+1]) {
+			return i + 2, true
+		}
+		return i, false
+
+	case r >= '0' && r <= '9' || r == '#' || r == '*':
+		i++
+		if i < len(runes) && runes[i] == variationEmoji {
+			i++
+		}
+		if i < len(runes) && runes[i] == combiningKeycap {
+			return i + 1, true
+		}
+		return i, false
+
+	case unicode.Is(pictographicRanges, r):
+		i++
+		if i < len(runes) && (runes[i] == variationEmoji || runes[i] == variationText) {
+			i++
+		}
+		if i < len(runes) && isSkinToneModifier(runes[i]) {
+			i++
+		}
+		// Tag sequences, such as the flags of England or Scotland, end with a cancel tag
+		if i < len(runes) && isEmojiTag(runes[i]) {
+			for i < len(runes) && isEmojiTag(runes[i]) {
+				i++
+			}
+			if i >= len(runes) || runes[i] != tagTerminator {
+				return i, false
+			}
+			i++
+		}
+		return i, true
+
+	default:
+		return i, false
+	}
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= regionalIndicator && r <= regionalIndicator+25
+}
+
+func isSkinToneModifier(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isEmojiTag(r rune) bool {
+	return r >= 0xE0020 && r <= 0xE007E
+}
+
+// ParseCustomEmojiReaction returns the name of the custom emoji a reaction like :party_parrot: refers to
+func ParseCustomEmojiReaction(reactionType string) (string, bool) {
+	if len(reactionType) < 3 || !strings.HasPrefix(reactionType, ":") || !strings.HasSuffix(reactionType, ":") {
+		return "", false
+	}
+	name := reactionType[1 : len(reactionType)-1]
+	if ValidateCustomEmojiName(name) != nil {
+		return "", false
+	}
+	return name, true
+}
+
+// CustomEmojiReaction returns the reaction type for a custom emoji
+func CustomEmojiReaction(name string) string {
+	return ":" + name + ":"
+}
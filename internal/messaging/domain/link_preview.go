@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxLinkPreviewsPerMessage is how many of a message's links are unfurled
+	MaxLinkPreviewsPerMessage = 3
+
+	maxLinkPreviewTitleLength       = 300
+	maxLinkPreviewDescriptionLength = 1000
+	maxLinkPreviewSiteNameLength    = 100
+)
+
+// ErrNoLinkPreview is returned when a page has no title, description or image to preview
+var ErrNoLinkPreview = errors.New("page has no preview metadata")
+
+// LinkPreview is the OpenGraph or Twitter card summary of a link in a message
+type LinkPreview struct {
+	url         string
+	title       string
+	description string
+	siteName    string
+	imageUrl    string
+	fetchedAt   time.Time
+}
+
+// NewLinkPreview builds a preview for url from page metadata, trimming overly long values
+func NewLinkPreview(url, title, description, siteName, imageUrl string) (*LinkPreview, error) {
+	title = truncatePreviewText(title, maxLinkPreviewTitleLength)
+	description = truncatePreviewText(description, maxLinkPreviewDescriptionLength)
+	siteName = truncatePreviewText(siteName, maxLinkPreviewSiteNameLength)
+	imageUrl = strings.TrimSpace(imageUrl)
+
+	if title == "" && description == "" && imageUrl == "" {
+		return nil, ErrNoLinkPreview
+	}
+
+	return &LinkPreview{
+		url:         url,
+		title:       title,
+		description: description,
+		siteName:    siteName,
+		imageUrl:    imageUrl,
+		fetchedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// For external usage
+func RehydrateLinkPreview(url, title, description, siteName, imageUrl string, fetchedAt time.Time) LinkPreview {
+	return LinkPreview{
+		url:         url,
+		title:       title,
+		description: description,
+		siteName:    siteName,
+		imageUrl:    imageUrl,
+		fetchedAt:   fetchedAt,
+	}
+}
+
+func truncatePreviewText(text string, maxLength int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:maxLength-1])) + "…"
+}
+
+func (p *LinkPreview) GetUrl() string {
+	return p.url
+}
+
+func (p *LinkPreview) GetTitle() string {
+	return p.title
+}
+
+func (p *LinkPreview) GetDescription() string {
+	return p.description
+}
+
+func (p *LinkPreview) GetSiteName() string {
+	return p.siteName
+}
+
+func (p *LinkPreview) GetImageUrl() string {
+	return p.imageUrl
+}
+
+func (p *LinkPreview) GetFetchedAt() time.Time {
+	return p.fetchedAt
+}
@@ -7,13 +7,14 @@ import (
 )
 
 type Member struct {
-	id       uuid.UUID
-	role     string
-	joinedAt time.Time
-	lastRead time.Time
+	id                uuid.UUID
+	role              MemberRole
+	joinedAt          time.Time
+	lastRead          time.Time
+	lastReadMessageId *uuid.UUID
 }
 
-func newMember(id uuid.UUID, role string, joinedAt, lastRead time.Time) Member {
+func newMember(id uuid.UUID, role MemberRole, joinedAt, lastRead time.Time) Member {
 	return Member{
 		id:       id,
 		role:     role,
@@ -30,11 +31,11 @@ func (m *Member) setId(id uuid.UUID) {
 	m.id = id
 }
 
-func (m *Member) GetRole() string {
+func (m *Member) GetRole() MemberRole {
 	return m.role
 }
 
-func (m *Member) setRole(role string) {
+func (m *Member) setRole(role MemberRole) {
 	m.role = role
 }
 
@@ -54,16 +55,26 @@ func (m *Member) setLastRead(lastRead time.Time) {
 	m.lastRead = lastRead
 }
 
+func (m *Member) GetLastReadMessageId() *uuid.UUID {
+	return m.lastReadMessageId
+}
+
+func (m *Member) setLastReadMessageId(id *uuid.UUID) {
+	m.lastReadMessageId = id
+}
+
 func RehydrateMember(
 	id uuid.UUID,
 	role string,
 	joinedAt time.Time,
 	lastRead time.Time,
+	lastReadMessageId *uuid.UUID,
 ) Member {
 	return Member{
-		id:       id,
-		role:     role,
-		joinedAt: joinedAt,
-		lastRead: lastRead,
+		id:                id,
+		role:              MemberRole(role),
+		joinedAt:          joinedAt,
+		lastRead:          lastRead,
+		lastReadMessageId: lastReadMessageId,
 	}
 }
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MemberRole is the role a member holds within a channel
+type MemberRole string
+
+const (
+	RoleOwner     MemberRole = "owner"
+	RoleAdmin     MemberRole = "admin"
+	RoleModerator MemberRole = "moderator"
+	RoleMember    MemberRole = "member"
+	RoleBot       MemberRole = "bot"
+	RoleGuest     MemberRole = "guest"
+)
+
+// Permission is an action within a channel that is restricted by role
+type Permission string
+
+const (
+	PermissionArchiveChannel   Permission = "archive_channel"
+	PermissionSetTopic         Permission = "set_topic"
+	PermissionRenameChannel    Permission = "rename_channel"
+	PermissionCreateInvites    Permission = "create_invites"
+	PermissionManageInvites    Permission = "manage_invites"
+	PermissionManageBots       Permission = "manage_bots"
+	PermissionDeleteAnyMessage Permission = "delete_any_message"
+	PermissionPinMessages      Permission = "pin_messages"
+	PermissionKickMembers      Permission = "kick_members"
+	PermissionBanMembers       Permission = "ban_members"
+	PermissionManageRoles      Permission = "manage_roles"
+)
+
+var ErrPermissionDenied = errors.New("user does not have permission to do this action")
+
+// rolePermissions is the permission matrix for channel roles
+var rolePermissions = map[MemberRole]map[Permission]bool{
+	RoleOwner: {
+		PermissionArchiveChannel:   true,
+		PermissionSetTopic:         true,
+		PermissionRenameChannel:    true,
+		PermissionCreateInvites:    true,
+		PermissionManageInvites:    true,
+		PermissionManageBots:       true,
+		PermissionDeleteAnyMessage: true,
+		PermissionPinMessages:      true,
+		PermissionKickMembers:      true,
+		PermissionBanMembers:       true,
+		PermissionManageRoles:      true,
+	},
+	RoleAdmin: {
+		PermissionArchiveChannel:   true,
+		PermissionSetTopic:         true,
+		PermissionRenameChannel:    true,
+		PermissionCreateInvites:    true,
+		PermissionManageInvites:    true,
+		PermissionManageBots:       true,
+		PermissionDeleteAnyMessage: true,
+		PermissionPinMessages:      true,
+		PermissionKickMembers:      true,
+		PermissionBanMembers:       true,
+		PermissionManageRoles:      true,
+	},
+	RoleModerator: {
+		PermissionSetTopic:         true,
+		PermissionCreateInvites:    true,
+		PermissionManageInvites:    true,
+		PermissionDeleteAnyMessage: true,
+		PermissionPinMessages:      true,
+		PermissionKickMembers:      true,
+	},
+	RoleMember: {
+		PermissionCreateInvites: true,
+		PermissionPinMessages:   true,
+	},
+	RoleBot:   {},
+	RoleGuest: {},
+}
+
+// roleRanks orders the roles; a member can only manage members ranked below them
+var roleRanks = map[MemberRole]int{
+	RoleOwner:     5,
+	RoleAdmin:     4,
+	RoleModerator: 3,
+	RoleMember:    2,
+	RoleGuest:     1,
+	RoleBot:       0,
+}
+
+// ParseMemberRole validates a role value
+func ParseMemberRole(value string) (MemberRole, error) {
+	role := MemberRole(value)
+	if _, ok := rolePermissions[role]; !ok {
+		return "", fmt.Errorf("invalid member role %q", value)
+	}
+	return role, nil
+}
+
+// Can reports whether the role grants the permission
+func (r MemberRole) Can(permission Permission) bool {
+	return rolePermissions[r][permission]
+}
+
+// Outranks reports whether the role is ranked strictly above the other role
+func (r MemberRole) Outranks(other MemberRole) bool {
+	return roleRanks[r] > roleRanks[other]
+}
+
+// Authorize returns ErrPermissionDenied unless the user is a member whose role grants the permission
+func (c *Channel) Authorize(userID uuid.UUID, permission Permission) error {
+	member := c.findMember(userID)
+	if member == nil || !member.GetRole().Can(permission) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// PromoteMember raises a member to a higher role
+func (c *Channel) PromoteMember(actorID, targetUserID uuid.UUID, role MemberRole) (*Member, error) {
+	if target := c.findMember(targetUserID); target != nil && !role.Outranks(target.GetRole()) {
+		return nil, fmt.Errorf("role %q is not higher than the member's current role", role)
+	}
+	return c.changeMemberRole(actorID, targetUserID, role)
+}
+
+// DemoteMember lowers a member to a lower role
+func (c *Channel) DemoteMember(actorID, targetUserID uuid.UUID, role MemberRole) (*Member, error) {
+	if target := c.findMember(targetUserID); target != nil && !target.GetRole().Outranks(role) {
+		return nil, fmt.Errorf("role %q is not lower than the member's current role", role)
+	}
+	return c.changeMemberRole(actorID, targetUserID, role)
+}
+
+// changeMemberRole sets a member's role.
+// The actor needs the manage roles permission, must outrank both the member's current and new role,
+// and ownership cannot be granted this way.
+func (c *Channel) changeMemberRole(actorID, targetUserID uuid.UUID, role MemberRole) (*Member, error) {
+	if err := c.Authorize(actorID, PermissionManageRoles); err != nil {
+		return nil, err
+	}
+
+	target := c.findMember(targetUserID)
+	if target == nil {
+		return nil, errors.New("user is not a member of the channel")
+	}
+
+	if role == RoleOwner || role == RoleBot || target.GetRole() == RoleBot {
+		return nil, fmt.Errorf("cannot change role to %q: %w", role, ErrPermissionDenied)
+	}
+
+	actorRole := c.findMember(actorID).GetRole()
+	if !actorRole.Outranks(target.GetRole()) || !actorRole.Outranks(role) {
+		return nil, ErrPermissionDenied
+	}
+
+	oldRole := target.GetRole()
+	target.setRole(role)
+	c.Version++
+
+	c.addEvent(CreateMemberRoleChangedEvent(c, targetUserID, oldRole, role, actorID))
+	return target, nil
+}
@@ -15,6 +15,17 @@ type Message struct {
 	createdAt       time.Time
 	parentMessageId *uuid.UUID
 	reactions       []Reaction
+	editedAt        *time.Time
+	deletedAt       *time.Time
+	deletedBy       *uuid.UUID
+	pinnedAt        *time.Time
+	pinnedBy        *uuid.UUID
+	thread          ThreadSummary
+	linkPreviews    []LinkPreview
+	attachments     []Attachment
+	poll            *Poll             // nil unless the message is a poll
+	reference       *MessageReference // nil unless the message forwards or quotes another one
+	source          *Message          // the referenced message, when loaded
 }
 
 func newMessage(id uuid.UUID, channelId uuid.UUID, senderUserId, integrationId, parentMessageId *uuid.UUID, content MessageContent, reactions []Reaction, timestamp time.Time) Message {
@@ -31,7 +42,7 @@ func newMessage(id uuid.UUID, channelId uuid.UUID, senderUserId, integrationId,
 }
 
 // For external usage
-func RehydrateMessage(id uuid.UUID, channelId uuid.UUID, senderUserId, integrationId, parentMessageId *uuid.UUID, content MessageContent, reactions []Reaction, timestamp time.Time) Message {
+func RehydrateMessage(id uuid.UUID, channelId uuid.UUID, senderUserId, integrationId, parentMessageId *uuid.UUID, content MessageContent, reactions []Reaction, timestamp time.Time, editedAt, deletedAt *time.Time, deletedBy *uuid.UUID, pinnedAt *time.Time, pinnedBy *uuid.UUID) Message {
 	return Message{
 		id:              id,
 		channelId:       channelId,
@@ -41,6 +52,11 @@ func RehydrateMessage(id uuid.UUID, channelId uuid.UUID, senderUserId, integrati
 		content:         content,
 		reactions:       reactions,
 		createdAt:       timestamp,
+		editedAt:        editedAt,
+		deletedAt:       deletedAt,
+		deletedBy:       deletedBy,
+		pinnedAt:        pinnedAt,
+		pinnedBy:        pinnedBy,
 	}
 }
 
@@ -108,6 +124,65 @@ func (m *Message) setReactions(reactions []Reaction) {
 	m.reactions = reactions
 }
 
+func (m *Message) GetEditedAt() *time.Time {
+	return m.editedAt
+}
+
+func (m *Message) setEditedAt(timestamp *time.Time) {
+	m.editedAt = timestamp
+}
+
+func (m *Message) GetDeletedAt() *time.Time {
+	return m.deletedAt
+}
+
+func (m *Message) GetDeletedBy() *uuid.UUID {
+	return m.deletedBy
+}
+
+// IsDeleted reports whether the message has been replaced by a tombstone
+func (m *Message) IsDeleted() bool {
+	return m.deletedAt != nil
+}
+
+// markDeleted turns the message into a tombstone, dropping its content, reactions, link previews, attachments, poll,
+// reference and pin
+func (m *Message) markDeleted(deletedBy uuid.UUID, timestamp time.Time) {
+	m.content = RehydrateMessageContent("", []uuid.UUID{}, []string{}, false, nil)
+	m.reactions = []Reaction{}
+	m.linkPreviews = nil
+	m.attachments = nil
+	m.poll = nil
+	m.reference = nil
+	m.source = nil
+	m.deletedAt = &timestamp
+	m.deletedBy = &deletedBy
+	m.clearPin()
+}
+
+func (m *Message) GetPinnedAt() *time.Time {
+	return m.pinnedAt
+}
+
+func (m *Message) GetPinnedBy() *uuid.UUID {
+	return m.pinnedBy
+}
+
+// IsPinned reports whether the message is pinned to its channel
+func (m *Message) IsPinned() bool {
+	return m.pinnedAt != nil
+}
+
+func (m *Message) setPinned(pinnedBy uuid.UUID, timestamp time.Time) {
+	m.pinnedAt = &timestamp
+	m.pinnedBy = &pinnedBy
+}
+
+func (m *Message) clearPin() {
+	m.pinnedAt = nil
+	m.pinnedBy = nil
+}
+
 func (m *Message) SetLoadedReactions(loadedReactions []Reaction) {
 	if loadedReactions == nil {
 		m.reactions = []Reaction{}
@@ -115,3 +190,53 @@ func (m *Message) SetLoadedReactions(loadedReactions []Reaction) {
 	}
 	m.reactions = loadedReactions
 }
+
+func (m *Message) GetThread() ThreadSummary {
+	return m.thread
+}
+
+func (m *Message) SetLoadedThread(thread ThreadSummary) {
+	m.thread = thread
+}
+
+func (m *Message) GetLinkPreviews() []LinkPreview {
+	return m.linkPreviews
+}
+
+// SetLoadedLinkPreviews attaches the previews of the message's links, in the order the links appear
+func (m *Message) SetLoadedLinkPreviews(previews []LinkPreview) {
+	m.linkPreviews = previews
+}
+
+func (m *Message) GetAttachments() []Attachment {
+	return m.attachments
+}
+
+// SetLoadedAttachments attaches the files sent with the message, in the order they were uploaded
+func (m *Message) SetLoadedAttachments(attachments []Attachment) {
+	m.attachments = attachments
+}
+
+func (m *Message) GetPoll() *Poll {
+	return m.poll
+}
+
+// SetLoadedPoll attaches the poll of a poll message, with its votes
+func (m *Message) SetLoadedPoll(poll *Poll) {
+	m.poll = poll
+}
+
+func (m *Message) GetReference() *MessageReference {
+	return m.reference
+}
+
+// GetSource returns the forwarded or quoted message. It is nil when the source was not loaded or no longer exists.
+func (m *Message) GetSource() *Message {
+	return m.source
+}
+
+// SetLoadedReference attaches the reference of a forwarded or quoting message, with the source message if it still exists
+func (m *Message) SetLoadedReference(reference *MessageReference, source *Message) {
+	m.reference = reference
+	m.source = source
+}
@@ -1,7 +1,8 @@
 package domain
 
 import (
-	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -12,35 +13,46 @@ type MessageContent struct {
 	mentions  []uuid.UUID
 	links     []string
 	formatted bool
+	richText  RichText
 }
 
-var (
-	urlRegex     = regexp.MustCompile(`https?:\/\/[^\s]+`)
-	mentionRegex = regexp.MustCompile(`@[\w-]+`)
-)
-
+// NewMessageContent parses the Markdown in the message. Links are taken from the parsed text,
+// so URLs inside code are not treated as links.
 func NewMessageContent(message string) MessageContent {
-	foundLinks := urlRegex.FindAllString(message, -1)
-	//foundUsernames := mentionRegex.FindAllString(message, -1)
+	richText := ParseRichText(message)
+
+	foundLinks := []string{}
+	richText.Walk(func(node *RichTextNode) {
+		if node.Type == RichTextLink && !slices.Contains(foundLinks, node.URL) {
+			foundLinks = append(foundLinks, node.URL)
+		}
+	})
 
-	if foundLinks == nil {
-		foundLinks = []string{}
-	}
 	return MessageContent{
 		text:      message,
 		mentions:  make([]uuid.UUID, 0),
 		links:     foundLinks,
-		formatted: true,
+		formatted: !richText.IsPlain(),
+		richText:  richText,
 	}
 }
 
-func RehydrateMessageContent(text string, mentions []uuid.UUID, links []string, formatted bool) MessageContent {
-	return MessageContent{
+// RehydrateMessageContent restores stored content. Content stored without a tree, or with one from an older parser,
+// is parsed again.
+func RehydrateMessageContent(text string, mentions []uuid.UUID, links []string, formatted bool, richText *RichText) MessageContent {
+	content := MessageContent{
 		text:      text,
 		mentions:  mentions,
 		links:     links,
 		formatted: formatted,
 	}
+	if richText != nil && richText.Version == RichTextVersion {
+		content.richText = *richText
+	} else {
+		content.richText = ParseRichText(text)
+		content.formatted = !content.richText.IsPlain()
+	}
+	return content
 }
 
 func (mc *MessageContent) GetText() string {
@@ -59,6 +71,44 @@ func (mc *MessageContent) setMentions(mentions []uuid.UUID) {
 	mc.mentions = mentions
 }
 
+// GetMentionedUsernames returns the distinct @usernames in the text, lowercased and without the @.
+// Mentions inside code are not included.
+func (mc *MessageContent) GetMentionedUsernames() []string {
+	seen := make(map[string]bool)
+	usernames := []string{}
+	mc.richText.Walk(func(node *RichTextNode) {
+		if node.Type != RichTextMention {
+			return
+		}
+		username := strings.ToLower(node.Username)
+		if seen[username] {
+			return
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	})
+	return usernames
+}
+
+// SetResolvedMentions stores the user IDs the mentioned usernames resolved to, keyed by lowercased username,
+// and links each mention in the rich text to its user
+func (mc *MessageContent) SetResolvedMentions(userIDs map[string]uuid.UUID) {
+	mc.mentions = []uuid.UUID{}
+	mc.richText.Walk(func(node *RichTextNode) {
+		if node.Type != RichTextMention {
+			return
+		}
+		userID, ok := userIDs[strings.ToLower(node.Username)]
+		if !ok {
+			return
+		}
+		node.UserID = &userID
+		if !slices.Contains(mc.mentions, userID) {
+			mc.mentions = append(mc.mentions, userID)
+		}
+	})
+}
+
 func (mc *MessageContent) GetLinks() []string {
 	return mc.links
 }
@@ -74,3 +124,7 @@ func (mc *MessageContent) GetIsFormatted() bool {
 func (mc *MessageContent) setIsFormatted(formatted bool) {
 	mc.formatted = formatted
 }
+
+func (mc *MessageContent) GetRichText() RichText {
+	return mc.richText
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ForwardMessage shares a message of the source channel into this channel. The user must be a member of both.
+// The comment may be empty.
+func (c *Channel) ForwardMessage(senderUserID uuid.UUID, sourceChannel *Channel, sourceMessageID uuid.UUID, comment MessageContent) (*Message, error) {
+	if sourceChannel.ID == c.ID {
+		return nil, ErrForwardToSameChannel
+	}
+
+	reference, err := newMessageReference(MessageReferenceForward, senderUserID, sourceChannel, sourceMessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := c.postWithReference(senderUserID, comment, nil, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	c.addEvent(CreateMessageForwardedEvent(c, message))
+	return message, nil
+}
+
+// QuoteMessage posts a reply that quotes a message of the source channel, which may be this channel
+func (c *Channel) QuoteMessage(senderUserID uuid.UUID, sourceChannel *Channel, sourceMessageID uuid.UUID, content MessageContent, parentMessageID *uuid.UUID) (*Message, error) {
+	if content.GetText() == "" {
+		return nil, ErrEmptyQuote
+	}
+
+	reference, err := newMessageReference(MessageReferenceQuote, senderUserID, sourceChannel, sourceMessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.postWithReference(senderUserID, content, parentMessageID, reference)
+}
+
+// postWithReference posts a message and attaches the reference to it and to the channel's copy
+func (c *Channel) postWithReference(senderUserID uuid.UUID, content MessageContent, parentMessageID *uuid.UUID, reference MessageReference) (*Message, error) {
+	message, err := c.PostMessage(senderUserID, content, parentMessageID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	message.reference = &reference
+	if stored := c.findMessage(message.GetId()); stored != nil {
+		stored.reference = &reference
+	}
+	return message, nil
+}
+
+// newMessageReference checks that the user can read the source message
+func newMessageReference(kind MessageReferenceKind, userID uuid.UUID, sourceChannel *Channel, sourceMessageID uuid.UUID) (MessageReference, error) {
+	if !sourceChannel.IsMember(userID) {
+		return MessageReference{}, ErrSourceAccessDenied
+	}
+
+	source := sourceChannel.findMessage(sourceMessageID)
+	if source == nil {
+		return MessageReference{}, errors.New("source message not found")
+	}
+	if source.IsDeleted() {
+		return MessageReference{}, ErrSourceMessageUnavailable
+	}
+
+	return MessageReference{
+		kind:            kind,
+		sourceMessageId: source.GetId(),
+		sourceChannelId: sourceChannel.ID,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	DefaultMessagePageSize = 50
+	MaxMessagePageSize     = 100
+)
+
+// MessageQuery selects a window of channel messages relative to a cursor message.
+// At most one of Before, After and Around is set; with none set the newest messages are returned.
+type MessageQuery struct {
+	Limit  int
+	Before *uuid.UUID
+	After  *uuid.UUID
+	Around *uuid.UUID
+}
+
+// MessagePage is a window of messages in chronological order.
+// PrevCursor is passed as Before to load older messages and NextCursor as After to load newer ones;
+// a nil cursor means there is nothing more in that direction.
+type MessagePage struct {
+	Messages   []Message
+	NextCursor *uuid.UUID
+	PrevCursor *uuid.UUID
+}
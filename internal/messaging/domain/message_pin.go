@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxPinnedMessages is the pin limit used when none is configured
+const DefaultMaxPinnedMessages = 50
+
+var ErrPinLimitReached = errors.New("channel has reached its pinned message limit")
+
+// PinMessage pins a message to the channel.
+// pinnedCount is the number of messages already pinned in the channel and maxPins the configured limit.
+func (c *Channel) PinMessage(messageID, userID uuid.UUID, pinnedCount, maxPins int) (*Message, error) {
+	if err := c.Authorize(userID, PermissionPinMessages); err != nil {
+		return nil, err
+	}
+
+	targetMessage := c.findMessage(messageID)
+	if targetMessage == nil {
+		return nil, errors.New("message not found")
+	}
+	if targetMessage.IsDeleted() {
+		return nil, errors.New("deleted messages cannot be pinned")
+	}
+	if targetMessage.IsPinned() {
+		return nil, errors.New("message is already pinned")
+	}
+	if pinnedCount >= maxPins {
+		return nil, ErrPinLimitReached
+	}
+
+	targetMessage.setPinned(userID, time.Now().UTC())
+	c.Version++
+
+	c.addEvent(CreatePinAddedEvent(c, targetMessage))
+	return targetMessage, nil
+}
+
+// UnpinMessage removes a message from the channel's pins
+func (c *Channel) UnpinMessage(messageID, userID uuid.UUID) (*Message, error) {
+	if err := c.Authorize(userID, PermissionPinMessages); err != nil {
+		return nil, err
+	}
+
+	targetMessage := c.findMessage(messageID)
+	if targetMessage == nil {
+		return nil, errors.New("message not found")
+	}
+	if !targetMessage.IsPinned() {
+		return nil, errors.New("message is not pinned")
+	}
+
+	targetMessage.clearPin()
+	c.Version++
+
+	c.addEvent(CreatePinRemovedEvent(c, messageID, userID))
+	return targetMessage, nil
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostPoll posts a poll to the channel as a message whose text is the question
+func (c *Channel) PostPoll(senderUserID uuid.UUID, poll *Poll, parentMessageID *uuid.UUID) (*Message, error) {
+	message, err := c.PostMessage(senderUserID, NewMessageContent(poll.GetQuestion()), parentMessageID, nil)
+	if err != nil {
+		return nil, err
+	}
+	// The channel keeps its own copy of the posted message
+	message.poll = poll
+	if stored := c.findMessage(message.GetId()); stored != nil {
+		stored.poll = poll
+	}
+
+	c.addEvent(CreatePollCreatedEvent(c, message))
+	return message, nil
+}
+
+// VotePoll replaces the user's votes in a poll with the given options
+func (c *Channel) VotePoll(messageID, userID uuid.UUID, optionIDs []uuid.UUID) (*Message, error) {
+	targetMessage, err := c.findPollForMember(messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := targetMessage.poll.vote(userID, optionIDs, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	c.Version++
+
+	c.addEvent(CreatePollVotedEvent(c, targetMessage, userID))
+	return targetMessage, nil
+}
+
+// RetractPollVote removes the user's votes from a poll
+func (c *Channel) RetractPollVote(messageID, userID uuid.UUID) (*Message, error) {
+	targetMessage, err := c.findPollForMember(messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := targetMessage.poll.retractVote(userID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	c.Version++
+
+	c.addEvent(CreatePollVoteRetractedEvent(c, targetMessage, userID))
+	return targetMessage, nil
+}
+
+// ClosePoll stops a poll from taking votes. The creator of the poll and members who can delete any message may close it.
+func (c *Channel) ClosePoll(messageID, userID uuid.UUID) (*Message, error) {
+	targetMessage, err := c.findPollForMember(messageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := targetMessage.GetSenderUserId()
+	isCreator := sender != nil && *sender == userID
+	if !isCreator && c.Authorize(userID, PermissionDeleteAnyMessage) != nil {
+		return nil, ErrPollCloseForbidden
+	}
+
+	if err := targetMessage.poll.close(&userID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	c.Version++
+
+	c.addEvent(CreatePollClosedEvent(c, targetMessage))
+	return targetMessage, nil
+}
+
+// ClosePollAtDeadline closes a poll whose closing time has passed
+func (c *Channel) ClosePollAtDeadline(messageID uuid.UUID, now time.Time) (*Message, error) {
+	targetMessage := c.findMessage(messageID)
+	if targetMessage == nil {
+		return nil, errors.New("message not found")
+	}
+	poll := targetMessage.GetPoll()
+	if poll == nil {
+		return nil, ErrNotAPoll
+	}
+	if poll.GetClosesAt() == nil || now.Before(*poll.GetClosesAt()) {
+		return nil, errors.New("poll has not reached its closing time")
+	}
+
+	if err := poll.close(nil, *poll.GetClosesAt()); err != nil {
+		return nil, err
+	}
+	c.Version++
+
+	c.addEvent(CreatePollClosedEvent(c, targetMessage))
+	return targetMessage, nil
+}
+
+// findPollForMember returns a loaded poll message that the user can take part in
+func (c *Channel) findPollForMember(messageID, userID uuid.UUID) (*Message, error) {
+	if !c.canUserPostMessage(userID) {
+		return nil, errors.New("user is not a member of this channel")
+	}
+
+	targetMessage := c.findMessage(messageID)
+	if targetMessage == nil {
+		return nil, errors.New("message not found")
+	}
+	if targetMessage.IsDeleted() {
+		return nil, errors.New("message has been deleted")
+	}
+	if targetMessage.GetPoll() == nil {
+		return nil, ErrNotAPoll
+	}
+	return targetMessage, nil
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type MessageReferenceKind string
+
+const (
+	// MessageReferenceForward is a message shared into another channel, with an optional comment
+	MessageReferenceForward MessageReferenceKind = "forward"
+	// MessageReferenceQuote is a message quoted in a reply, in the same channel or another one
+	MessageReferenceQuote MessageReferenceKind = "quote"
+)
+
+var (
+	ErrSourceMessageUnavailable = errors.New("source message is not available")
+	ErrSourceAccessDenied       = errors.New("user cannot access the channel of the source message")
+	ErrEmptyQuote               = errors.New("a quote needs a reply")
+	ErrForwardToSameChannel     = errors.New("messages are forwarded to other channels; quote the message to reply to it")
+)
+
+// MessageReference points a forwarded or quoting message at the message it shares.
+// The source is looked up when the message is read, so edits and deletions of the source show in its preview.
+type MessageReference struct {
+	kind            MessageReferenceKind
+	sourceMessageId uuid.UUID
+	sourceChannelId uuid.UUID
+}
+
+// For external usage
+func RehydrateMessageReference(kind MessageReferenceKind, sourceMessageId, sourceChannelId uuid.UUID) MessageReference {
+	return MessageReference{
+		kind:            kind,
+		sourceMessageId: sourceMessageId,
+		sourceChannelId: sourceChannelId,
+	}
+}
+
+func (r *MessageReference) GetKind() MessageReferenceKind {
+	return r.kind
+}
+
+func (r *MessageReference) GetSourceMessageId() uuid.UUID {
+	return r.sourceMessageId
+}
+
+func (r *MessageReference) GetSourceChannelId() uuid.UUID {
+	return r.sourceChannelId
+}
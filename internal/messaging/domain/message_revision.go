@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MessageRevision is a snapshot of a message's content before it was edited
+type MessageRevision struct {
+	id              uuid.UUID
+	messageId       uuid.UUID
+	previousContent MessageContent
+	editedBy        uuid.UUID
+	editedAt        time.Time
+}
+
+func newMessageRevision(id, messageId uuid.UUID, previousContent MessageContent, editedBy uuid.UUID, editedAt time.Time) MessageRevision {
+	return MessageRevision{
+		id:              id,
+		messageId:       messageId,
+		previousContent: previousContent,
+		editedBy:        editedBy,
+		editedAt:        editedAt,
+	}
+}
+
+func RehydrateMessageRevision(id, messageId uuid.UUID, previousContent MessageContent, editedBy uuid.UUID, editedAt time.Time) MessageRevision {
+	return MessageRevision{
+		id:              id,
+		messageId:       messageId,
+		previousContent: previousContent,
+		editedBy:        editedBy,
+		editedAt:        editedAt,
+	}
+}
+
+func (r *MessageRevision) GetId() uuid.UUID {
+	return r.id
+}
+
+func (r *MessageRevision) GetMessageId() uuid.UUID {
+	return r.messageId
+}
+
+func (r *MessageRevision) GetPreviousContent() *MessageContent {
+	return &r.previousContent
+}
+
+func (r *MessageRevision) GetEditedBy() uuid.UUID {
+	return r.editedBy
+}
+
+func (r *MessageRevision) GetEditedAt() time.Time {
+	return r.editedAt
+}
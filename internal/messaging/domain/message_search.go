@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultSearchPageSize = 20
+	MaxSearchPageSize     = 50
+	searchDateLayout      = "2006-01-02"
+)
+
+var ErrEmptySearch = errors.New("search needs at least one search term or filter")
+
+// MessageSearchQuery is a parsed search request.
+// Terms is the free text matched against the full-text index; the other fields narrow the results.
+type MessageSearchQuery struct {
+	Terms         string
+	FromUsernames []string
+	FromUserIDs   []uuid.UUID
+	ChannelNames  []string
+	HasLink       bool
+	IsThread      bool
+	Before        *time.Time
+	After         *time.Time
+	Limit         int
+	Cursor        *MessageSearchCursor
+}
+
+// ParseMessageSearchQuery splits a raw search string into free text and operators.
+// Supported operators are from:@user, in:#channel, has:link, is:thread, before:YYYY-MM-DD and after:YYYY-MM-DD;
+// any other token is kept as search text.
+func ParseMessageSearchQuery(raw string) (MessageSearchQuery, error) {
+	var query MessageSearchQuery
+	var terms []string
+
+	for _, token := range strings.Fields(raw) {
+		operator, value, found := strings.Cut(token, ":")
+		if !found || value == "" {
+			terms = append(terms, token)
+			continue
+		}
+
+		switch strings.ToLower(operator) {
+		case "from":
+			query.FromUsernames = append(query.FromUsernames, strings.TrimPrefix(value, "@"))
+		case "in":
+			query.ChannelNames = append(query.ChannelNames, strings.ToLower(strings.TrimPrefix(value, "#")))
+		case "has":
+			if strings.ToLower(value) != "link" {
+				return MessageSearchQuery{}, fmt.Errorf("unsupported filter has:%s", value)
+			}
+			query.HasLink = true
+		case "is":
+			if strings.ToLower(value) != "thread" {
+				return MessageSearchQuery{}, fmt.Errorf("unsupported filter is:%s", value)
+			}
+			query.IsThread = true
+		case "before":
+			date, err := time.Parse(searchDateLayout, value)
+			if err != nil {
+				return MessageSearchQuery{}, fmt.Errorf("invalid before date %q, expected YYYY-MM-DD", value)
+			}
+			query.Before = &date
+		case "after":
+			date, err := time.Parse(searchDateLayout, value)
+			if err != nil {
+				return MessageSearchQuery{}, fmt.Errorf("invalid after date %q, expected YYYY-MM-DD", value)
+			}
+			// after: includes nothing from the given day itself
+			nextDay := date.AddDate(0, 0, 1)
+			query.After = &nextDay
+		default:
+			terms = append(terms, token)
+		}
+	}
+
+	query.Terms = strings.Join(terms, " ")
+	if !query.HasCriteria() {
+		return MessageSearchQuery{}, ErrEmptySearch
+	}
+	return query, nil
+}
+
+// HasCriteria reports whether the query has search text or at least one filter
+func (q MessageSearchQuery) HasCriteria() bool {
+	return q.Terms != "" || len(q.FromUsernames) > 0 || len(q.ChannelNames) > 0 ||
+		q.HasLink || q.IsThread || q.Before != nil || q.After != nil
+}
+
+// MessageSearchCursor is the position of the last result of a page in rank order
+type MessageSearchCursor struct {
+	Rank      float32
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode returns the opaque string form of the cursor handed to clients
+func (c MessageSearchCursor) Encode() string {
+	raw := strings.Join([]string{
+		strconv.FormatFloat(float64(c.Rank), 'g', -1, 32),
+		c.CreatedAt.UTC().Format(time.RFC3339Nano),
+		c.ID.String(),
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseMessageSearchCursor(encoded string) (*MessageSearchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("invalid search cursor")
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid search cursor")
+	}
+
+	rank, err := strconv.ParseFloat(parts[0], 32)
+	if err != nil {
+		return nil, errors.New("invalid search cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, errors.New("invalid search cursor")
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, errors.New("invalid search cursor")
+	}
+
+	return &MessageSearchCursor{Rank: float32(rank), CreatedAt: createdAt, ID: id}, nil
+}
+
+// MessageSearchResult is a message that matched a search with its rank and a highlighted snippet
+type MessageSearchResult struct {
+	Message Message
+	Rank    float32
+	Snippet string
+}
+
+// MessageSearchPage is a page of results, best match first; NextCursor is set when more results follow
+type MessageSearchPage struct {
+	Results    []MessageSearchResult
+	NextCursor *MessageSearchCursor
+}
@@ -0,0 +1,298 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+	MaxPollQuestionLength = 300
+	MaxPollOptionLength   = 100
+	// MaxPollDuration is how far in the future the closing time of a poll can be
+	MaxPollDuration = 30 * 24 * time.Hour
+	minPollDuration = time.Minute
+)
+
+var (
+	ErrInvalidPoll        = fmt.Errorf("a poll needs a question of at most %d characters and %d to %d distinct options of at most %d characters", MaxPollQuestionLength, MinPollOptions, MaxPollOptions, MaxPollOptionLength)
+	ErrInvalidPollCloseAt = fmt.Errorf("a poll must close between one minute and %d days from now", int(MaxPollDuration.Hours()/24))
+	ErrNotAPoll           = errors.New("message is not a poll")
+	ErrPollClosed         = errors.New("poll is closed")
+	ErrInvalidPollVote    = errors.New("vote must name options of the poll, and only one unless the poll allows several")
+	ErrPollCloseForbidden = errors.New("only the creator of the poll or a member who can delete messages can close it")
+	ErrPollVoteNotFound   = errors.New("user has not voted in this poll")
+)
+
+// PollOption is one of the answers of a poll
+type PollOption struct {
+	id   uuid.UUID
+	text string
+}
+
+// For external usage
+func RehydratePollOption(id uuid.UUID, text string) PollOption {
+	return PollOption{
+		id:   id,
+		text: text,
+	}
+}
+
+func (o *PollOption) GetId() uuid.UUID {
+	return o.id
+}
+
+func (o *PollOption) GetText() string {
+	return o.text
+}
+
+// PollVote is the choice of an option by a user. Multiple choice polls have one vote per chosen option.
+type PollVote struct {
+	optionId uuid.UUID
+	userId   uuid.UUID
+	votedAt  time.Time
+}
+
+// For external usage
+func RehydratePollVote(optionId, userId uuid.UUID, votedAt time.Time) PollVote {
+	return PollVote{
+		optionId: optionId,
+		userId:   userId,
+		votedAt:  votedAt,
+	}
+}
+
+func (v *PollVote) GetOptionId() uuid.UUID {
+	return v.optionId
+}
+
+func (v *PollVote) GetUserId() uuid.UUID {
+	return v.userId
+}
+
+func (v *PollVote) GetVotedAt() time.Time {
+	return v.votedAt
+}
+
+// PollTally is the number of votes for one option, with the voters unless the poll is anonymous
+type PollTally struct {
+	Option PollOption
+	Count  int
+	Voters []uuid.UUID
+}
+
+// Poll is a question posted as a message that channel members vote on. The question is also the text of the
+// message, so polls show up in search and in clients that do not know about polls.
+type Poll struct {
+	question       string
+	options        []PollOption
+	multipleChoice bool
+	anonymous      bool
+	closesAt       *time.Time
+	closedAt       *time.Time
+	closedBy       *uuid.UUID // nil when the poll closed at its closing time
+	votes          []PollVote
+}
+
+// NewPoll validates a poll before it is posted. closesAt is optional.
+func NewPoll(question string, options []string, multipleChoice, anonymous bool, closesAt *time.Time) (*Poll, error) {
+	question = strings.TrimSpace(question)
+	if question == "" || len([]rune(question)) > MaxPollQuestionLength {
+		return nil, ErrInvalidPoll
+	}
+	if len(options) < MinPollOptions || len(options) > MaxPollOptions {
+		return nil, ErrInvalidPoll
+	}
+
+	pollOptions := make([]PollOption, 0, len(options))
+	seen := make(map[string]bool)
+	for _, text := range options {
+		text = strings.TrimSpace(text)
+		key := strings.ToLower(text)
+		if text == "" || len([]rune(text)) > MaxPollOptionLength || seen[key] {
+			return nil, ErrInvalidPoll
+		}
+		seen[key] = true
+
+		optionID, err := uuid.NewV7()
+		if err != nil {
+			return nil, err
+		}
+		pollOptions = append(pollOptions, PollOption{id: optionID, text: text})
+	}
+
+	if closesAt != nil {
+		now := time.Now().UTC()
+		if closesAt.Before(now.Add(minPollDuration)) || closesAt.After(now.Add(MaxPollDuration)) {
+			return nil, ErrInvalidPollCloseAt
+		}
+		utc := closesAt.UTC()
+		closesAt = &utc
+	}
+
+	return &Poll{
+		question:       question,
+		options:        pollOptions,
+		multipleChoice: multipleChoice,
+		anonymous:      anonymous,
+		closesAt:       closesAt,
+		votes:          []PollVote{},
+	}, nil
+}
+
+// For external usage
+func RehydratePoll(question string, options []PollOption, multipleChoice, anonymous bool, closesAt, closedAt *time.Time, closedBy *uuid.UUID, votes []PollVote) Poll {
+	return Poll{
+		question:       question,
+		options:        options,
+		multipleChoice: multipleChoice,
+		anonymous:      anonymous,
+		closesAt:       closesAt,
+		closedAt:       closedAt,
+		closedBy:       closedBy,
+		votes:          votes,
+	}
+}
+
+func (p *Poll) GetQuestion() string {
+	return p.question
+}
+
+func (p *Poll) GetOptions() []PollOption {
+	return p.options
+}
+
+func (p *Poll) IsMultipleChoice() bool {
+	return p.multipleChoice
+}
+
+// IsAnonymous reports whether the voters are hidden. Votes are still stored per user, so each user votes once.
+func (p *Poll) IsAnonymous() bool {
+	return p.anonymous
+}
+
+func (p *Poll) GetClosesAt() *time.Time {
+	return p.closesAt
+}
+
+func (p *Poll) GetClosedAt() *time.Time {
+	return p.closedAt
+}
+
+func (p *Poll) GetClosedBy() *uuid.UUID {
+	return p.closedBy
+}
+
+func (p *Poll) GetVotes() []PollVote {
+	return p.votes
+}
+
+// IsClosed reports whether the poll no longer takes votes, either because it was closed or its closing time passed
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.closedAt != nil || (p.closesAt != nil && !now.Before(*p.closesAt))
+}
+
+// GetUserVotes returns the options the user voted for
+func (p *Poll) GetUserVotes(userID uuid.UUID) []uuid.UUID {
+	optionIDs := []uuid.UUID{}
+	for _, vote := range p.votes {
+		if vote.userId == userID {
+			optionIDs = append(optionIDs, vote.optionId)
+		}
+	}
+	return optionIDs
+}
+
+// GetVoterCount returns the number of users who voted
+func (p *Poll) GetVoterCount() int {
+	voters := make(map[uuid.UUID]bool)
+	for _, vote := range p.votes {
+		voters[vote.userId] = true
+	}
+	return len(voters)
+}
+
+// GetTallies returns the votes of every option, in the order of the options
+func (p *Poll) GetTallies() []PollTally {
+	tallies := make([]PollTally, len(p.options))
+	indexByOption := make(map[uuid.UUID]int, len(p.options))
+	for i, option := range p.options {
+		tallies[i] = PollTally{Option: option, Voters: []uuid.UUID{}}
+		indexByOption[option.id] = i
+	}
+	for _, vote := range p.votes {
+		if i, ok := indexByOption[vote.optionId]; ok {
+			tallies[i].Count++
+			tallies[i].Voters = append(tallies[i].Voters, vote.userId)
+		}
+	}
+	return tallies
+}
+
+// vote replaces the user's votes with the given options
+func (p *Poll) vote(userID uuid.UUID, optionIDs []uuid.UUID, now time.Time) error {
+	if p.IsClosed(now) {
+		return ErrPollClosed
+	}
+	if len(optionIDs) == 0 || (!p.multipleChoice && len(optionIDs) > 1) {
+		return ErrInvalidPollVote
+	}
+
+	valid := make(map[uuid.UUID]bool, len(p.options))
+	for _, option := range p.options {
+		valid[option.id] = true
+	}
+	chosen := make(map[uuid.UUID]bool, len(optionIDs))
+	for _, optionID := range optionIDs {
+		if !valid[optionID] || chosen[optionID] {
+			return ErrInvalidPollVote
+		}
+		chosen[optionID] = true
+	}
+
+	votes := []PollVote{}
+	for _, vote := range p.votes {
+		if vote.userId != userID {
+			votes = append(votes, vote)
+		}
+	}
+	for _, optionID := range optionIDs {
+		votes = append(votes, PollVote{optionId: optionID, userId: userID, votedAt: now})
+	}
+	p.votes = votes
+	return nil
+}
+
+// retractVote removes all votes of the user
+func (p *Poll) retractVote(userID uuid.UUID, now time.Time) error {
+	if p.IsClosed(now) {
+		return ErrPollClosed
+	}
+
+	votes := []PollVote{}
+	for _, vote := range p.votes {
+		if vote.userId != userID {
+			votes = append(votes, vote)
+		}
+	}
+	if len(votes) == len(p.votes) {
+		return ErrPollVoteNotFound
+	}
+	p.votes = votes
+	return nil
+}
+
+// close stops the poll from taking votes. closedBy is nil when the poll closes at its closing time.
+func (p *Poll) close(closedBy *uuid.UUID, now time.Time) error {
+	if p.closedAt != nil {
+		return ErrPollClosed
+	}
+	p.closedAt = &now
+	p.closedBy = closedBy
+	return nil
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreatePollCommand struct {
+	ChannelID       uuid.UUID
+	SenderUserID    uuid.UUID
+	Question        string
+	Options         []string
+	MultipleChoice  bool
+	Anonymous       bool
+	ClosesAt        *time.Time
+	ParentMessageID *uuid.UUID
+}
+
+func (c CreatePollCommand) CommandName() string {
+	return "CreatePoll"
+}
+
+type VotePollCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+	OptionIDs []uuid.UUID
+}
+
+func (c VotePollCommand) CommandName() string {
+	return "VotePoll"
+}
+
+type RetractPollVoteCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c RetractPollVoteCommand) CommandName() string {
+	return "RetractPollVote"
+}
+
+type ClosePollCommand struct {
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (c ClosePollCommand) CommandName() string {
+	return "ClosePoll"
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxReminderAhead is how far in the future a reminder can be set
+	MaxReminderAhead = 365 * 24 * time.Hour
+	// MaxPendingReminders is how many reminders a user can have waiting at once
+	MaxPendingReminders = 200
+)
+
+var ErrReminderLimit = fmt.Errorf("cannot have more than %d pending reminders", MaxPendingReminders)
+
+type ReminderStatus string
+
+const (
+	ReminderPending ReminderStatus = "pending"
+	ReminderFired   ReminderStatus = "fired"
+)
+
+// Reminder brings a message back to a user's attention at a given time.
+// A fired reminder is kept as the user's notification until they snooze or delete it.
+type Reminder struct {
+	id        uuid.UUID
+	userId    uuid.UUID
+	channelId uuid.UUID
+	messageId uuid.UUID
+	remindAt  time.Time
+	firedAt   *time.Time
+	createdAt time.Time
+	message   *Message
+}
+
+// NewReminder sets a reminder about a message for a member of the channel it was posted in
+func NewReminder(userID uuid.UUID, channel *Channel, message *Message, remindAt time.Time) (*Reminder, error) {
+	if channel.findMember(userID) == nil {
+		return nil, errors.New("user is not a member of the channel")
+	}
+	if message.GetChannelId() != channel.ID {
+		return nil, errors.New("message does not belong to this channel")
+	}
+	if message.IsDeleted() {
+		return nil, errors.New("cannot set a reminder on a deleted message")
+	}
+
+	now := time.Now().UTC()
+	if err := validateRemindAt(remindAt, now); err != nil {
+		return nil, err
+	}
+
+	return &Reminder{
+		id:        uuid.New(),
+		userId:    userID,
+		channelId: channel.ID,
+		messageId: message.GetId(),
+		remindAt:  remindAt.UTC(),
+		createdAt: now,
+		message:   message,
+	}, nil
+}
+
+// For external usage
+func RehydrateReminder(id, userId, channelId, messageId uuid.UUID, remindAt time.Time, firedAt *time.Time, createdAt time.Time) Reminder {
+	return Reminder{
+		id:        id,
+		userId:    userId,
+		channelId: channelId,
+		messageId: messageId,
+		remindAt:  remindAt,
+		firedAt:   firedAt,
+		createdAt: createdAt,
+	}
+}
+
+func validateRemindAt(remindAt, now time.Time) error {
+	if !remindAt.After(now) {
+		return errors.New("reminder time must be in the future")
+	}
+	if remindAt.After(now.Add(MaxReminderAhead)) {
+		return fmt.Errorf("reminders cannot be set more than %d days ahead", int(MaxReminderAhead.Hours()/24))
+	}
+	return nil
+}
+
+// Snooze moves the reminder to a later time; a fired reminder becomes pending again
+func (r *Reminder) Snooze(remindAt time.Time) error {
+	if err := validateRemindAt(remindAt, time.Now().UTC()); err != nil {
+		return err
+	}
+	r.remindAt = remindAt.UTC()
+	r.firedAt = nil
+	return nil
+}
+
+func (r *Reminder) GetId() uuid.UUID {
+	return r.id
+}
+
+func (r *Reminder) GetUserId() uuid.UUID {
+	return r.userId
+}
+
+func (r *Reminder) GetChannelId() uuid.UUID {
+	return r.channelId
+}
+
+func (r *Reminder) GetMessageId() uuid.UUID {
+	return r.messageId
+}
+
+func (r *Reminder) GetRemindAt() time.Time {
+	return r.remindAt
+}
+
+func (r *Reminder) GetFiredAt() *time.Time {
+	return r.firedAt
+}
+
+func (r *Reminder) GetCreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *Reminder) GetStatus() ReminderStatus {
+	if r.firedAt != nil {
+		return ReminderFired
+	}
+	return ReminderPending
+}
+
+// GetMessage returns the message the reminder is about when it has been loaded
+func (r *Reminder) GetMessage() *Message {
+	return r.message
+}
+
+func (r *Reminder) SetLoadedMessage(message *Message) {
+	r.message = message
+}
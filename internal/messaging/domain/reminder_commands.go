@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SetReminderCommand struct {
+	UserID    uuid.UUID
+	ChannelID uuid.UUID
+	MessageID uuid.UUID
+	RemindAt  time.Time
+}
+
+func (c SetReminderCommand) CommandName() string {
+	return "SetReminder"
+}
+
+type SnoozeReminderCommand struct {
+	UserID     uuid.UUID
+	ReminderID uuid.UUID
+	RemindAt   time.Time
+}
+
+func (c SnoozeReminderCommand) CommandName() string {
+	return "SnoozeReminder"
+}
+
+type DeleteReminderCommand struct {
+	UserID     uuid.UUID
+	ReminderID uuid.UUID
+}
+
+func (c DeleteReminderCommand) CommandName() string {
+	return "DeleteReminder"
+}
+
+type ListRemindersCommand struct {
+	UserID uuid.UUID
+	Status *ReminderStatus
+}
+
+func (c ListRemindersCommand) CommandName() string {
+	return "ListReminders"
+}
@@ -0,0 +1,568 @@
+package domain
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// RichTextVersion is bumped whenever the parser output changes, so stored trees from an older parser are rebuilt on load
+const RichTextVersion = 1
+
+// maxRichTextDepth limits how deeply quotes and inline styles can nest
+const maxRichTextDepth = 8
+
+type RichTextNodeType string
+
+// Block nodes
+const (
+	RichTextParagraph RichTextNodeType = "paragraph"
+	RichTextCodeBlock RichTextNodeType = "code_block"
+	RichTextQuote     RichTextNodeType = "quote"
+	RichTextList      RichTextNodeType = "list"
+	RichTextListItem  RichTextNodeType = "list_item"
+)
+
+// Inline nodes
+const (
+	RichTextText       RichTextNodeType = "text"
+	RichTextBold       RichTextNodeType = "bold"
+	RichTextItalic     RichTextNodeType = "italic"
+	RichTextCode       RichTextNodeType = "code"
+	RichTextLink       RichTextNodeType = "link"
+	RichTextMention    RichTextNodeType = "mention"
+	RichTextChannelRef RichTextNodeType = "channel_ref"
+	RichTextLineBreak  RichTextNodeType = "line_break"
+)
+
+// RichText is the parsed form of a message's Markdown. It is stored with the message and sent to clients as is,
+// so every client and export renders the same tree instead of parsing the text again.
+type RichText struct {
+	Version int            `json:"version"`
+	Blocks  []RichTextNode `json:"blocks"`
+}
+
+// RichTextNode is a single block or inline element. Only the fields relevant to its type are set.
+type RichTextNode struct {
+	Type RichTextNodeType `json:"type"`
+	// Text is the literal content of text, code and code_block nodes
+	Text string `json:"text,omitempty"`
+	// Language is the optional language of a code_block
+	Language string `json:"language,omitempty"`
+	// URL is the target of a link; only http, https and mailto links are produced
+	URL string `json:"url,omitempty"`
+	// Username and UserID identify a mention; UserID is set once the username has been resolved
+	Username string     `json:"username,omitempty"`
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	// ChannelName is the name of a referenced channel
+	ChannelName string `json:"channel_name,omitempty"`
+	// Ordered and Start describe a list
+	Ordered bool `json:"ordered,omitempty"`
+	Start   *int `json:"start,omitempty"`
+	// Children are the nested nodes of paragraphs, quotes, lists, list items, bold, italic and links
+	Children []RichTextNode `json:"children,omitempty"`
+}
+
+var (
+	unorderedListItemRegex = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	orderedListItemRegex   = regexp.MustCompile(`^\s*(\d{1,9})[.)]\s+(.*)$`)
+	codeLanguageRegex      = regexp.MustCompile(`^[\w+#.-]{1,32}$`)
+)
+
+// ParseRichText parses the supported Markdown subset: **bold**, *italic*, `code`, fenced code blocks, > quotes,
+// - and 1. lists, [links](https://...), @mentions and #channel references. A backslash escapes punctuation.
+// Anything else, including raw HTML, is kept as plain text.
+func ParseRichText(text string) RichText {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	blocks := []RichTextNode{}
+	if strings.TrimSpace(text) != "" {
+		blocks = parseRichTextBlocks(strings.Split(text, "\n"), 0)
+	}
+	return RichText{Version: RichTextVersion, Blocks: blocks}
+}
+
+// IsPlain reports whether the text is only paragraphs of text, without formatting, mentions or links
+func (rt RichText) IsPlain() bool {
+	for _, block := range rt.Blocks {
+		if block.Type != RichTextParagraph {
+			return false
+		}
+		for _, node := range block.Children {
+			if node.Type != RichTextText && node.Type != RichTextLineBreak {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Walk calls fn for every node in the tree, parents before their children
+func (rt RichText) Walk(fn func(node *RichTextNode)) {
+	for i := range rt.Blocks {
+		walkRichTextNode(&rt.Blocks[i], fn)
+	}
+}
+
+func walkRichTextNode(node *RichTextNode, fn func(node *RichTextNode)) {
+	fn(node)
+	for i := range node.Children {
+		walkRichTextNode(&node.Children[i], fn)
+	}
+}
+
+func parseRichTextBlocks(lines []string, depth int) []RichTextNode {
+	blocks := []RichTextNode{}
+	var paragraph []string
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			blocks = append(blocks, newRichTextParagraph(paragraph))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			i++
+		case isCodeFence(trimmed):
+			flushParagraph()
+			language := strings.TrimSpace(trimmed[3:])
+			if !codeLanguageRegex.MatchString(language) {
+				language = ""
+			}
+			body := []string{}
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "```"; i++ {
+				body = append(body, lines[i])
+			}
+			// An unclosed fence runs to the end of the message
+			i++
+			blocks = append(blocks, RichTextNode{Type: RichTextCodeBlock, Language: language, Text: strings.Join(body, "\n")})
+		case strings.HasPrefix(trimmed, ">") && depth < maxRichTextDepth:
+			flushParagraph()
+			quoted := []string{}
+			for ; i < len(lines); i++ {
+				line := strings.TrimLeftFunc(lines[i], unicode.IsSpace)
+				if !strings.HasPrefix(line, ">") {
+					break
+				}
+				line = strings.TrimPrefix(line, ">")
+				quoted = append(quoted, strings.TrimPrefix(line, " "))
+			}
+			blocks = append(blocks, RichTextNode{Type: RichTextQuote, Children: parseRichTextBlocks(quoted, depth+1)})
+		case isListItem(lines[i]):
+			flushParagraph()
+			var list RichTextNode
+			list, i = parseRichTextList(lines, i)
+			blocks = append(blocks, list)
+		default:
+			paragraph = append(paragraph, lines[i])
+			i++
+		}
+	}
+	flushParagraph()
+	return blocks
+}
+
+// isCodeFence matches an opening ``` line; ```code``` on a single line is inline code instead
+func isCodeFence(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "```") && !strings.Contains(trimmed[3:], "`")
+}
+
+func isListItem(line string) bool {
+	return unorderedListItemRegex.MatchString(line) || orderedListItemRegex.MatchString(line)
+}
+
+// parseRichTextList collects the consecutive items of one list, starting at lines[start], and returns the index after it
+func parseRichTextList(lines []string, start int) (RichTextNode, int) {
+	list := RichTextNode{Type: RichTextList, Children: []RichTextNode{}}
+	if matches := orderedListItemRegex.FindStringSubmatch(lines[start]); matches != nil {
+		number, _ := strconv.Atoi(matches[1])
+		list.Ordered = true
+		list.Start = &number
+	}
+
+	i := start
+	for ; i < len(lines); i++ {
+		var matches []string
+		if list.Ordered {
+			if matches = orderedListItemRegex.FindStringSubmatch(lines[i]); matches != nil {
+				matches = matches[1:]
+			}
+		} else {
+			matches = unorderedListItemRegex.FindStringSubmatch(lines[i])
+		}
+		if matches == nil {
+			break
+		}
+		list.Children = append(list.Children, RichTextNode{Type: RichTextListItem, Children: parseRichTextInline(matches[1])})
+	}
+	return list, i
+}
+
+// newRichTextParagraph keeps the message's line breaks, since chat messages are not reflowed
+func newRichTextParagraph(lines []string) RichTextNode {
+	children := []RichTextNode{}
+	for i, line := range lines {
+		if i > 0 {
+			children = append(children, RichTextNode{Type: RichTextLineBreak})
+		}
+		children = append(children, parseRichTextInline(line)...)
+	}
+	return RichTextNode{Type: RichTextParagraph, Children: children}
+}
+
+func parseRichTextInline(text string) []RichTextNode {
+	p := &inlineParser{src: text, unmatched: make(map[unmatchedDelimiter]int)}
+	return p.parse(0, len(text), 0)
+}
+
+type unmatchedDelimiter struct {
+	delimiter string
+	end       int
+}
+
+type inlineParser struct {
+	src    string
+	inLink bool
+	// unmatched remembers, per delimiter and span end, the earliest position from which no closing delimiter exists,
+	// so text full of unmatched delimiters is still parsed in linear time
+	unmatched map[unmatchedDelimiter]int
+}
+
+// parse turns src[start:end] into inline nodes, merging adjacent text
+func (p *inlineParser) parse(start, end, depth int) []RichTextNode {
+	nodes := []RichTextNode{}
+	var text strings.Builder
+	emit := func(node RichTextNode) {
+		if text.Len() > 0 {
+			nodes = append(nodes, RichTextNode{Type: RichTextText, Text: text.String()})
+			text.Reset()
+		}
+		nodes = append(nodes, node)
+	}
+
+	for i := start; i < end; {
+		c := p.src[i]
+		switch {
+		case c == '\\' && i+1 < end && isASCIIPunctuation(p.src[i+1]):
+			text.WriteByte(p.src[i+1])
+			i += 2
+			continue
+		case c == '`':
+			if node, next, ok := p.parseCodeSpan(i, end); ok {
+				emit(node)
+				i = next
+				continue
+			}
+		case (c == '*' || c == '_') && depth < maxRichTextDepth:
+			if node, next, ok := p.parseEmphasis(i, end, depth); ok {
+				emit(node)
+				i = next
+				continue
+			}
+		case c == '[' && !p.inLink && depth < maxRichTextDepth:
+			if node, next, ok := p.parseLink(i, end, depth); ok {
+				emit(node)
+				i = next
+				continue
+			}
+		case c == '@' && p.atWordStart(i):
+			if name := p.readName(i+1, end); name != "" {
+				emit(RichTextNode{Type: RichTextMention, Username: name})
+				i += 1 + len(name)
+				continue
+			}
+		case c == '#' && p.atWordStart(i):
+			if name := p.readName(i+1, end); name != "" {
+				emit(RichTextNode{Type: RichTextChannelRef, ChannelName: name})
+				i += 1 + len(name)
+				continue
+			}
+		case c == 'h' && !p.inLink && p.atWordStart(i):
+			if node, next, ok := p.parseAutolink(i, end); ok {
+				emit(node)
+				i = next
+				continue
+			}
+		}
+
+		// Not the start of any element: a run of delimiters is kept as written so it is not retried character by character
+		next := i + 1
+		if c == '`' || c == '*' || c == '_' {
+			next = p.runEnd(i, end)
+		}
+		text.WriteString(p.src[i:next])
+		i = next
+	}
+
+	if text.Len() > 0 {
+		nodes = append(nodes, RichTextNode{Type: RichTextText, Text: text.String()})
+	}
+	return nodes
+}
+
+// parseCodeSpan matches a run of backticks with a closing run of the same length; the content is literal
+func (p *inlineParser) parseCodeSpan(i, end int) (RichTextNode, int, bool) {
+	openEnd := p.runEnd(i, end)
+	delimiter := p.src[i:openEnd]
+	key := unmatchedDelimiter{delimiter: delimiter, end: end}
+	if from, ok := p.unmatched[key]; ok && i >= from {
+		return RichTextNode{}, 0, false
+	}
+
+	for j := openEnd; j < end; {
+		if p.src[j] != '`' {
+			j++
+			continue
+		}
+		closeEnd := p.runEnd(j, end)
+		if closeEnd-j == len(delimiter) {
+			code := p.src[openEnd:j]
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			return RichTextNode{Type: RichTextCode, Text: code}, closeEnd, true
+		}
+		j = closeEnd
+	}
+
+	p.unmatched[key] = i
+	return RichTextNode{}, 0, false
+}
+
+// parseEmphasis matches **bold**/__bold__ or *italic*/_italic_. The opening delimiter must be followed by a non-space,
+// the closing one preceded by a non-space, and underscores inside words (snake_case) are not emphasis.
+func (p *inlineParser) parseEmphasis(i, end, depth int) (RichTextNode, int, bool) {
+	c := p.src[i]
+	if c == '_' && isWordRune(p.runeBefore(i)) {
+		return RichTextNode{}, 0, false
+	}
+
+	run := p.runEnd(i, end) - i
+	for _, size := range []int{2, 1} {
+		if run < size {
+			continue
+		}
+		contentStart := i + size
+		if contentStart >= end || isSpaceByte(p.src[contentStart]) {
+			continue
+		}
+		closeAt, ok := p.findEmphasisClose(c, size, contentStart, end)
+		if !ok {
+			continue
+		}
+
+		nodeType := RichTextItalic
+		if size == 2 {
+			nodeType = RichTextBold
+		}
+		children := p.parse(contentStart, closeAt, depth+1)
+		return RichTextNode{Type: nodeType, Children: children}, closeAt + size, true
+	}
+	return RichTextNode{}, 0, false
+}
+
+// findEmphasisClose returns where the closing delimiter of the given size starts. A longer closing run closes
+// with its last characters, so ***both*** is bold around italic.
+func (p *inlineParser) findEmphasisClose(c byte, size, contentStart, end int) (int, bool) {
+	key := unmatchedDelimiter{delimiter: strings.Repeat(string(c), size), end: end}
+	if from, ok := p.unmatched[key]; ok && contentStart >= from {
+		return 0, false
+	}
+
+	for j := contentStart + 1; j < end; {
+		switch p.src[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			if _, next, ok := p.parseCodeSpan(j, end); ok {
+				j = next
+				continue
+			}
+		case c:
+			runEnd := p.runEnd(j, end)
+			closes := runEnd-j >= size && !isSpaceByte(p.src[j-1])
+			if c == '_' && runEnd < end && isWordRune(p.runeAt(runEnd)) {
+				closes = false
+			}
+			if closes {
+				return runEnd - size, true
+			}
+			j = runEnd
+			continue
+		}
+		j++
+	}
+
+	p.unmatched[key] = contentStart
+	return 0, false
+}
+
+// parseLink matches [label](url). Links with other schemes than http, https and mailto are left as text.
+func (p *inlineParser) parseLink(i, end, depth int) (RichTextNode, int, bool) {
+	key := unmatchedDelimiter{delimiter: "]", end: end}
+	if from, ok := p.unmatched[key]; ok && i >= from {
+		return RichTextNode{}, 0, false
+	}
+
+	labelEnd := -1
+	for j := i + 1; j < end; j++ {
+		if p.src[j] == '\\' {
+			j++
+			continue
+		}
+		if p.src[j] == ']' {
+			labelEnd = j
+			break
+		}
+	}
+	if labelEnd == -1 {
+		p.unmatched[key] = i
+		return RichTextNode{}, 0, false
+	}
+	if labelEnd+1 >= end || p.src[labelEnd+1] != '(' {
+		return RichTextNode{}, 0, false
+	}
+
+	// Balanced parentheses are allowed inside the URL, as in Wikipedia links
+	urlStart := labelEnd + 2
+	urlEnd := -1
+	balance := 0
+	for j := urlStart; j < end && urlEnd == -1; j++ {
+		switch p.src[j] {
+		case '(':
+			balance++
+		case ')':
+			if balance == 0 {
+				urlEnd = j
+			}
+			balance--
+		case ' ', '\t':
+			return RichTextNode{}, 0, false
+		}
+	}
+	if urlEnd == -1 {
+		return RichTextNode{}, 0, false
+	}
+	target := p.src[urlStart:urlEnd]
+	if !isSafeLinkURL(target) {
+		return RichTextNode{}, 0, false
+	}
+
+	p.inLink = true
+	children := p.parse(i+1, labelEnd, depth+1)
+	p.inLink = false
+	if len(children) == 0 {
+		children = []RichTextNode{{Type: RichTextText, Text: target}}
+	}
+	return RichTextNode{Type: RichTextLink, URL: target, Children: children}, urlEnd + 1, true
+}
+
+// parseAutolink turns a bare http(s) URL into a link, leaving trailing punctuation and unbalanced ) outside it
+func (p *inlineParser) parseAutolink(i, end int) (RichTextNode, int, bool) {
+	rest := p.src[i:end]
+	if !strings.HasPrefix(rest, "http://") && !strings.HasPrefix(rest, "https://") {
+		return RichTextNode{}, 0, false
+	}
+
+	linkEnd := i
+	for linkEnd < end {
+		r, size := utf8.DecodeRuneInString(p.src[linkEnd:end])
+		if unicode.IsSpace(r) || r == '<' || r == '>' {
+			break
+		}
+		linkEnd += size
+	}
+	for linkEnd > i {
+		last := p.src[linkEnd-1]
+		if strings.IndexByte(".,:;!?'\"*_", last) >= 0 {
+			linkEnd--
+			continue
+		}
+		if last == ')' && strings.Count(p.src[i:linkEnd], "(") < strings.Count(p.src[i:linkEnd], ")") {
+			linkEnd--
+			continue
+		}
+		break
+	}
+
+	target := p.src[i:linkEnd]
+	if !isSafeLinkURL(target) {
+		return RichTextNode{}, 0, false
+	}
+	return RichTextNode{Type: RichTextLink, URL: target, Children: []RichTextNode{{Type: RichTextText, Text: target}}}, linkEnd, true
+}
+
+// readName reads the [A-Za-z0-9_-] name after an @ or #
+func (p *inlineParser) readName(start, end int) string {
+	j := start
+	for j < end && (isASCIIWordByte(p.src[j]) || p.src[j] == '-') {
+		j++
+	}
+	return p.src[start:j]
+}
+
+// atWordStart reports whether position i is not preceded by a letter or digit, so e-mail addresses are not mentions
+func (p *inlineParser) atWordStart(i int) bool {
+	return !isWordRune(p.runeBefore(i))
+}
+
+func (p *inlineParser) runEnd(i, end int) int {
+	j := i
+	for j < end && p.src[j] == p.src[i] {
+		j++
+	}
+	return j
+}
+
+func (p *inlineParser) runeBefore(i int) rune {
+	if i == 0 {
+		return ' '
+	}
+	r, _ := utf8.DecodeLastRuneInString(p.src[:i])
+	return r
+}
+
+func (p *inlineParser) runeAt(i int) rune {
+	r, _ := utf8.DecodeRuneInString(p.src[i:])
+	return r
+}
+
+func isSafeLinkURL(target string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https":
+		return parsed.Host != ""
+	case "mailto":
+		return parsed.Opaque != ""
+	default:
+		return false
+	}
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isASCIIWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isASCIIPunctuation(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+func isSpaceByte(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxScheduleAhead is how far in the future a message can be scheduled
+	MaxScheduleAhead = 120 * 24 * time.Hour
+	// MaxPendingScheduledMessages is how many unsent scheduled messages a user can have at once
+	MaxPendingScheduledMessages = 100
+)
+
+type ScheduledMessageStatus string
+
+const (
+	// ScheduledMessagePending is waiting for its time to come
+	ScheduledMessagePending ScheduledMessageStatus = "pending"
+	// ScheduledMessageSending has been claimed by a dispatcher and is being posted
+	ScheduledMessageSending ScheduledMessageStatus = "sending"
+	// ScheduledMessageFailed could not be posted; it stays until the user reschedules or cancels it
+	ScheduledMessageFailed ScheduledMessageStatus = "failed"
+)
+
+var (
+	ErrScheduledMessageSending = errors.New("scheduled message is already being sent")
+	ErrScheduledMessageLimit   = fmt.Errorf("cannot have more than %d scheduled messages", MaxPendingScheduledMessages)
+)
+
+// ScheduledMessage is a message written now and posted to its channel at a later time.
+// Once posted it is removed; the posted message lives on as a regular message.
+type ScheduledMessage struct {
+	id              uuid.UUID
+	channelId       uuid.UUID
+	senderUserId    uuid.UUID
+	parentMessageId *uuid.UUID
+	contentText     string
+	scheduledFor    time.Time
+	status          ScheduledMessageStatus
+	failureReason   string
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+// NewScheduledMessage schedules a message for a member of the channel
+func NewScheduledMessage(channel *Channel, senderUserID uuid.UUID, contentText string, parentMessageID *uuid.UUID, scheduledFor time.Time) (*ScheduledMessage, error) {
+	if channel.findMember(senderUserID) == nil {
+		return nil, errors.New("user is not a member of the channel")
+	}
+	if channel.IsArchived {
+		return nil, errors.New("cannot schedule messages in an archived channel")
+	}
+
+	now := time.Now().UTC()
+	if err := validateScheduledMessage(contentText, scheduledFor, now); err != nil {
+		return nil, err
+	}
+
+	return &ScheduledMessage{
+		id:              uuid.New(),
+		channelId:       channel.ID,
+		senderUserId:    senderUserID,
+		parentMessageId: parentMessageID,
+		contentText:     contentText,
+		scheduledFor:    scheduledFor.UTC(),
+		status:          ScheduledMessagePending,
+		createdAt:       now,
+		updatedAt:       now,
+	}, nil
+}
+
+// For external usage
+func RehydrateScheduledMessage(id, channelId, senderUserId uuid.UUID, parentMessageId *uuid.UUID, contentText string, scheduledFor time.Time, status ScheduledMessageStatus, failureReason string, createdAt, updatedAt time.Time) ScheduledMessage {
+	return ScheduledMessage{
+		id:              id,
+		channelId:       channelId,
+		senderUserId:    senderUserId,
+		parentMessageId: parentMessageId,
+		contentText:     contentText,
+		scheduledFor:    scheduledFor,
+		status:          status,
+		failureReason:   failureReason,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+func validateScheduledMessage(contentText string, scheduledFor, now time.Time) error {
+	if strings.TrimSpace(contentText) == "" {
+		return errors.New("scheduled message cannot be empty")
+	}
+	if !scheduledFor.After(now) {
+		return errors.New("scheduled time must be in the future")
+	}
+	if scheduledFor.After(now.Add(MaxScheduleAhead)) {
+		return fmt.Errorf("messages cannot be scheduled more than %d days ahead", int(MaxScheduleAhead.Hours()/24))
+	}
+	return nil
+}
+
+// Update changes the content and time of a message that is not being sent.
+// A failed message goes back to pending, so updating it is how it gets retried.
+func (m *ScheduledMessage) Update(contentText string, scheduledFor time.Time) error {
+	if m.status == ScheduledMessageSending {
+		return ErrScheduledMessageSending
+	}
+
+	now := time.Now().UTC()
+	if err := validateScheduledMessage(contentText, scheduledFor, now); err != nil {
+		return err
+	}
+
+	m.contentText = contentText
+	m.scheduledFor = scheduledFor.UTC()
+	m.status = ScheduledMessagePending
+	m.failureReason = ""
+	m.updatedAt = now
+	return nil
+}
+
+// EnsureCancellable reports whether the message can still be cancelled
+func (m *ScheduledMessage) EnsureCancellable() error {
+	if m.status == ScheduledMessageSending {
+		return ErrScheduledMessageSending
+	}
+	return nil
+}
+
+func (m *ScheduledMessage) GetId() uuid.UUID {
+	return m.id
+}
+
+func (m *ScheduledMessage) GetChannelId() uuid.UUID {
+	return m.channelId
+}
+
+func (m *ScheduledMessage) GetSenderUserId() uuid.UUID {
+	return m.senderUserId
+}
+
+func (m *ScheduledMessage) GetParentMessageId() *uuid.UUID {
+	return m.parentMessageId
+}
+
+func (m *ScheduledMessage) GetContentText() string {
+	return m.contentText
+}
+
+func (m *ScheduledMessage) GetScheduledFor() time.Time {
+	return m.scheduledFor
+}
+
+func (m *ScheduledMessage) GetStatus() ScheduledMessageStatus {
+	return m.status
+}
+
+func (m *ScheduledMessage) GetFailureReason() string {
+	return m.failureReason
+}
+
+func (m *ScheduledMessage) GetCreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m *ScheduledMessage) GetUpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// ToSendCommand builds the command that posts the message to its channel
+func (m *ScheduledMessage) ToSendCommand() SendMessageCommand {
+	return SendMessageCommand{
+		ChannelID:       m.channelId,
+		SenderUserID:    m.senderUserId,
+		Content:         NewMessageContent(m.contentText),
+		ParentMessageID: m.parentMessageId,
+	}
+}
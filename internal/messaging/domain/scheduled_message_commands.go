@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScheduleMessageCommand struct {
+	ChannelID       uuid.UUID
+	UserID          uuid.UUID
+	ContentText     string
+	ParentMessageID *uuid.UUID
+	ScheduledFor    time.Time
+}
+
+func (c ScheduleMessageCommand) CommandName() string {
+	return "ScheduleMessage"
+}
+
+type UpdateScheduledMessageCommand struct {
+	UserID             uuid.UUID
+	ScheduledMessageID uuid.UUID
+	ContentText        string
+	ScheduledFor       time.Time
+}
+
+func (c UpdateScheduledMessageCommand) CommandName() string {
+	return "UpdateScheduledMessage"
+}
+
+type CancelScheduledMessageCommand struct {
+	UserID             uuid.UUID
+	ScheduledMessageID uuid.UUID
+}
+
+func (c CancelScheduledMessageCommand) CommandName() string {
+	return "CancelScheduledMessage"
+}
+
+type ListScheduledMessagesCommand struct {
+	UserID    uuid.UUID
+	ChannelID *uuid.UUID
+}
+
+func (c ListScheduledMessagesCommand) CommandName() string {
+	return "ListScheduledMessages"
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnknownSlashCommand = errors.New("unknown slash command")
+
+// slashCommandRegex matches "/name" optionally followed by whitespace and arguments.
+// Text such as "/path/to/file" is not a command and is posted as written.
+var slashCommandRegex = regexp.MustCompile(`(?s)^/([a-zA-Z][a-zA-Z0-9_-]{0,31})(?:\s+(.*))?$`)
+
+// ParseSlashCommand splits a message starting with "/" into a lowercased command name and its arguments.
+// A message starting with "//" is an escaped slash and is not a command; see UnescapeSlashCommand.
+func ParseSlashCommand(text string) (name, args string, ok bool) {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "//") {
+		return "", "", false
+	}
+
+	matches := slashCommandRegex.FindStringSubmatch(trimmed)
+	if matches == nil {
+		return "", "", false
+	}
+	return strings.ToLower(matches[1]), strings.TrimSpace(matches[2]), true
+}
+
+// UnescapeSlashCommand turns a leading "//" into "/", so "//shrug" posts the text "/shrug"
+func UnescapeSlashCommand(text string) string {
+	if strings.HasPrefix(strings.TrimSpace(text), "//") {
+		return strings.Replace(text, "//", "/", 1)
+	}
+	return text
+}
+
+// SlashCommandInvocation is a slash command typed by a user in a channel or thread
+type SlashCommandInvocation struct {
+	Name            string
+	Args            string
+	ChannelID       uuid.UUID
+	UserID          uuid.UUID
+	ParentMessageID *uuid.UUID
+}
+
+// SlashCommandResult describes what a command did, so the transport can answer the invoker
+// and notify the channel the same way it does for the equivalent regular request
+type SlashCommandResult struct {
+	// EphemeralText is shown only to the user who ran the command
+	EphemeralText string
+	// PostedMessage is set when the command posted a message to the channel
+	PostedMessage *Message
+	// UpdatedChannel is set when the command changed the channel's name, topic or state
+	UpdatedChannel *Channel
+	// LeftChannel is set when the command removed the invoker from the channel
+	LeftChannel bool
+}
+
+// NewEphemeralResult is a result that only answers the invoker
+func NewEphemeralResult(text string) *SlashCommandResult {
+	return &SlashCommandResult{EphemeralText: text}
+}
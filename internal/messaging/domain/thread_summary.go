@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ThreadSummary describes the replies posted under a message
+type ThreadSummary struct {
+	replyCount     int
+	lastReplyAt    *time.Time
+	participantIds []uuid.UUID
+}
+
+// For external usage
+func RehydrateThreadSummary(replyCount int, lastReplyAt *time.Time, participantIds []uuid.UUID) ThreadSummary {
+	return ThreadSummary{
+		replyCount:     replyCount,
+		lastReplyAt:    lastReplyAt,
+		participantIds: participantIds,
+	}
+}
+
+func (t ThreadSummary) GetReplyCount() int {
+	return t.replyCount
+}
+
+func (t ThreadSummary) GetLastReplyAt() *time.Time {
+	return t.lastReplyAt
+}
+
+func (t ThreadSummary) GetParticipantIds() []uuid.UUID {
+	return t.participantIds
+}
@@ -0,0 +1,8 @@
+package domain
+
+// UnreadCounts is the number of messages a member has not read in a channel,
+// and how many of those mention them
+type UnreadCounts struct {
+	Unread   int
+	Mentions int
+}
@@ -0,0 +1,345 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+)
+
+const (
+	tiffTagOrientation = 0x0112
+	tiffTagGPSInfo     = 0x8825
+	tiffEntrySize      = 12
+)
+
+var (
+	exifHeader = []byte("Exif\x00\x00")
+	xmpHeader  = []byte("http://ns.adobe.com/xap/1.0/\x00")
+	pngHeader  = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// StripLocation removes the location a photo was taken at from an image, keeping the rest of its metadata,
+// such as the orientation. GPS entries of EXIF data are blanked and XMP packets, which may repeat them,
+// are dropped. JPEG, PNG and WebP images are supported, anything else is returned unchanged.
+func StripLocation(data []byte, contentType string) []byte {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEGLocation(data)
+	case "image/png":
+		return stripPNGLocation(data)
+	case "image/webp":
+		return stripWebPLocation(data)
+	default:
+		return data
+	}
+}
+
+// readOrientation returns the EXIF orientation of an image, 1 when it has none
+func readOrientation(data []byte, contentType string) int {
+	var tiff []byte
+	switch contentType {
+	case "image/jpeg":
+		forEachJPEGSegment(data, func(marker byte, payload []byte) bool {
+			if marker == 0xE1 && bytes.HasPrefix(payload, exifHeader) {
+				tiff = payload[len(exifHeader):]
+				return false
+			}
+			return true
+		})
+	case "image/png":
+		forEachPNGChunk(data, func(kind string, payload []byte) bool {
+			if kind == "eXIf" {
+				tiff = bytes.TrimPrefix(payload, exifHeader)
+				return false
+			}
+			return true
+		})
+	case "image/webp":
+		forEachWebPChunk(data, func(kind string, payload []byte) bool {
+			if kind == "EXIF" {
+				tiff = bytes.TrimPrefix(payload, exifHeader)
+				return false
+			}
+			return true
+		})
+	}
+
+	orientation, _ := parseTIFF(tiff)
+	if orientation < 1 || orientation > 8 {
+		return 1
+	}
+	return orientation
+}
+
+func stripJPEGLocation(data []byte) []byte {
+	if len(data) < 2 || data[0] != 0xFF || data[1] != 0xD8 {
+		return data
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(data))
+	out.Write(data[:2])
+	rest := 2
+
+	complete := forEachJPEGSegment(data, func(marker byte, payload []byte) bool {
+		segmentStart := rest
+		rest += 4 + len(payload)
+		if marker == 0xE1 && bytes.HasPrefix(payload, xmpHeader) {
+			return true
+		}
+		if marker == 0xE1 && bytes.HasPrefix(payload, exifHeader) {
+			scrubTIFFLocation(payload[len(exifHeader):])
+		}
+		out.Write(data[segmentStart:rest])
+		return true
+	})
+	if !complete {
+		return data
+	}
+
+	out.Write(data[rest:])
+	return out.Bytes()
+}
+
+// forEachJPEGSegment calls fn with the marker and payload of each segment before the image data, in place.
+// It stops when fn returns false and reports whether the segments were well-formed.
+func forEachJPEGSegment(data []byte, fn func(marker byte, payload []byte) bool) bool {
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return false
+		}
+		marker := data[pos+1]
+		// Start of scan: the compressed image data follows
+		if marker == 0xDA {
+			return true
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		if length < 2 || pos+2+length > len(data) {
+			return false
+		}
+		if !fn(marker, data[pos+4:pos+2+length]) {
+			return true
+		}
+		pos += 2 + length
+	}
+	return false
+}
+
+func stripPNGLocation(data []byte) []byte {
+	if !bytes.HasPrefix(data, pngHeader) {
+		return data
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(data))
+	out.Write(pngHeader)
+
+	complete := forEachPNGChunk(data, func(kind string, payload []byte) bool {
+		if (kind == "iTXt" || kind == "tEXt" || kind == "zTXt") && bytes.HasPrefix(payload, []byte("XML:com.adobe.xmp\x00")) {
+			return true
+		}
+		if kind == "eXIf" {
+			scrubTIFFLocation(bytes.TrimPrefix(payload, exifHeader))
+		}
+
+		var header [8]byte
+		binary.BigEndian.PutUint32(header[:4], uint32(len(payload)))
+		copy(header[4:], kind)
+		out.Write(header[:])
+		out.Write(payload)
+
+		crc := crc32.NewIEEE()
+		crc.Write(header[4:])
+		crc.Write(payload)
+		var sum [4]byte
+		binary.BigEndian.PutUint32(sum[:], crc.Sum32())
+		out.Write(sum[:])
+		return true
+	})
+	if !complete {
+		return data
+	}
+	return out.Bytes()
+}
+
+// forEachPNGChunk calls fn with the type and payload of each chunk, in place.
+// It stops when fn returns false and reports whether the chunks were well-formed.
+func forEachPNGChunk(data []byte, fn func(kind string, payload []byte) bool) bool {
+	if !bytes.HasPrefix(data, pngHeader) {
+		return false
+	}
+	pos := len(pngHeader)
+	for pos+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		if length < 0 || pos+12+length > len(data) {
+			return false
+		}
+		kind := string(data[pos+4 : pos+8])
+		if !fn(kind, data[pos+8:pos+8+length]) {
+			return true
+		}
+		pos += 12 + length
+		if kind == "IEND" {
+			return true
+		}
+	}
+	return pos == len(data)
+}
+
+func stripWebPLocation(data []byte) []byte {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return data
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(data))
+	out.Write(data[:12])
+
+	complete := forEachWebPChunk(data, func(kind string, payload []byte) bool {
+		switch kind {
+		case "XMP ":
+			return true
+		case "EXIF":
+			scrubTIFFLocation(bytes.TrimPrefix(payload, exifHeader))
+		case "VP8X":
+			// The XMP flag has to go with the XMP chunk
+			if len(payload) > 0 {
+				payload[0] &^= 0x04
+			}
+		}
+
+		var header [8]byte
+		copy(header[:4], kind)
+		binary.LittleEndian.PutUint32(header[4:], uint32(len(payload)))
+		out.Write(header[:])
+		out.Write(payload)
+		if len(payload)%2 == 1 {
+			out.WriteByte(0)
+		}
+		return true
+	})
+	if !complete {
+		return data
+	}
+
+	result := out.Bytes()
+	binary.LittleEndian.PutUint32(result[4:8], uint32(len(result)-8))
+	return result
+}
+
+// forEachWebPChunk calls fn with the type and payload of each chunk, in place.
+// It stops when fn returns false and reports whether the chunks were well-formed.
+func forEachWebPChunk(data []byte, fn func(kind string, payload []byte) bool) bool {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return false
+	}
+	pos := 12
+	for pos+8 <= len(data) {
+		kind := string(data[pos : pos+4])
+		length := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		if length < 0 || pos+8+length > len(data) {
+			return false
+		}
+		if !fn(kind, data[pos+8:pos+8+length]) {
+			return true
+		}
+		pos += 8 + length + length%2
+	}
+	return pos >= len(data)
+}
+
+// parseTIFF reads the orientation and the offset of the GPS directory from EXIF data
+func parseTIFF(tiff []byte) (orientation int, gpsOffset int) {
+	order, ifd, ok := tiffHeader(tiff)
+	if !ok {
+		return 0, 0
+	}
+
+	count, ok := tiffEntryCount(tiff, order, ifd)
+	if !ok {
+		return 0, 0
+	}
+	for i := range count {
+		entry := tiff[ifd+2+i*tiffEntrySize:]
+		switch order.Uint16(entry) {
+		case tiffTagOrientation:
+			orientation = int(order.Uint16(entry[8:]))
+		case tiffTagGPSInfo:
+			gpsOffset = int(order.Uint32(entry[8:]))
+		}
+	}
+	return orientation, gpsOffset
+}
+
+// scrubTIFFLocation blanks the GPS directory of EXIF data in place, so no offsets change
+func scrubTIFFLocation(tiff []byte) {
+	order, _, ok := tiffHeader(tiff)
+	if !ok {
+		return
+	}
+	_, gpsOffset := parseTIFF(tiff)
+	if gpsOffset <= 0 {
+		return
+	}
+
+	count, ok := tiffEntryCount(tiff, order, gpsOffset)
+	if !ok {
+		return
+	}
+	for i := range count {
+		entry := tiff[gpsOffset+2+i*tiffEntrySize : gpsOffset+2+(i+1)*tiffEntrySize]
+		// Values larger than four bytes are stored elsewhere and blanked there
+		size := tiffTypeSize(order.Uint16(entry[2:])) * int(order.Uint32(entry[4:]))
+		if size > 4 {
+			offset := int(order.Uint32(entry[8:]))
+			if offset > 0 && size <= len(tiff) && offset <= len(tiff)-size {
+				clear(tiff[offset : offset+size])
+			}
+		}
+		clear(entry)
+	}
+	order.PutUint16(tiff[gpsOffset:], 0)
+}
+
+func tiffHeader(tiff []byte) (binary.ByteOrder, int, bool) {
+	if len(tiff) < 8 {
+		return nil, 0, false
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, 0, false
+	}
+	return order, int(order.Uint32(tiff[4:])), true
+}
+
+func tiffEntryCount(tiff []byte, order binary.ByteOrder, ifd int) (int, bool) {
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0, false
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	if ifd+2+count*tiffEntrySize > len(tiff) {
+		return 0, false
+	}
+	return count, true
+}
+
+func tiffTypeSize(fieldType uint16) int {
+	switch fieldType {
+	case 1, 2, 6, 7: // BYTE, ASCII, SBYTE, UNDEFINED
+		return 1
+	case 3, 8: // SHORT, SSHORT
+		return 2
+	case 4, 9, 11: // LONG, SLONG, FLOAT
+		return 4
+	case 5, 10, 12: // RATIONAL, SRATIONAL, DOUBLE
+		return 8
+	default:
+		return 0
+	}
+}
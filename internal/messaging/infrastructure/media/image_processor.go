@@ -0,0 +1,200 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	// Decoders for the image types attachments can have
+	_ "image/gif"
+
+	_ "golang.org/x/image/bmp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// MaxImagePixels bounds the size of images that are decoded, so a small file cannot claim huge dimensions
+	MaxImagePixels = 50_000_000
+	// placeholderDimension is the longest side of the copy the blurhash and dominant color are computed from
+	placeholderDimension = 32
+	thumbnailJPEGQuality = 80
+)
+
+var ErrImageTooLarge = fmt.Errorf("image has more than %d pixels", MaxImagePixels)
+
+// ThumbnailSize is a named size thumbnails are generated at
+type ThumbnailSize struct {
+	Name         string
+	MaxDimension int
+}
+
+// DefaultThumbnailSizes are the sizes clients pick from, by the longest side of the thumbnail
+var DefaultThumbnailSizes = []ThumbnailSize{
+	{Name: "small", MaxDimension: 160},
+	{Name: "medium", MaxDimension: 480},
+	{Name: "large", MaxDimension: 1024},
+}
+
+type Thumbnail struct {
+	Name        string
+	Width       int
+	Height      int
+	ContentType string
+	Data        []byte
+}
+
+// ProcessedImage is what was learned from an image. Width and Height are as displayed, after EXIF orientation.
+type ProcessedImage struct {
+	Width         int
+	Height        int
+	BlurHash      string
+	DominantColor string
+	Thumbnails    []Thumbnail
+}
+
+// ImageProcessor decodes images and generates their thumbnails and placeholders, in pure Go
+type ImageProcessor struct {
+	sizes []ThumbnailSize
+}
+
+func NewImageProcessor(sizes []ThumbnailSize) *ImageProcessor {
+	if len(sizes) == 0 {
+		sizes = DefaultThumbnailSizes
+	}
+	return &ImageProcessor{
+		sizes: sizes,
+	}
+}
+
+// Process decodes an image and returns its dimensions, placeholders and a thumbnail for each size smaller than
+// the image. Thumbnails are JPEGs, or PNGs for images with transparency, and have no metadata.
+func (p *ImageProcessor) Process(data []byte, contentType string) (*ProcessedImage, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error reading image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, errors.New("image has no pixels")
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+	orientation := readOrientation(data, contentType)
+
+	result := &ProcessedImage{}
+	result.Width, result.Height = orientedSize(src.Bounds().Dx(), src.Bounds().Dy(), orientation)
+
+	placeholder := orient(scaleToFit(src, placeholderDimension, draw.ApproxBiLinear), orientation)
+	result.BlurHash = blurHash(placeholder)
+	result.DominantColor = dominantColor(placeholder)
+
+	longest := max(src.Bounds().Dx(), src.Bounds().Dy())
+	for _, size := range p.sizes {
+		if longest <= size.MaxDimension {
+			continue
+		}
+
+		scaled := orient(scaleToFit(src, size.MaxDimension, draw.CatmullRom), orientation)
+		thumbnail, err := encodeThumbnail(scaled)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %s thumbnail: %w", size.Name, err)
+		}
+		thumbnail.Name = size.Name
+		result.Thumbnails = append(result.Thumbnails, *thumbnail)
+	}
+
+	return result, nil
+}
+
+// scaleToFit returns a copy of src whose longest side is at most maxDimension, keeping the aspect ratio
+func scaleToFit(src image.Image, maxDimension int, scaler draw.Scaler) *image.RGBA {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxDimension || height > maxDimension {
+		if width >= height {
+			height = max(1, height*maxDimension/width)
+			width = maxDimension
+		} else {
+			width = max(1, width*maxDimension/height)
+			height = maxDimension
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	scaler.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+func encodeThumbnail(img *image.RGBA) (*Thumbnail, error) {
+	var buf bytes.Buffer
+	contentType := "image/jpeg"
+	if img.Opaque() {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailJPEGQuality}); err != nil {
+			return nil, err
+		}
+	} else {
+		contentType = "image/png"
+		encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+		if err := encoder.Encode(&buf, img); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Thumbnail{
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+		ContentType: contentType,
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func orientedSize(width, height, orientation int) (int, int) {
+	if orientation >= 5 {
+		return height, width
+	}
+	return width, height
+}
+
+// orient turns an image the way its EXIF orientation says it is displayed
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+	width, height := orientedSize(srcWidth, srcHeight, orientation)
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		for x := range width {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = srcWidth-1-x, y
+			case 3: // rotated 180°
+				sx, sy = srcWidth-1-x, srcHeight-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, srcHeight-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotated 90° clockwise
+				sx, sy = y, srcHeight-1-x
+			case 7: // transversed
+				sx, sy = srcWidth-1-y, srcHeight-1-x
+			case 8: // rotated 90° counter-clockwise
+				sx, sy = srcWidth-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,17 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+type AttachmentRepository interface {
+	Save(ctx context.Context, attachment *models.Attachment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error)
+	DeleteExpired(ctx context.Context, unattachedBefore time.Time, limit int) ([]models.Attachment, error)
+	SaveProcessingResult(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error)
+	FindPendingProcessing(ctx context.Context, createdBefore time.Time, limit int) ([]models.Attachment, error)
+}
@@ -0,0 +1,14 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+type BookmarkRepository interface {
+	Save(ctx context.Context, bookmark *models.Bookmark) error
+	Delete(ctx context.Context, userID, bookmarkID uuid.UUID) error
+	FindByUser(ctx context.Context, userID uuid.UUID, query models.BookmarkQuery) (*models.BookmarkPage, error)
+}
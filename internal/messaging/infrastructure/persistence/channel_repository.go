@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
@@ -10,13 +11,37 @@ import (
 type ChannelRepository interface {
 	Save(ctx context.Context, channel *models.Channel) error
 	FindById(ctx context.Context, id uuid.UUID) (*models.Channel, error)
+	FindByDirectKey(ctx context.Context, directKey string) (*models.Channel, error)
+	FindDirectory(ctx context.Context, query models.ChannelDirectoryQuery) (*models.ChannelDirectoryPage, error)
+	FindChannelsWithMember(ctx context.Context, userID uuid.UUID) ([]*models.Channel, error)
 	FindUserChannels(ctx context.Context, userID uuid.UUID) ([]*models.Channel, error)
-	FindMessages(ctx context.Context, channelID uuid.UUID, limit int, offset int) ([]models.Message, error)
+	FindMessages(ctx context.Context, channelID uuid.UUID, query models.MessageQuery) (*models.MessagePage, error)
+	FindThreadReplies(ctx context.Context, channelID, parentMessageID uuid.UUID, query models.MessageQuery) (*models.MessagePage, error)
+	FindMentions(ctx context.Context, userID uuid.UUID, query models.MessageQuery) (*models.MessagePage, error)
+	SearchMessages(ctx context.Context, userID uuid.UUID, query models.MessageSearchQuery) (*models.MessageSearchPage, error)
+	UpdateReadMarker(ctx context.Context, channelID uuid.UUID, member *models.Member) error
+	FindUnreadCounts(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]models.UnreadCounts, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	FindMessageByID(ctx context.Context, channelID, messageID uuid.UUID) (*models.Message, error)
+	FindMessagesByIDs(ctx context.Context, messageIDs []uuid.UUID) ([]models.Message, error)
 	SaveMessage(ctx context.Context, message *models.Message) error
+	UpdateMessage(ctx context.Context, message *models.Message, revision *models.MessageRevision) error
+	MarkMessageDeleted(ctx context.Context, message *models.Message) error
+	PinMessageWithinLimit(ctx context.Context, message *models.Message, maxPins int) error
+	UpdateMessagePin(ctx context.Context, message *models.Message) error
+	CountPinnedMessages(ctx context.Context, channelID uuid.UUID) (int, error)
+	FindPinnedMessages(ctx context.Context, channelID uuid.UUID) ([]models.Message, error)
+	FindMessageRevisions(ctx context.Context, messageID uuid.UUID) ([]models.MessageRevision, error)
 	SaveReaction(ctx context.Context, reaction *models.Reaction) error
 	DeleteReaction(ctx context.Context, messageID, userID uuid.UUID, reactionType string) error
 	FindReactionsByMessageID(ctx context.Context, messageID uuid.UUID) ([]models.Reaction, error)
+	ReplaceLinkPreviews(ctx context.Context, message *models.Message) error
+	IsChannelMember(ctx context.Context, channelID, userID uuid.UUID) (bool, error)
+	FindMemberIDs(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error)
+	ReplacePollVotes(ctx context.Context, message *models.Message, userID uuid.UUID) error
+	ClosePoll(ctx context.Context, message *models.Message) error
+	FindPollsDueToClose(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error)
+	FindUnsentAttachments(ctx context.Context, channelID uuid.UUID, attachmentIDs []uuid.UUID) ([]models.Attachment, error)
 	FindByInviteCode(ctx context.Context, inviteCode string) (*models.Channel, error)
 	FindByInviteID(ctx context.Context, inviteID uuid.UUID) (*models.Channel, error)
 }
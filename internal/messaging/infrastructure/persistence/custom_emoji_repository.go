@@ -0,0 +1,16 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+type CustomEmojiRepository interface {
+	Save(ctx context.Context, emoji *models.CustomEmoji) error
+	FindByName(ctx context.Context, name string) (*models.CustomEmoji, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*models.CustomEmoji, error)
+	FindAll(ctx context.Context) ([]models.CustomEmoji, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
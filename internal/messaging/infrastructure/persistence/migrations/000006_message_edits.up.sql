@@ -0,0 +1,14 @@
+ALTER TABLE messages ADD COLUMN edited_at TIMESTAMPTZ;
+
+CREATE TABLE message_revisions (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    content_text TEXT NOT NULL,
+    content_mentions UUID[],
+    content_link TEXT[],
+    content_formatted BOOLEAN NOT NULL DEFAULT FALSE,
+    edited_by UUID NOT NULL,
+    edited_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_message_revisions_message_id ON message_revisions (message_id, edited_at DESC);
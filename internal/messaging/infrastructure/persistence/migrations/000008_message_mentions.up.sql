@@ -0,0 +1 @@
+CREATE INDEX idx_messages_content_mentions ON messages USING GIN (content_mentions);
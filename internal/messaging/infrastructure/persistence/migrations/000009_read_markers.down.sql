@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_members_channel_id_user_id;
+ALTER TABLE members DROP COLUMN IF EXISTS last_read_message_id;
@@ -0,0 +1,2 @@
+ALTER TABLE members ADD COLUMN last_read_message_id UUID;
+CREATE INDEX idx_members_channel_id_user_id ON members (channel_id, user_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_channels_direct_key;
+ALTER TABLE channels DROP COLUMN IF EXISTS direct_key;
+ALTER TABLE channels DROP COLUMN IF EXISTS kind;
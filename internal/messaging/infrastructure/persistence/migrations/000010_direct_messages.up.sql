@@ -0,0 +1,3 @@
+ALTER TABLE channels ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'channel';
+ALTER TABLE channels ADD COLUMN direct_key TEXT;
+CREATE UNIQUE INDEX idx_channels_direct_key ON channels (direct_key) WHERE direct_key IS NOT NULL;
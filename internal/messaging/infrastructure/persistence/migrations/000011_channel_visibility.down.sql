@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_channels_directory;
+ALTER TABLE channels DROP COLUMN IF EXISTS visibility;
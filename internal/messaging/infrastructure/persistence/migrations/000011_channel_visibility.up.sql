@@ -0,0 +1,4 @@
+ALTER TABLE channels ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public';
+UPDATE channels SET visibility = 'private' WHERE kind <> 'channel';
+CREATE INDEX idx_channels_directory ON channels (last_message_time DESC, id DESC)
+    WHERE kind = 'channel' AND visibility = 'public' AND is_archived = FALSE;
@@ -0,0 +1,11 @@
+CREATE TABLE channel_bans (
+    channel_id UUID NOT NULL REFERENCES channels (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    banned_by UUID NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ DEFAULT NULL, -- NULL means the ban is permanent
+    created_at TIMESTAMPTZ NOT NULL DEFAULT 'now()',
+    PRIMARY KEY (channel_id, user_id)
+);
+
+CREATE INDEX idx_channel_bans_user_id ON channel_bans (user_id);
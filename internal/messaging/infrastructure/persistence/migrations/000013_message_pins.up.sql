@@ -0,0 +1,4 @@
+ALTER TABLE messages ADD COLUMN pinned_at TIMESTAMPTZ;
+ALTER TABLE messages ADD COLUMN pinned_by UUID;
+
+CREATE INDEX idx_messages_pinned ON messages (channel_id, pinned_at DESC) WHERE pinned_at IS NOT NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE bookmarks (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    channel_id UUID NOT NULL REFERENCES channels (id) ON DELETE CASCADE,
+    message_id UUID NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT 'now()',
+    UNIQUE (user_id, message_id)
+);
+
+CREATE INDEX idx_bookmarks_user_created_at ON bookmarks (user_id, created_at DESC, id DESC);
@@ -0,0 +1,4 @@
+ALTER TABLE messages ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('english', coalesce(content_text, ''))) STORED;
+
+CREATE INDEX idx_messages_search_vector ON messages USING GIN (search_vector);
@@ -0,0 +1,16 @@
+CREATE TABLE scheduled_messages (
+    id UUID PRIMARY KEY,
+    channel_id UUID NOT NULL REFERENCES channels (id) ON DELETE CASCADE,
+    sender_user_id UUID NOT NULL,
+    parent_message_id UUID REFERENCES messages (id) ON DELETE CASCADE,
+    content_text TEXT NOT NULL,
+    scheduled_for TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    failure_reason TEXT NOT NULL DEFAULT '',
+    claimed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT 'now()',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX idx_scheduled_messages_due ON scheduled_messages (scheduled_for) WHERE status = 'pending';
+CREATE INDEX idx_scheduled_messages_sender ON scheduled_messages (sender_user_id, scheduled_for);
@@ -0,0 +1,12 @@
+CREATE TABLE reminders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    channel_id UUID NOT NULL REFERENCES channels (id) ON DELETE CASCADE,
+    message_id UUID NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    remind_at TIMESTAMPTZ NOT NULL,
+    fired_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX idx_reminders_due ON reminders (remind_at) WHERE fired_at IS NULL;
+CREATE INDEX idx_reminders_user_remind_at ON reminders (user_id, remind_at);
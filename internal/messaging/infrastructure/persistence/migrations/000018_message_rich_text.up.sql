@@ -0,0 +1,2 @@
+-- Messages stored before this column are parsed when loaded
+ALTER TABLE messages ADD COLUMN content_rich_text JSONB;
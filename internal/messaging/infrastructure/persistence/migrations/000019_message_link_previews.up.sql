@@ -0,0 +1,11 @@
+CREATE TABLE message_link_previews (
+    message_id UUID NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL, -- Order of the link in the message
+    url TEXT NOT NULL,
+    title TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    site_name TEXT NOT NULL DEFAULT '',
+    image_url TEXT NOT NULL DEFAULT '',
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT 'now()',
+    PRIMARY KEY (message_id, position)
+);
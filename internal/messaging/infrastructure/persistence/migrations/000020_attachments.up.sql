@@ -0,0 +1,15 @@
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY,
+    channel_id UUID NOT NULL REFERENCES channels (id) ON DELETE CASCADE,
+    uploader_id UUID NOT NULL,
+    message_id UUID REFERENCES messages (id) ON DELETE CASCADE, -- NULL until the upload is sent with a message
+    position SMALLINT, -- Order of the attachment in the message
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX idx_attachments_message_id ON attachments (message_id, position) WHERE message_id IS NOT NULL;
+CREATE INDEX idx_attachments_unattached_created_at ON attachments (created_at) WHERE message_id IS NULL;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_attachments_pending_processing;
+ALTER TABLE attachments DROP COLUMN IF EXISTS processed_at;
+ALTER TABLE attachments DROP COLUMN IF EXISTS thumbnails;
+ALTER TABLE attachments DROP COLUMN IF EXISTS dominant_color;
+ALTER TABLE attachments DROP COLUMN IF EXISTS blur_hash;
+ALTER TABLE attachments DROP COLUMN IF EXISTS height;
+ALTER TABLE attachments DROP COLUMN IF EXISTS width;
+ALTER TABLE attachments DROP COLUMN IF EXISTS processing_status;
@@ -0,0 +1,10 @@
+-- Images uploaded before processing existed are left unprocessed
+ALTER TABLE attachments ADD COLUMN processing_status VARCHAR(20) NOT NULL DEFAULT 'none';
+ALTER TABLE attachments ADD COLUMN width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE attachments ADD COLUMN height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE attachments ADD COLUMN blur_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE attachments ADD COLUMN dominant_color VARCHAR(7) NOT NULL DEFAULT '';
+ALTER TABLE attachments ADD COLUMN thumbnails JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE attachments ADD COLUMN processed_at TIMESTAMPTZ;
+
+CREATE INDEX idx_attachments_pending_processing ON attachments (created_at) WHERE processing_status = 'pending';
@@ -0,0 +1,12 @@
+CREATE TABLE custom_emoji (
+    id UUID PRIMARY KEY,
+    name VARCHAR(32) NOT NULL UNIQUE,
+    alias_for UUID REFERENCES custom_emoji (id) ON DELETE CASCADE, -- NULL unless the emoji is an alias of another one
+    creator_id UUID NOT NULL,
+    content_type TEXT NOT NULL DEFAULT '',
+    size BIGINT NOT NULL DEFAULT 0,
+    storage_key TEXT NOT NULL DEFAULT '', -- Empty for aliases, which use the image of the original emoji
+    created_at TIMESTAMPTZ NOT NULL DEFAULT 'now()'
+);
+
+CREATE INDEX idx_custom_emoji_alias_for ON custom_emoji (alias_for) WHERE alias_for IS NOT NULL;
@@ -0,0 +1,30 @@
+CREATE TABLE polls (
+    message_id UUID PRIMARY KEY REFERENCES messages (id) ON DELETE CASCADE,
+    channel_id UUID NOT NULL REFERENCES channels (id) ON DELETE CASCADE,
+    question TEXT NOT NULL,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at TIMESTAMPTZ, -- NULL for polls that stay open until they are closed
+    closed_at TIMESTAMPTZ,
+    closed_by UUID -- NULL when the poll closed at closes_at
+);
+
+CREATE INDEX idx_polls_closes_at ON polls (closes_at) WHERE closed_at IS NULL AND closes_at IS NOT NULL;
+
+CREATE TABLE poll_options (
+    id UUID PRIMARY KEY,
+    message_id UUID NOT NULL REFERENCES polls (message_id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    text TEXT NOT NULL,
+    UNIQUE (message_id, position)
+);
+
+CREATE TABLE poll_votes (
+    message_id UUID NOT NULL REFERENCES polls (message_id) ON DELETE CASCADE,
+    option_id UUID NOT NULL REFERENCES poll_options (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    voted_at TIMESTAMPTZ NOT NULL DEFAULT 'now()',
+    PRIMARY KEY (option_id, user_id)
+);
+
+CREATE INDEX idx_poll_votes_message_id ON poll_votes (message_id, user_id);
@@ -0,0 +1,10 @@
+-- Forwarded and quoting messages point at their source. The source is not a foreign key, so the reference
+-- outlives the source message and its channel and the preview can say that the source is gone.
+CREATE TABLE message_references (
+    message_id UUID PRIMARY KEY REFERENCES messages (id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('forward', 'quote')),
+    source_message_id UUID NOT NULL,
+    source_channel_id UUID NOT NULL
+);
+
+CREATE INDEX idx_message_references_source_message_id ON message_references (source_message_id);
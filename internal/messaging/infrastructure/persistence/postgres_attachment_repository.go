@@ -0,0 +1,210 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/common"
+)
+
+var _ AttachmentRepository = (*PostgresAttachmentRepository)(nil)
+
+const attachmentColumns = `a.id, a.channel_id, a.uploader_id, a.message_id, a.file_name, a.content_type, a.size, a.storage_key, a.created_at,
+	a.processing_status, a.width, a.height, a.blur_hash, a.dominant_color, a.thumbnails`
+
+// attachmentThumbnailRecord is the JSONB representation of a thumbnail in the thumbnails column
+type attachmentThumbnailRecord struct {
+	Name        string `json:"name"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	StorageKey  string `json:"storage_key"`
+}
+
+type PostgresAttachmentRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresAttachmentRepository(pool *pgxpool.Pool) *PostgresAttachmentRepository {
+	return &PostgresAttachmentRepository{
+		pool: pool,
+	}
+}
+
+func (r *PostgresAttachmentRepository) Save(ctx context.Context, attachment *models.Attachment) error {
+	query := `
+		INSERT INTO attachments (id, channel_id, uploader_id, file_name, content_type, size, storage_key, created_at, processing_status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		attachment.GetId(),
+		attachment.GetChannelId(),
+		attachment.GetUploaderId(),
+		attachment.GetFileName(),
+		attachment.GetContentType(),
+		attachment.GetSize(),
+		attachment.GetStorageKey(),
+		attachment.GetCreatedAt(),
+		string(attachment.GetProcessingStatus()),
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting attachment %s: %w", attachment.GetId(), err)
+	}
+	return nil
+}
+
+// FindByID returns an attachment. Attachments of deleted messages are reported as not found.
+func (r *PostgresAttachmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments a
+		LEFT JOIN messages m ON m.id = a.message_id
+		WHERE a.id = $1 AND m.deleted_at IS NULL
+	`
+	attachment, err := scanAttachment(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("attachment with ID %s not found: %w", id, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error finding attachment %s: %w", id, err)
+	}
+	return attachment, nil
+}
+
+// DeleteExpired removes up to limit uploads that were never sent and were created before unattachedBefore,
+// together with the attachments of deleted messages. The removed attachments are returned so their blobs can be deleted.
+func (r *PostgresAttachmentRepository) DeleteExpired(ctx context.Context, unattachedBefore time.Time, limit int) ([]models.Attachment, error) {
+	query := `
+		DELETE FROM attachments a
+		WHERE a.id IN (
+			SELECT e.id FROM attachments e
+			LEFT JOIN messages m ON m.id = e.message_id
+			WHERE (e.message_id IS NULL AND e.created_at < $1) OR m.deleted_at IS NOT NULL
+			LIMIT $2
+		)
+		RETURNING ` + attachmentColumns
+
+	rows, err := r.pool.Query(ctx, query, unattachedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting expired attachments: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning deleted attachment: %w", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deleted attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// SaveProcessingResult records the metadata and thumbnails of a processed image and returns the attachment as stored.
+// It returns common.ErrConflict when the attachment was already processed or no longer exists.
+func (r *PostgresAttachmentRepository) SaveProcessingResult(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	thumbnails := []attachmentThumbnailRecord{}
+	for _, thumbnail := range attachment.GetThumbnails() {
+		thumbnails = append(thumbnails, attachmentThumbnailRecord{
+			Name:        thumbnail.GetName(),
+			Width:       thumbnail.GetWidth(),
+			Height:      thumbnail.GetHeight(),
+			ContentType: thumbnail.GetContentType(),
+			Size:        thumbnail.GetSize(),
+			StorageKey:  thumbnail.GetStorageKey(),
+		})
+	}
+
+	query := `
+		UPDATE attachments a
+		SET processing_status = $2, width = $3, height = $4, blur_hash = $5, dominant_color = $6, thumbnails = $7, processed_at = $8
+		WHERE a.id = $1 AND a.processing_status = 'pending'
+		RETURNING ` + attachmentColumns
+
+	saved, err := scanAttachment(r.pool.QueryRow(ctx, query,
+		attachment.GetId(),
+		string(attachment.GetProcessingStatus()),
+		attachment.GetWidth(),
+		attachment.GetHeight(),
+		attachment.GetBlurHash(),
+		attachment.GetDominantColor(),
+		thumbnails,
+		time.Now().UTC(),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("attachment %s is no longer pending processing: %w", attachment.GetId(), common.ErrConflict)
+		}
+		return nil, fmt.Errorf("error saving processing result of attachment %s: %w", attachment.GetId(), err)
+	}
+	return saved, nil
+}
+
+// FindPendingProcessing returns up to limit attachments created before createdBefore that are still waiting to be processed
+func (r *PostgresAttachmentRepository) FindPendingProcessing(ctx context.Context, createdBefore time.Time, limit int) ([]models.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments a
+		WHERE a.processing_status = 'pending' AND a.created_at < $1
+		ORDER BY a.created_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.pool.Query(ctx, query, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying attachments pending processing: %w", err)
+	}
+	defer rows.Close()
+
+	attachments := []models.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning attachment: %w", err)
+		}
+		attachments = append(attachments, *attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments pending processing: %w", err)
+	}
+	return attachments, nil
+}
+
+func scanAttachment(row pgx.Row) (*models.Attachment, error) {
+	var id, channelID, uploaderID uuid.UUID
+	var messageID *uuid.UUID
+	var fileName, contentType, storageKey, processingStatus, blurHash, dominantColor string
+	var size int64
+	var createdAt time.Time
+	var width, height int
+	var thumbnailRecords []attachmentThumbnailRecord
+
+	if err := row.Scan(
+		&id, &channelID, &uploaderID, &messageID, &fileName, &contentType, &size, &storageKey, &createdAt,
+		&processingStatus, &width, &height, &blurHash, &dominantColor, &thumbnailRecords,
+	); err != nil {
+		return nil, err
+	}
+
+	thumbnails := make([]models.AttachmentThumbnail, len(thumbnailRecords))
+	for i, record := range thumbnailRecords {
+		thumbnails[i] = models.RehydrateAttachmentThumbnail(record.Name, record.Width, record.Height, record.ContentType, record.Size, record.StorageKey)
+	}
+
+	attachment := models.RehydrateAttachment(
+		id, channelID, uploaderID, messageID, fileName, contentType, size, storageKey, createdAt,
+		models.AttachmentProcessingStatus(processingStatus), width, height, blurHash, dominantColor, thumbnails,
+	)
+	return &attachment, nil
+}
@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/common"
+)
+
+var _ BookmarkRepository = (*PostgresBookmarkRepository)(nil)
+
+type PostgresBookmarkRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresBookmarkRepository(pool *pgxpool.Pool) *PostgresBookmarkRepository {
+	return &PostgresBookmarkRepository{
+		pool: pool,
+	}
+}
+
+func (r *PostgresBookmarkRepository) Save(ctx context.Context, bookmark *models.Bookmark) error {
+	query := `
+		INSERT INTO bookmarks (id, user_id, channel_id, message_id, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		bookmark.GetId(),
+		bookmark.GetUserId(),
+		bookmark.GetChannelId(),
+		bookmark.GetMessageId(),
+		bookmark.GetNote(),
+		bookmark.GetCreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("message %s is already bookmarked by user %s: %w", bookmark.GetMessageId(), bookmark.GetUserId(), common.ErrConflict)
+		}
+		return fmt.Errorf("error inserting bookmark %s: %w", bookmark.GetId(), err)
+	}
+	return nil
+}
+
+func (r *PostgresBookmarkRepository) Delete(ctx context.Context, userID, bookmarkID uuid.UUID) error {
+	cmdTag, err := r.pool.Exec(ctx, `DELETE FROM bookmarks WHERE id = $1 AND user_id = $2`, bookmarkID, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting bookmark %s: %w", bookmarkID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("bookmark with ID %s not found: %w", bookmarkID, common.ErrNotFound)
+	}
+	return nil
+}
+
+// FindByUser returns a page of the user's bookmarks, most recently saved first.
+// Bookmarks are kept when their message is deleted or the user leaves the channel, but are hidden until
+// the user rejoins, so their content does not leak.
+func (r *PostgresBookmarkRepository) FindByUser(ctx context.Context, userID uuid.UUID, query models.BookmarkQuery) (*models.BookmarkPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultBookmarkPageSize
+	}
+	if limit > models.MaxBookmarkPageSize {
+		limit = models.MaxBookmarkPageSize
+	}
+
+	args := []any{userID}
+	where := `b.user_id = $1 AND m.deleted_at IS NULL`
+
+	if query.Before != nil {
+		var createdAt time.Time
+		err := r.pool.QueryRow(ctx, `SELECT created_at FROM bookmarks WHERE id = $1 AND user_id = $2`, *query.Before, userID).Scan(&createdAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("cursor bookmark %s not found: %w", *query.Before, common.ErrNotFound)
+			}
+			return nil, fmt.Errorf("error resolving cursor bookmark %s: %w", *query.Before, err)
+		}
+		args = append(args, createdAt, *query.Before)
+		where += " AND b.created_at <= $2 AND (b.created_at, b.id) < ($2, $3)"
+	}
+	args = append(args, limit+1)
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT b.id, b.user_id, b.channel_id, b.message_id, b.note, b.created_at
+		FROM bookmarks b
+		JOIN messages m ON m.id = b.message_id
+		JOIN members mb ON mb.channel_id = b.channel_id AND mb.user_id = b.user_id
+		WHERE %s
+		ORDER BY b.created_at DESC, b.id DESC
+		LIMIT $%d
+	`, where, len(args))
+
+	rows, err := r.pool.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying bookmarks for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	bookmarks := []models.Bookmark{}
+	for rows.Next() {
+		var id, ownerID, channelID, messageID uuid.UUID
+		var note string
+		var createdAt time.Time
+		if err := rows.Scan(&id, &ownerID, &channelID, &messageID, &note, &createdAt); err != nil {
+			return nil, fmt.Errorf("error scanning bookmark for user %s: %w", userID, err)
+		}
+		bookmarks = append(bookmarks, models.RehydrateBookmark(id, ownerID, channelID, messageID, note, createdAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bookmarks for user %s: %w", userID, err)
+	}
+
+	page := &models.BookmarkPage{Bookmarks: bookmarks}
+	if len(bookmarks) > limit {
+		page.Bookmarks = bookmarks[:limit]
+		next := page.Bookmarks[limit-1].GetId()
+		page.NextCursor = &next
+	}
+
+	return page, nil
+}
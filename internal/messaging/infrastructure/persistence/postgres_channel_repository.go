@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +19,22 @@ import (
 
 var _ ChannelRepository = (*PostgresChannelRepository)(nil)
 
+// messageColumns is the column list expected by scanMessage
+const messageColumns = `id, channel_id, sender_user_id, integration_id,
+		       content_text, content_mentions, content_link, content_formatted, content_rich_text,
+		       created_at, parent_message_id, edited_at, deleted_at, deleted_by, pinned_at, pinned_by`
+
+const channelColumns = `c.id, c.kind, c.direct_key, c.visibility, c.name, c.topic, c.creator_user_id,
+		       c.creation_time, c.last_message_time, c.is_archived, c.version`
+
+// ts_headline marks matches with control characters so the snippet can be HTML-escaped before they become <mark> tags
+const (
+	searchHighlightStart  = "\x02"
+	searchHighlightStop   = "\x03"
+	searchHeadlineOptions = "StartSel=" + searchHighlightStart + ", StopSel=" + searchHighlightStop +
+		", MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" … \""
+)
+
 type PostgresChannelRepository struct {
 	pool *pgxpool.Pool
 }
@@ -30,11 +48,15 @@ func NewPostgresChannelRepository(pool *pgxpool.Pool) *PostgresChannelRepository
 // Helper method to scan basic channel data
 func (r *PostgresChannelRepository) scanChannelBasic(row pgx.Row) (*models.Channel, error) {
 	var channel models.Channel
-	var topic *string
+	var kind, visibility string
+	var directKey, topic *string
 	var lastMsgTime *time.Time
 
 	err := row.Scan(
 		&channel.ID,
+		&kind,
+		&directKey,
+		&visibility,
 		&channel.Name,
 		&topic,
 		&channel.CreatorUserID,
@@ -47,6 +69,11 @@ func (r *PostgresChannelRepository) scanChannelBasic(row pgx.Row) (*models.Chann
 		return nil, err
 	}
 
+	channel.Kind = models.ChannelKind(kind)
+	channel.Visibility = models.ChannelVisibility(visibility)
+	if directKey != nil {
+		channel.DirectKey = *directKey
+	}
 	if topic != nil {
 		channel.Topic = *topic
 	}
@@ -60,7 +87,7 @@ func (r *PostgresChannelRepository) scanChannelBasic(row pgx.Row) (*models.Chann
 // Helper method to load members for a channel
 func (r *PostgresChannelRepository) loadMembers(ctx context.Context, channelID uuid.UUID) ([]models.Member, error) {
 	query := `
-		SELECT user_id, role, joined_at, last_read
+		SELECT user_id, role, joined_at, last_read, last_read_message_id
 		FROM members
 		WHERE channel_id = $1
 		ORDER BY joined_at ASC
@@ -78,8 +105,9 @@ func (r *PostgresChannelRepository) loadMembers(ctx context.Context, channelID u
 		var memberRole string
 		var memberJoinedAt time.Time
 		var memberLastRead sql.NullTime
+		var memberLastReadMessageID *uuid.UUID
 
-		if err := rows.Scan(&memberUserID, &memberRole, &memberJoinedAt, &memberLastRead); err != nil {
+		if err := rows.Scan(&memberUserID, &memberRole, &memberJoinedAt, &memberLastRead, &memberLastReadMessageID); err != nil {
 			return nil, fmt.Errorf("error scanning member for channel %s: %w", channelID, err)
 		}
 
@@ -88,7 +116,7 @@ func (r *PostgresChannelRepository) loadMembers(ctx context.Context, channelID u
 			actualLastRead = memberLastRead.Time
 		}
 
-		member := models.RehydrateMember(memberUserID, memberRole, memberJoinedAt, actualLastRead)
+		member := models.RehydrateMember(memberUserID, memberRole, memberJoinedAt, actualLastRead, memberLastReadMessageID)
 		members = append(members, member)
 	}
 
@@ -145,256 +173,797 @@ func (r *PostgresChannelRepository) loadInvites(ctx context.Context, channelID u
 	return invites, nil
 }
 
-// Helper method to load messages for a channel
-func (r *PostgresChannelRepository) loadMessages(ctx context.Context, channelID uuid.UUID, limit int, offset int) ([]models.Message, error) {
+// Helper method to load the bans of a channel
+func (r *PostgresChannelRepository) loadBans(ctx context.Context, channelID uuid.UUID) ([]models.ChannelBan, error) {
 	query := `
-		SELECT id, channel_id, sender_user_id, integration_id,
-		       content_text, content_mentions, content_link, content_formatted,
-		       created_at, parent_message_id
-		FROM messages
+		SELECT user_id, banned_by, reason, expires_at, created_at
+		FROM channel_bans
 		WHERE channel_id = $1
-		ORDER BY created_at ASC
-		LIMIT $2 OFFSET $3
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying bans for channel %s: %w", channelID, err)
+	}
+	defer rows.Close()
+
+	bans := []models.ChannelBan{}
+	for rows.Next() {
+		var userID, bannedBy uuid.UUID
+		var reason string
+		var expiresAt *time.Time
+		var createdAt time.Time
+
+		if err := rows.Scan(&userID, &bannedBy, &reason, &expiresAt, &createdAt); err != nil {
+			return nil, fmt.Errorf("error scanning ban for channel %s: %w", channelID, err)
+		}
+
+		bans = append(bans, models.RehydrateChannelBan(userID, bannedBy, reason, expiresAt, createdAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating bans for channel %s: %w", channelID, err)
+	}
+
+	return bans, nil
+}
+
+// scanMessage scans a row starting with messageColumns; extra receives any columns selected after them
+func (r *PostgresChannelRepository) scanMessage(row pgx.Row, extra ...any) (models.Message, error) {
+	var messageId, channelID uuid.UUID
+	var senderUserID, integrationID, parentMessageID *uuid.UUID
+	var mentions []uuid.UUID
+	var links []string
+	var text string
+	var timestamp time.Time
+	var editedAt, deletedAt, pinnedAt *time.Time
+	var deletedBy, pinnedBy *uuid.UUID
+	var isFormatted bool
+	var richText *models.RichText
+
+	dest := []any{
+		&messageId,
+		&channelID,
+		&senderUserID,
+		&integrationID,
+		&text,
+		&mentions,
+		&links,
+		&isFormatted,
+		&richText,
+		&timestamp,
+		&parentMessageID,
+		&editedAt,
+		&deletedAt,
+		&deletedBy,
+		&pinnedAt,
+		&pinnedBy,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return models.Message{}, err
+	}
+
+	content := models.RehydrateMessageContent(text, mentions, links, isFormatted, richText)
+
+	return models.RehydrateMessage(
+		messageId,
+		channelID,
+		senderUserID,
+		integrationID,
+		parentMessageID,
+		content,
+		[]models.Reaction{},
+		timestamp,
+		editedAt,
+		deletedAt,
+		deletedBy,
+		pinnedAt,
+		pinnedBy,
+	), nil
+}
+
+// messageCursor is the keyset position of a message within its channel
+type messageCursor struct {
+	createdAt time.Time
+	id        uuid.UUID
+}
+
+// Helper method to resolve a cursor message ID to its keyset position
+func (r *PostgresChannelRepository) findMessageCursor(ctx context.Context, channelID, messageID uuid.UUID) (*messageCursor, error) {
+	query := `
+		SELECT created_at
+		FROM messages
+		WHERE id = $1 AND channel_id = $2
 	`
 
+	cursor := &messageCursor{id: messageID}
+	if err := r.pool.QueryRow(ctx, query, messageID, channelID).Scan(&cursor.createdAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("cursor message %s not found in channel %s: %w", messageID, channelID, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error resolving cursor message %s: %w", messageID, err)
+	}
+
+	return cursor, nil
+}
+
+// Helper method to load up to limit messages on one side of a cursor.
+// Messages are returned in chronological order together with whether more exist past the limit.
+// A nil cursor starts from the newest message; a non-nil parentID restricts the window to that thread.
+func (r *PostgresChannelRepository) queryMessageWindow(ctx context.Context, channelID uuid.UUID, parentID *uuid.UUID, cursor *messageCursor, older bool, inclusive bool, limit int) ([]models.Message, bool, error) {
 	if limit <= 0 {
-		limit = 50
+		return nil, false, nil
+	}
+
+	// The created_at range is repeated outside the row comparison so the
+	// (channel_id, created_at DESC) index can bound the scan.
+	order := "DESC"
+	rangeOp, rowOp := "<=", "<"
+	if !older {
+		order = "ASC"
+		rangeOp, rowOp = ">=", ">"
+	}
+	if inclusive {
+		rowOp = rangeOp
+	}
+
+	args := []any{channelID}
+	where := "channel_id = $1"
+	if cursor != nil {
+		args = append(args, cursor.createdAt, cursor.id)
+		where += fmt.Sprintf(" AND created_at %s $2 AND (created_at, id) %s ($2, $3)", rangeOp, rowOp)
 	}
-	if offset < 0 {
-		offset = 0
+	if parentID != nil {
+		args = append(args, *parentID)
+		where += fmt.Sprintf(" AND parent_message_id = $%d", len(args))
 	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE %s
+		ORDER BY created_at %s, id %s
+		LIMIT $%d
+	`, where, order, order, len(args))
 
-	rows, err := r.pool.Query(ctx, query, channelID, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error querying messages for channel %s: %w", channelID, err)
+		return nil, false, fmt.Errorf("error querying messages for channel %s: %w", channelID, err)
 	}
 	defer rows.Close()
 
 	var messages []models.Message
-	var messageIDs []uuid.UUID
-
 	for rows.Next() {
-		var messageId uuid.UUID
-		var senderUserID, integrationID, parentMessageID *uuid.UUID
-		var mentions []uuid.UUID
-		var links []string
-		var text string
-		var timestamp time.Time
-		var isFormatted bool
-
-		err := rows.Scan(
-			&messageId,
-			&channelID,
-			&senderUserID,
-			&integrationID,
-			&text,
-			&mentions,
-			&links,
-			&isFormatted,
-			&timestamp,
-			&parentMessageID,
-		)
+		msg, err := r.scanMessage(rows)
 		if err != nil {
-			return nil, fmt.Errorf("error scanning message for channel %s: %w", channelID, err)
+			return nil, false, fmt.Errorf("error scanning message for channel %s: %w", channelID, err)
 		}
+		messages = append(messages, msg)
+	}
 
-		content := models.RehydrateMessageContent(text, mentions, links, isFormatted)
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("error iterating messages for channel %s: %w", channelID, err)
+	}
 
-		msg := models.RehydrateMessage(
-			messageId,
-			channelID,
-			senderUserID,
-			integrationID,
-			parentMessageID,
-			content,
-			[]models.Reaction{},
-			timestamp,
-		)
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
 
-		messages = append(messages, msg)
-		messageIDs = append(messageIDs, messageId)
+	if older {
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating messages for channel %s: %w", channelID, err)
+	return messages, hasMore, nil
+}
+
+// Helper method to load a page of messages for a channel, or for a single thread when parentID is set
+func (r *PostgresChannelRepository) loadMessages(ctx context.Context, channelID uuid.UUID, parentID *uuid.UUID, query models.MessageQuery) (*models.MessagePage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultMessagePageSize
+	}
+	if limit > models.MaxMessagePageSize {
+		limit = models.MaxMessagePageSize
 	}
 
-	// Load reactions for all messages if we have any
-	if len(messages) > 0 {
-		if err := r.loadReactionsForMessages(ctx, messages, messageIDs); err != nil {
+	var messages []models.Message
+	var hasOlder, hasNewer bool
+
+	switch {
+	case query.Before != nil:
+		cursor, err := r.findMessageCursor(ctx, channelID, *query.Before)
+		if err != nil {
+			return nil, err
+		}
+		messages, hasOlder, err = r.queryMessageWindow(ctx, channelID, parentID, cursor, true, false, limit)
+		if err != nil {
+			return nil, err
+		}
+		hasNewer = true
+	case query.After != nil:
+		cursor, err := r.findMessageCursor(ctx, channelID, *query.After)
+		if err != nil {
+			return nil, err
+		}
+		messages, hasNewer, err = r.queryMessageWindow(ctx, channelID, parentID, cursor, false, false, limit)
+		if err != nil {
+			return nil, err
+		}
+		hasOlder = true
+	case query.Around != nil:
+		cursor, err := r.findMessageCursor(ctx, channelID, *query.Around)
+		if err != nil {
+			return nil, err
+		}
+		// The older half includes the cursor message itself
+		older, olderHasMore, err := r.queryMessageWindow(ctx, channelID, parentID, cursor, true, true, limit-limit/2)
+		if err != nil {
+			return nil, err
+		}
+		newer, newerHasMore, err := r.queryMessageWindow(ctx, channelID, parentID, cursor, false, false, limit/2)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(older, newer...)
+		hasOlder, hasNewer = olderHasMore, newerHasMore
+	default:
+		var err error
+		messages, hasOlder, err = r.queryMessageWindow(ctx, channelID, parentID, nil, true, false, limit)
+		if err != nil {
 			return nil, err
 		}
 	}
 
-	return messages, nil
+	page := &models.MessagePage{Messages: messages}
+	if len(messages) == 0 {
+		return page, nil
+	}
+
+	if hasOlder {
+		prev := messages[0].GetId()
+		page.PrevCursor = &prev
+	}
+	if hasNewer {
+		next := messages[len(messages)-1].GetId()
+		page.NextCursor = &next
+	}
+
+	messageIDs := make([]uuid.UUID, len(messages))
+	for i, msg := range messages {
+		messageIDs[i] = msg.GetId()
+	}
+
+	// Load reactions for all messages
+	if err := r.loadReactionsForMessages(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadThreadSummaries(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadLinkPreviews(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadPolls(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadReferences(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
 
-// Helper method to load reactions for messages
-func (r *PostgresChannelRepository) loadReactionsForMessages(ctx context.Context, messages []models.Message, messageIDs []uuid.UUID) error {
+// Helper method to load reply counts, last reply time and participants for messages.
+// Deleted replies are not counted.
+func (r *PostgresChannelRepository) loadThreadSummaries(ctx context.Context, messages []models.Message, messageIDs []uuid.UUID) error {
 	query := `
-		SELECT id, message_id, user_id, reaction_type, created_at
-		FROM reactions
-		WHERE message_id = ANY($1)
-		ORDER BY message_id, created_at ASC
+		SELECT parent_message_id,
+		       COUNT(*),
+		       MAX(created_at),
+		       COALESCE(ARRAY_AGG(DISTINCT sender_user_id) FILTER (WHERE sender_user_id IS NOT NULL), '{}')
+		FROM messages
+		WHERE parent_message_id = ANY($1) AND deleted_at IS NULL
+		GROUP BY parent_message_id
 	`
 
 	rows, err := r.pool.Query(ctx, query, messageIDs)
 	if err != nil {
-		return fmt.Errorf("error querying reactions for messages: %w", err)
+		return fmt.Errorf("error querying thread summaries: %w", err)
 	}
 	defer rows.Close()
 
-	reactionsByMessageID := make(map[uuid.UUID][]models.Reaction)
+	summariesByMessageID := make(map[uuid.UUID]models.ThreadSummary)
 	for rows.Next() {
-		var reactionID uuid.UUID
-		var reactionMessageID uuid.UUID
-		var reactionUserID uuid.UUID
-		var reactionType string
-		var reactionTimestamp time.Time
+		var parentID uuid.UUID
+		var replyCount int
+		var lastReplyAt time.Time
+		var participants []uuid.UUID
 
-		err := rows.Scan(
-			&reactionID,
-			&reactionMessageID,
-			&reactionUserID,
-			&reactionType,
-			&reactionTimestamp,
-		)
-		if err != nil {
-			return fmt.Errorf("error scanning reaction: %w", err)
+		if err := rows.Scan(&parentID, &replyCount, &lastReplyAt, &participants); err != nil {
+			return fmt.Errorf("error scanning thread summary: %w", err)
 		}
 
-		reaction := models.RehydrateReaction(reactionID, reactionMessageID, reactionUserID, reactionType, reactionTimestamp)
-		reactionsByMessageID[reactionMessageID] = append(reactionsByMessageID[reactionMessageID], reaction)
+		summariesByMessageID[parentID] = models.RehydrateThreadSummary(replyCount, &lastReplyAt, participants)
 	}
 
 	if err := rows.Err(); err != nil {
-		return fmt.Errorf("error iterating reactions: %w", err)
+		return fmt.Errorf("error iterating thread summaries: %w", err)
 	}
 
-	// Attach reactions to messages
 	for i := range messages {
-		if reactions, ok := reactionsByMessageID[messages[i].GetId()]; ok {
-			messages[i].SetLoadedReactions(reactions)
+		if summary, ok := summariesByMessageID[messages[i].GetId()]; ok {
+			messages[i].SetLoadedThread(summary)
 		}
 	}
 
 	return nil
 }
 
-// Helper method to save members using COPY
-func (r *PostgresChannelRepository) saveMembers(ctx context.Context, tx pgx.Tx, channelID uuid.UUID, members []models.Member) error {
-	if len(members) == 0 {
-		return nil
-	}
+// Helper method to load link previews for messages, in the order the links appear
+func (r *PostgresChannelRepository) loadLinkPreviews(ctx context.Context, messages []models.Message, messageIDs []uuid.UUID) error {
+	query := `
+		SELECT message_id, url, title, description, site_name, image_url, fetched_at
+		FROM message_link_previews
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, position ASC
+	`
 
-	// Delete existing members
-	deleteQuery := `DELETE FROM members WHERE channel_id = $1`
-	_, err := tx.Exec(ctx, deleteQuery, channelID)
+	rows, err := r.pool.Query(ctx, query, messageIDs)
 	if err != nil {
-		return fmt.Errorf("error deleting old members for channel %s: %w", channelID, err)
+		return fmt.Errorf("error querying link previews for messages: %w", err)
 	}
+	defer rows.Close()
 
-	// Insert new members using COPY
-	memberRows := make([][]any, len(members))
-	for i, member := range members {
-		memberRows[i] = []any{
-			channelID,
-			member.GetId(),
-			member.GetRole(),
-			member.GetJoinedAt(),
-			member.GetLastRead(),
+	previewsByMessageID := make(map[uuid.UUID][]models.LinkPreview)
+	for rows.Next() {
+		var messageID uuid.UUID
+		var url, title, description, siteName, imageURL string
+		var fetchedAt time.Time
+
+		if err := rows.Scan(&messageID, &url, &title, &description, &siteName, &imageURL, &fetchedAt); err != nil {
+			return fmt.Errorf("error scanning link preview: %w", err)
 		}
+
+		preview := models.RehydrateLinkPreview(url, title, description, siteName, imageURL, fetchedAt)
+		previewsByMessageID[messageID] = append(previewsByMessageID[messageID], preview)
 	}
 
-	copyCount, err := tx.CopyFrom(
-		ctx,
-		pgx.Identifier{"members"},
-		[]string{"channel_id", "user_id", "role", "joined_at", "last_read"},
-		pgx.CopyFromRows(memberRows),
-	)
-	if err != nil {
-		return fmt.Errorf("error inserting members for channel %s using copy: %w", channelID, err)
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating link previews: %w", err)
 	}
 
-	if int(copyCount) != len(members) {
-		return fmt.Errorf("expected %d members to be inserted for channel %s, but got %d", len(members), channelID, copyCount)
+	for i := range messages {
+		if previews, ok := previewsByMessageID[messages[i].GetId()]; ok {
+			messages[i].SetLoadedLinkPreviews(previews)
+		}
 	}
 
 	return nil
 }
 
-// Helper method to save invites
-func (r *PostgresChannelRepository) saveInvites(ctx context.Context, tx pgx.Tx, channelID uuid.UUID, invites []models.ChannelInvite) error {
-	if len(invites) == 0 {
-		return nil
-	}
+// Helper method to load the attachments sent with messages, in the order they were attached
+func (r *PostgresChannelRepository) loadAttachments(ctx context.Context, messages []models.Message, messageIDs []uuid.UUID) error {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments a
+		WHERE a.message_id = ANY($1)
+		ORDER BY a.message_id, a.position ASC
+	`
 
-	// Delete existing invites
-	deleteQuery := `DELETE FROM channel_invites WHERE channel_id = $1`
-	_, err := tx.Exec(ctx, deleteQuery, channelID)
+	rows, err := r.pool.Query(ctx, query, messageIDs)
 	if err != nil {
-		return fmt.Errorf("error deleting old invites for channel %s: %w", channelID, err)
+		return fmt.Errorf("error querying attachments for messages: %w", err)
 	}
+	defer rows.Close()
 
-	// Insert new invites
-	insertQuery := `
-		INSERT INTO channel_invites (id, channel_id, created_by_user_id, invite_code, expires_at, max_uses, current_uses, created_at, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	`
-
-	for _, invite := range invites {
-		var maxUses *int
-		if invite.GetMaxUse() != nil {
-			maxUses = invite.GetMaxUse()
+	attachmentsByMessageID := make(map[uuid.UUID][]models.Attachment)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return fmt.Errorf("error scanning attachment: %w", err)
 		}
+		messageID := *attachment.GetMessageId()
+		attachmentsByMessageID[messageID] = append(attachmentsByMessageID[messageID], *attachment)
+	}
 
-		_, err := tx.Exec(ctx, insertQuery,
-			invite.GetID(),
-			invite.GetChannelID(),
-			invite.GetCreatedByUserID(),
-			invite.GetInviteCode(),
-			invite.GetExpiresAt(),
-			maxUses,
-			invite.GetCurrentUses(),
-			invite.GetCreatedAt(),
-			invite.GetIsActive(),
-		)
-		if err != nil {
-			return fmt.Errorf("error inserting invite %s for channel %s: %w", invite.GetID(), channelID, err)
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating attachments: %w", err)
+	}
+
+	for i := range messages {
+		// Attachments of deleted messages are kept until they are cleaned up, but never shown
+		if attachments, ok := attachmentsByMessageID[messages[i].GetId()]; ok && !messages[i].IsDeleted() {
+			messages[i].SetLoadedAttachments(attachments)
 		}
 	}
 
 	return nil
 }
 
-func (r *PostgresChannelRepository) Save(ctx context.Context, channel *models.Channel) error {
-	tx, err := r.pool.Begin(ctx)
+// Helper method to load the references of forwarded and quoting messages with their source messages.
+// Sources are loaded with their attachments and polls, but not their own references.
+func (r *PostgresChannelRepository) loadReferences(ctx context.Context, messages []models.Message, messageIDs []uuid.UUID) error {
+	query := `
+		SELECT message_id, kind, source_message_id, source_channel_id
+		FROM message_references
+		WHERE message_id = ANY($1)
+	`
+	rows, err := r.pool.Query(ctx, query, messageIDs)
 	if err != nil {
-		return fmt.Errorf("error beginning transaction: %w", err)
+		return fmt.Errorf("error querying message references: %w", err)
 	}
-	defer tx.Rollback(ctx)
+	defer rows.Close()
 
-	// Lock and update/insert channel
-	lockQuery := `SELECT version FROM channels WHERE id = $1 FOR UPDATE`
-	var currentVersion int64
-	err = tx.QueryRow(ctx, lockQuery, channel.ID).Scan(&currentVersion)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			if channel.Version != 1 {
-				return fmt.Errorf("cannot insert channel %s with version %d: %w", channel.ID, channel.Version, err)
-			}
-			insertQuery := `
-				INSERT INTO channels(id, name, topic, creator_user_id, creation_time, last_message_time, is_archived, version)
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-			`
-			_, err := tx.Exec(ctx, insertQuery, channel.ID, channel.Name, channel.Topic, channel.CreatorUserID, channel.CreationTime, channel.LastMessageTime, channel.IsArchived, channel.Version)
-			if err != nil {
-				return fmt.Errorf("error inserting channel %s: %w", channel.ID, err)
-			}
-		} else {
-			return fmt.Errorf("error locking channel %s: %w", channel.ID, err)
+	referencesByMessageID := make(map[uuid.UUID]models.MessageReference)
+	sourceIDs := []uuid.UUID{}
+	for rows.Next() {
+		var messageID, sourceMessageID, sourceChannelID uuid.UUID
+		var kind string
+		if err := rows.Scan(&messageID, &kind, &sourceMessageID, &sourceChannelID); err != nil {
+			return fmt.Errorf("error scanning message reference: %w", err)
 		}
-	} else {
+		referencesByMessageID[messageID] = models.RehydrateMessageReference(models.MessageReferenceKind(kind), sourceMessageID, sourceChannelID)
+		sourceIDs = append(sourceIDs, sourceMessageID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating message references: %w", err)
+	}
+	if len(sourceIDs) == 0 {
+		return nil
+	}
+
+	sourceRows, err := r.pool.Query(ctx, `SELECT `+messageColumns+` FROM messages WHERE id = ANY($1)`, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("error querying source messages: %w", err)
+	}
+	defer sourceRows.Close()
+
+	sources := []models.Message{}
+	for sourceRows.Next() {
+		source, err := r.scanMessage(sourceRows)
+		if err != nil {
+			return fmt.Errorf("error scanning source message: %w", err)
+		}
+		sources = append(sources, source)
+	}
+	if err := sourceRows.Err(); err != nil {
+		return fmt.Errorf("error iterating source messages: %w", err)
+	}
+
+	foundIDs := make([]uuid.UUID, len(sources))
+	for i := range sources {
+		foundIDs[i] = sources[i].GetId()
+	}
+	if err := r.loadAttachments(ctx, sources, foundIDs); err != nil {
+		return err
+	}
+	if err := r.loadPolls(ctx, sources, foundIDs); err != nil {
+		return err
+	}
+
+	sourcesByID := make(map[uuid.UUID]*models.Message, len(sources))
+	for i := range sources {
+		sourcesByID[sources[i].GetId()] = &sources[i]
+	}
+
+	for i := range messages {
+		reference, ok := referencesByMessageID[messages[i].GetId()]
+		if !ok || messages[i].IsDeleted() {
+			continue
+		}
+		messages[i].SetLoadedReference(&reference, sourcesByID[reference.GetSourceMessageId()])
+	}
+
+	return nil
+}
+
+// Helper method to load the polls of poll messages with their options and votes
+func (r *PostgresChannelRepository) loadPolls(ctx context.Context, messages []models.Message, messageIDs []uuid.UUID) error {
+	pollQuery := `
+		SELECT message_id, question, multiple_choice, anonymous, closes_at, closed_at, closed_by
+		FROM polls
+		WHERE message_id = ANY($1)
+	`
+	rows, err := r.pool.Query(ctx, pollQuery, messageIDs)
+	if err != nil {
+		return fmt.Errorf("error querying polls for messages: %w", err)
+	}
+	defer rows.Close()
+
+	type pollRecord struct {
+		question                  string
+		multipleChoice, anonymous bool
+		closesAt, closedAt        *time.Time
+		closedBy                  *uuid.UUID
+	}
+	pollsByMessageID := make(map[uuid.UUID]pollRecord)
+	pollIDs := []uuid.UUID{}
+	for rows.Next() {
+		var messageID uuid.UUID
+		var record pollRecord
+		if err := rows.Scan(&messageID, &record.question, &record.multipleChoice, &record.anonymous, &record.closesAt, &record.closedAt, &record.closedBy); err != nil {
+			return fmt.Errorf("error scanning poll: %w", err)
+		}
+		pollsByMessageID[messageID] = record
+		pollIDs = append(pollIDs, messageID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating polls: %w", err)
+	}
+	if len(pollIDs) == 0 {
+		return nil
+	}
+
+	optionRows, err := r.pool.Query(ctx, `SELECT message_id, id, text FROM poll_options WHERE message_id = ANY($1) ORDER BY message_id, position ASC`, pollIDs)
+	if err != nil {
+		return fmt.Errorf("error querying poll options: %w", err)
+	}
+	defer optionRows.Close()
+
+	optionsByMessageID := make(map[uuid.UUID][]models.PollOption)
+	for optionRows.Next() {
+		var messageID, optionID uuid.UUID
+		var text string
+		if err := optionRows.Scan(&messageID, &optionID, &text); err != nil {
+			return fmt.Errorf("error scanning poll option: %w", err)
+		}
+		optionsByMessageID[messageID] = append(optionsByMessageID[messageID], models.RehydratePollOption(optionID, text))
+	}
+	if err := optionRows.Err(); err != nil {
+		return fmt.Errorf("error iterating poll options: %w", err)
+	}
+
+	voteRows, err := r.pool.Query(ctx, `SELECT message_id, option_id, user_id, voted_at FROM poll_votes WHERE message_id = ANY($1) ORDER BY voted_at ASC`, pollIDs)
+	if err != nil {
+		return fmt.Errorf("error querying poll votes: %w", err)
+	}
+	defer voteRows.Close()
+
+	votesByMessageID := make(map[uuid.UUID][]models.PollVote)
+	for voteRows.Next() {
+		var messageID, optionID, userID uuid.UUID
+		var votedAt time.Time
+		if err := voteRows.Scan(&messageID, &optionID, &userID, &votedAt); err != nil {
+			return fmt.Errorf("error scanning poll vote: %w", err)
+		}
+		votesByMessageID[messageID] = append(votesByMessageID[messageID], models.RehydratePollVote(optionID, userID, votedAt))
+	}
+	if err := voteRows.Err(); err != nil {
+		return fmt.Errorf("error iterating poll votes: %w", err)
+	}
+
+	for i := range messages {
+		record, ok := pollsByMessageID[messages[i].GetId()]
+		if !ok || messages[i].IsDeleted() {
+			continue
+		}
+		votes := votesByMessageID[messages[i].GetId()]
+		if votes == nil {
+			votes = []models.PollVote{}
+		}
+		poll := models.RehydratePoll(record.question, optionsByMessageID[messages[i].GetId()], record.multipleChoice, record.anonymous, record.closesAt, record.closedAt, record.closedBy, votes)
+		messages[i].SetLoadedPoll(&poll)
+	}
+
+	return nil
+}
+
+// Helper method to load reactions for messages
+func (r *PostgresChannelRepository) loadReactionsForMessages(ctx context.Context, messages []models.Message, messageIDs []uuid.UUID) error {
+	query := `
+		SELECT id, message_id, user_id, reaction_type, created_at
+		FROM reactions
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, created_at ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, messageIDs)
+	if err != nil {
+		return fmt.Errorf("error querying reactions for messages: %w", err)
+	}
+	defer rows.Close()
+
+	reactionsByMessageID := make(map[uuid.UUID][]models.Reaction)
+	for rows.Next() {
+		var reactionID uuid.UUID
+		var reactionMessageID uuid.UUID
+		var reactionUserID uuid.UUID
+		var reactionType string
+		var reactionTimestamp time.Time
+
+		err := rows.Scan(
+			&reactionID,
+			&reactionMessageID,
+			&reactionUserID,
+			&reactionType,
+			&reactionTimestamp,
+		)
+		if err != nil {
+			return fmt.Errorf("error scanning reaction: %w", err)
+		}
+
+		reaction := models.RehydrateReaction(reactionID, reactionMessageID, reactionUserID, reactionType, reactionTimestamp)
+		reactionsByMessageID[reactionMessageID] = append(reactionsByMessageID[reactionMessageID], reaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating reactions: %w", err)
+	}
+
+	// Attach reactions to messages
+	for i := range messages {
+		if reactions, ok := reactionsByMessageID[messages[i].GetId()]; ok {
+			messages[i].SetLoadedReactions(reactions)
+		}
+	}
+
+	return nil
+}
+
+// Helper method to save members using COPY
+// Every channel is loaded with its members, so an empty list means the last member is gone.
+func (r *PostgresChannelRepository) saveMembers(ctx context.Context, tx pgx.Tx, channelID uuid.UUID, members []models.Member) error {
+	// Delete existing members
+	deleteQuery := `DELETE FROM members WHERE channel_id = $1`
+	_, err := tx.Exec(ctx, deleteQuery, channelID)
+	if err != nil {
+		return fmt.Errorf("error deleting old members for channel %s: %w", channelID, err)
+	}
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	// Insert new members using COPY
+	memberRows := make([][]any, len(members))
+	for i, member := range members {
+		memberRows[i] = []any{
+			channelID,
+			member.GetId(),
+			string(member.GetRole()),
+			member.GetJoinedAt(),
+			member.GetLastRead(),
+			member.GetLastReadMessageId(),
+		}
+	}
+
+	copyCount, err := tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"members"},
+		[]string{"channel_id", "user_id", "role", "joined_at", "last_read", "last_read_message_id"},
+		pgx.CopyFromRows(memberRows),
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting members for channel %s using copy: %w", channelID, err)
+	}
+
+	if int(copyCount) != len(members) {
+		return fmt.Errorf("expected %d members to be inserted for channel %s, but got %d", len(members), channelID, copyCount)
+	}
+
+	return nil
+}
+
+// Helper method to save bans
+// Unlike invites the ban list may legitimately become empty, so it is always replaced.
+func (r *PostgresChannelRepository) saveBans(ctx context.Context, tx pgx.Tx, channelID uuid.UUID, bans []models.ChannelBan) error {
+	deleteQuery := `DELETE FROM channel_bans WHERE channel_id = $1`
+	if _, err := tx.Exec(ctx, deleteQuery, channelID); err != nil {
+		return fmt.Errorf("error deleting old bans for channel %s: %w", channelID, err)
+	}
+
+	insertQuery := `
+		INSERT INTO channel_bans (channel_id, user_id, banned_by, reason, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	for _, ban := range bans {
+		_, err := tx.Exec(ctx, insertQuery,
+			channelID,
+			ban.GetUserId(),
+			ban.GetBannedBy(),
+			ban.GetReason(),
+			ban.GetExpiresAt(),
+			ban.GetCreatedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting ban of user %s for channel %s: %w", ban.GetUserId(), channelID, err)
+		}
+	}
+
+	return nil
+}
+
+// Helper method to save invites
+func (r *PostgresChannelRepository) saveInvites(ctx context.Context, tx pgx.Tx, channelID uuid.UUID, invites []models.ChannelInvite) error {
+	if len(invites) == 0 {
+		return nil
+	}
+
+	// Delete existing invites
+	deleteQuery := `DELETE FROM channel_invites WHERE channel_id = $1`
+	_, err := tx.Exec(ctx, deleteQuery, channelID)
+	if err != nil {
+		return fmt.Errorf("error deleting old invites for channel %s: %w", channelID, err)
+	}
+
+	// Insert new invites
+	insertQuery := `
+		INSERT INTO channel_invites (id, channel_id, created_by_user_id, invite_code, expires_at, max_uses, current_uses, created_at, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	for _, invite := range invites {
+		var maxUses *int
+		if invite.GetMaxUse() != nil {
+			maxUses = invite.GetMaxUse()
+		}
+
+		_, err := tx.Exec(ctx, insertQuery,
+			invite.GetID(),
+			invite.GetChannelID(),
+			invite.GetCreatedByUserID(),
+			invite.GetInviteCode(),
+			invite.GetExpiresAt(),
+			maxUses,
+			invite.GetCurrentUses(),
+			invite.GetCreatedAt(),
+			invite.GetIsActive(),
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting invite %s for channel %s: %w", invite.GetID(), channelID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgresChannelRepository) Save(ctx context.Context, channel *models.Channel) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock and update/insert channel
+	lockQuery := `SELECT version FROM channels WHERE id = $1 FOR UPDATE`
+	var currentVersion int64
+	err = tx.QueryRow(ctx, lockQuery, channel.ID).Scan(&currentVersion)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if channel.Version != 1 {
+				return fmt.Errorf("cannot insert channel %s with version %d: %w", channel.ID, channel.Version, err)
+			}
+			insertQuery := `
+				INSERT INTO channels(id, kind, direct_key, visibility, name, topic, creator_user_id, creation_time, last_message_time, is_archived, version)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			`
+			var directKey *string
+			if channel.DirectKey != "" {
+				directKey = &channel.DirectKey
+			}
+			_, err := tx.Exec(ctx, insertQuery, channel.ID, string(channel.Kind), directKey, string(channel.Visibility), channel.Name, channel.Topic, channel.CreatorUserID, channel.CreationTime, channel.LastMessageTime, channel.IsArchived, channel.Version)
+			if err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+					return fmt.Errorf("direct conversation %s already exists: %w", channel.DirectKey, common.ErrConflict)
+				}
+				return fmt.Errorf("error inserting channel %s: %w", channel.ID, err)
+			}
+		} else {
+			return fmt.Errorf("error locking channel %s: %w", channel.ID, err)
+		}
+	} else {
 		if currentVersion != channel.Version-1 {
 			return fmt.Errorf("concurrency conflict saving channel %s: expected version %d, found %d: %w", channel.ID, currentVersion, channel.Version, common.ErrConcurrency)
 		}
@@ -408,224 +977,1360 @@ func (r *PostgresChannelRepository) Save(ctx context.Context, channel *models.Ch
 			return fmt.Errorf("error updating channel %s: %w", channel.ID, err)
 		}
 
-		if cmdTag.RowsAffected() != 1 {
-			return fmt.Errorf("channel %s update affected %d rows, expected 1 (possible lost update): %w", channel.ID, cmdTag.RowsAffected(), common.ErrConcurrency)
-		}
+		if cmdTag.RowsAffected() != 1 {
+			return fmt.Errorf("channel %s update affected %d rows, expected 1 (possible lost update): %w", channel.ID, cmdTag.RowsAffected(), common.ErrConcurrency)
+		}
+	}
+
+	if err := r.saveMembers(ctx, tx, channel.ID, channel.Members); err != nil {
+		return err
+	}
+
+	if err := r.saveInvites(ctx, tx, channel.ID, channel.Invites); err != nil {
+		return err
+	}
+
+	if err := r.saveBans(ctx, tx, channel.ID, channel.Bans); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction for channel %s: %w", channel.ID, err)
+	}
+	return nil
+}
+
+// FindUserChannels returns the channels the user is currently a member of.
+// The creator is not matched separately, since ownership can move and the creator can leave.
+func (r *PostgresChannelRepository) FindUserChannels(ctx context.Context, userID uuid.UUID) ([]*models.Channel, error) {
+	query := `
+		SELECT ` + channelColumns + `
+		FROM channels c
+		JOIN members m ON c.id = m.channel_id
+		WHERE m.user_id = $1
+		ORDER BY c.last_message_time DESC NULLS LAST
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying for channels for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var channels []*models.Channel
+	for rows.Next() {
+		channel, err := r.scanChannelBasic(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning channel for user %s: %w", userID, err)
+		}
+
+		// Load members for each channel
+		members, err := r.loadMembers(ctx, channel.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		channel.Members = members
+		channel.Messages = []models.Message{}
+		channel.Invites = []models.ChannelInvite{}
+
+		channels = append(channels, channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating channels for user %s: %w", userID, err)
+	}
+
+	return channels, nil
+}
+
+// FindChannelsWithMember returns every channel and direct conversation the user is a member of,
+// with the members and bans needed to save them again
+func (r *PostgresChannelRepository) FindChannelsWithMember(ctx context.Context, userID uuid.UUID) ([]*models.Channel, error) {
+	query := `
+		SELECT ` + channelColumns + `
+		FROM channels c
+		JOIN members m ON c.id = m.channel_id
+		WHERE m.user_id = $1
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying channels of user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var channels []*models.Channel
+	for rows.Next() {
+		channel, err := r.scanChannelBasic(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning channel of user %s: %w", userID, err)
+		}
+		channels = append(channels, channel)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating channels of user %s: %w", userID, err)
+	}
+
+	for _, channel := range channels {
+		members, err := r.loadMembers(ctx, channel.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		bans, err := r.loadBans(ctx, channel.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		channel.Members = members
+		channel.Messages = []models.Message{}
+		channel.Invites = []models.ChannelInvite{}
+		channel.Bans = bans
+	}
+
+	return channels, nil
+}
+
+func (r *PostgresChannelRepository) FindById(ctx context.Context, id uuid.UUID) (*models.Channel, error) {
+	query := `
+		SELECT ` + channelColumns + `
+		FROM channels c
+		WHERE c.id = $1
+	`
+
+	row := r.pool.QueryRow(ctx, query, id)
+	channel, err := r.scanChannelBasic(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("channel with ID %s not found: %w", id, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error scanning channel %s: %w", id, err)
+	}
+
+	// Load members and invites using helper methods
+	members, err := r.loadMembers(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	invites, err := r.loadInvites(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bans, err := r.loadBans(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Members = members
+	channel.Invites = invites
+	channel.Bans = bans
+	channel.Messages = []models.Message{}
+
+	return channel, nil
+}
+
+// FindDirectory returns a page of public, non-archived channels ordered by most recent activity,
+// with channels that have no messages yet last.
+// The search term is matched case-insensitively against the channel name and topic.
+func (r *PostgresChannelRepository) FindDirectory(ctx context.Context, query models.ChannelDirectoryQuery) (*models.ChannelDirectoryPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultDirectoryPageSize
+	}
+	if limit > models.MaxDirectoryPageSize {
+		limit = models.MaxDirectoryPageSize
+	}
+
+	args := []any{string(models.ChannelKindChannel), string(models.ChannelVisibilityPublic)}
+	listed := `kind = $1 AND visibility = $2 AND is_archived = FALSE`
+	where := `c.kind = $1 AND c.visibility = $2 AND c.is_archived = FALSE`
+
+	if query.Search != "" {
+		args = append(args, "%"+escapeLikePattern(query.Search)+"%")
+		where += fmt.Sprintf(" AND (c.name ILIKE $%d OR c.topic ILIKE $%d)", len(args), len(args))
+	}
+
+	// Channels without messages sort last; the sentinel keeps the keyset comparison well defined for them
+	activity := `COALESCE(c.last_message_time, '-infinity'::timestamptz)`
+
+	if query.Before != nil {
+		// Only a channel that is itself listed can be a cursor, so the cursor reveals nothing about other channels
+		var listedCursor bool
+		cursorQuery := `SELECT EXISTS (SELECT 1 FROM channels WHERE id = $3 AND ` + listed + `)`
+		if err := r.pool.QueryRow(ctx, cursorQuery, args[0], args[1], *query.Before).Scan(&listedCursor); err != nil {
+			return nil, fmt.Errorf("error resolving cursor channel %s: %w", *query.Before, err)
+		}
+		if !listedCursor {
+			return nil, fmt.Errorf("cursor channel %s not found: %w", *query.Before, common.ErrNotFound)
+		}
+
+		args = append(args, *query.Before)
+		where += fmt.Sprintf(` AND (%s, c.id) < (
+			SELECT COALESCE(cc.last_message_time, '-infinity'::timestamptz), cc.id FROM channels cc WHERE cc.id = $%d
+		)`, activity, len(args))
+	}
+	args = append(args, limit+1)
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT c.id, c.name, c.topic, c.creation_time, c.last_message_time,
+		       (SELECT COUNT(*) FROM members m WHERE m.channel_id = c.id) AS members_count
+		FROM channels c
+		WHERE %s
+		ORDER BY %s DESC, c.id DESC
+		LIMIT $%d
+	`, where, activity, len(args))
+
+	rows, err := r.pool.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying channel directory: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.ChannelDirectoryEntry
+	for rows.Next() {
+		var entry models.ChannelDirectoryEntry
+		var topic *string
+		var lastMessageTime *time.Time
+		if err := rows.Scan(&entry.ID, &entry.Name, &topic, &entry.CreationTime, &lastMessageTime, &entry.MembersCount); err != nil {
+			return nil, fmt.Errorf("error scanning channel directory entry: %w", err)
+		}
+		if topic != nil {
+			entry.Topic = *topic
+		}
+		if lastMessageTime != nil {
+			entry.LastMessageTime = *lastMessageTime
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating channel directory: %w", err)
+	}
+
+	page := &models.ChannelDirectoryPage{Channels: entries}
+	if len(entries) > limit {
+		page.Channels = entries[:limit]
+		next := page.Channels[limit-1].ID
+		page.NextCursor = &next
+	}
+
+	return page, nil
+}
+
+// escapeLikePattern escapes the LIKE wildcards in a user supplied search term
+func escapeLikePattern(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
+
+// FindByDirectKey returns the direct conversation identified by the given member set key
+func (r *PostgresChannelRepository) FindByDirectKey(ctx context.Context, directKey string) (*models.Channel, error) {
+	query := `
+		SELECT ` + channelColumns + `
+		FROM channels c
+		WHERE c.direct_key = $1
+	`
+
+	row := r.pool.QueryRow(ctx, query, directKey)
+	channel, err := r.scanChannelBasic(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("direct conversation %s not found: %w", directKey, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error scanning direct conversation %s: %w", directKey, err)
+	}
+
+	members, err := r.loadMembers(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bans, err := r.loadBans(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Members = members
+	channel.Invites = []models.ChannelInvite{}
+	channel.Bans = bans
+	channel.Messages = []models.Message{}
+
+	return channel, nil
+}
+
+func (r *PostgresChannelRepository) FindMessages(ctx context.Context, channelID uuid.UUID, query models.MessageQuery) (*models.MessagePage, error) {
+	return r.loadMessages(ctx, channelID, nil, query)
+}
+
+func (r *PostgresChannelRepository) FindThreadReplies(ctx context.Context, channelID, parentMessageID uuid.UUID, query models.MessageQuery) (*models.MessagePage, error) {
+	return r.loadMessages(ctx, channelID, &parentMessageID, query)
+}
+
+// FindMentions returns the non-deleted messages mentioning the user in channels they belong to, newest first.
+// Only query.Before is honoured as a cursor.
+func (r *PostgresChannelRepository) FindMentions(ctx context.Context, userID uuid.UUID, query models.MessageQuery) (*models.MessagePage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultMessagePageSize
+	}
+	if limit > models.MaxMessagePageSize {
+		limit = models.MaxMessagePageSize
+	}
+
+	args := []any{userID}
+	where := `channel_id IN (SELECT channel_id FROM members WHERE user_id = $1)
+		  AND $1 = ANY(content_mentions)
+		  AND deleted_at IS NULL`
+
+	if query.Before != nil {
+		var createdAt time.Time
+		err := r.pool.QueryRow(ctx, `SELECT created_at FROM messages WHERE id = $1`, *query.Before).Scan(&createdAt)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("cursor message %s not found: %w", *query.Before, common.ErrNotFound)
+			}
+			return nil, fmt.Errorf("error resolving cursor message %s: %w", *query.Before, err)
+		}
+		args = append(args, createdAt, *query.Before)
+		where += " AND created_at <= $2 AND (created_at, id) < ($2, $3)"
+	}
+	args = append(args, limit+1)
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d
+	`, where, len(args))
+
+	rows, err := r.pool.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying mentions for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	var messages []models.Message
+	for rows.Next() {
+		msg, err := r.scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning mention for user %s: %w", userID, err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating mentions for user %s: %w", userID, err)
+	}
+
+	page := &models.MessagePage{Messages: messages}
+	if len(messages) > limit {
+		page.Messages = messages[:limit]
+		prev := page.Messages[limit-1].GetId()
+		page.PrevCursor = &prev
+	}
+	if len(page.Messages) == 0 {
+		return page, nil
+	}
+
+	messageIDs := make([]uuid.UUID, len(page.Messages))
+	for i, msg := range page.Messages {
+		messageIDs[i] = msg.GetId()
+	}
+
+	if err := r.loadReactionsForMessages(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadThreadSummaries(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadLinkPreviews(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadPolls(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadReferences(ctx, page.Messages, messageIDs); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// SearchMessages runs a full-text search over the messages of the channels the user is a member of.
+// Results are ordered by rank, then recency; without search terms every match has rank 0.
+func (r *PostgresChannelRepository) SearchMessages(ctx context.Context, userID uuid.UUID, query models.MessageSearchQuery) (*models.MessageSearchPage, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultSearchPageSize
+	}
+	if limit > models.MaxSearchPageSize {
+		limit = models.MaxSearchPageSize
+	}
+
+	args := []any{userID}
+	conditions := []string{
+		"channel_id IN (SELECT channel_id FROM members WHERE user_id = $1)",
+		"deleted_at IS NULL",
+	}
+	rankExpr := "0::real"
+	snippetExpr := "left(content_text, 200)"
+
+	if query.Terms != "" {
+		args = append(args, query.Terms)
+		tsQuery := fmt.Sprintf("websearch_to_tsquery('english', $%d)", len(args))
+		conditions = append(conditions, "search_vector @@ "+tsQuery)
+		rankExpr = fmt.Sprintf("ts_rank(search_vector, %s)", tsQuery)
+
+		args = append(args, searchHeadlineOptions)
+		snippetExpr = fmt.Sprintf("ts_headline('english', content_text, %s, $%d)", tsQuery, len(args))
+	}
+	if len(query.FromUserIDs) > 0 {
+		args = append(args, query.FromUserIDs)
+		conditions = append(conditions, fmt.Sprintf("sender_user_id = ANY($%d)", len(args)))
+	}
+	if len(query.ChannelNames) > 0 {
+		args = append(args, query.ChannelNames)
+		conditions = append(conditions, fmt.Sprintf("channel_id IN (SELECT id FROM channels WHERE lower(name) = ANY($%d))", len(args)))
+	}
+	if query.HasLink {
+		conditions = append(conditions, "cardinality(content_link) > 0")
+	}
+	if query.IsThread {
+		conditions = append(conditions, `(parent_message_id IS NOT NULL
+		  OR EXISTS (SELECT 1 FROM messages replies WHERE replies.parent_message_id = messages.id AND replies.deleted_at IS NULL))`)
+	}
+	if query.Before != nil {
+		args = append(args, *query.Before)
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+	if query.After != nil {
+		args = append(args, *query.After)
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+
+	cursorCondition := "TRUE"
+	if query.Cursor != nil {
+		args = append(args, query.Cursor.Rank, query.Cursor.CreatedAt, query.Cursor.ID)
+		cursorCondition = fmt.Sprintf("(search_rank, created_at, id) < ($%d::real, $%d, $%d)", len(args)-2, len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT `+messageColumns+`, search_rank, %s
+		FROM (
+			SELECT *, %s AS search_rank
+			FROM messages
+			WHERE %s
+		) messages
+		WHERE %s
+		ORDER BY search_rank DESC, created_at DESC, id DESC
+		LIMIT $%d
+	`, snippetExpr, rankExpr, strings.Join(conditions, "\n\t\t\t  AND "), cursorCondition, len(args))
+
+	rows, err := r.pool.Query(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error searching messages for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	results := []models.MessageSearchResult{}
+	for rows.Next() {
+		var rank float32
+		var snippet string
+		msg, err := r.scanMessage(rows, &rank, &snippet)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning search result for user %s: %w", userID, err)
+		}
+		results = append(results, models.MessageSearchResult{
+			Message: msg,
+			Rank:    rank,
+			Snippet: formatSearchSnippet(snippet),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search results for user %s: %w", userID, err)
+	}
+
+	page := &models.MessageSearchPage{Results: results}
+	if len(results) > limit {
+		page.Results = results[:limit]
+		last := page.Results[limit-1]
+		page.NextCursor = &models.MessageSearchCursor{
+			Rank:      last.Rank,
+			CreatedAt: last.Message.GetCreatedAt(),
+			ID:        last.Message.GetId(),
+		}
+	}
+	if len(page.Results) == 0 {
+		return page, nil
+	}
+
+	messages := make([]models.Message, len(page.Results))
+	messageIDs := make([]uuid.UUID, len(page.Results))
+	for i, result := range page.Results {
+		messages[i] = result.Message
+		messageIDs[i] = result.Message.GetId()
+	}
+	if err := r.loadReactionsForMessages(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadThreadSummaries(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadLinkPreviews(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadPolls(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadReferences(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	for i := range page.Results {
+		page.Results[i].Message = messages[i]
+	}
+
+	return page, nil
+}
+
+// UpdateReadMarker stores a member's read marker, refusing to move it backwards
+func (r *PostgresChannelRepository) UpdateReadMarker(ctx context.Context, channelID uuid.UUID, member *models.Member) error {
+	query := `
+		UPDATE members
+		SET last_read = $3, last_read_message_id = $4
+		WHERE channel_id = $1 AND user_id = $2 AND (last_read IS NULL OR last_read < $3)
+	`
+
+	_, err := r.pool.Exec(ctx, query, channelID, member.GetId(), member.GetLastRead(), member.GetLastReadMessageId())
+	if err != nil {
+		return fmt.Errorf("error updating read marker for user %s in channel %s: %w", member.GetId(), channelID, err)
+	}
+
+	return nil
+}
+
+// FindUnreadCounts returns, per channel the user belongs to, how many messages from others arrived after
+// their read marker and how many of those mention them
+func (r *PostgresChannelRepository) FindUnreadCounts(ctx context.Context, userID uuid.UUID) (map[uuid.UUID]models.UnreadCounts, error) {
+	query := `
+		SELECT mb.channel_id,
+		       COUNT(m.id),
+		       COUNT(m.id) FILTER (WHERE $1 = ANY(m.content_mentions))
+		FROM members mb
+		LEFT JOIN messages m
+		       ON m.channel_id = mb.channel_id
+		      AND m.created_at > COALESCE(mb.last_read, mb.joined_at)
+		      AND m.deleted_at IS NULL
+		      AND m.sender_user_id IS DISTINCT FROM $1
+		WHERE mb.user_id = $1
+		GROUP BY mb.channel_id
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying unread counts for user %s: %w", userID, err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]models.UnreadCounts)
+	for rows.Next() {
+		var channelID uuid.UUID
+		var unread models.UnreadCounts
+		if err := rows.Scan(&channelID, &unread.Unread, &unread.Mentions); err != nil {
+			return nil, fmt.Errorf("error scanning unread counts for user %s: %w", userID, err)
+		}
+		counts[channelID] = unread
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unread counts for user %s: %w", userID, err)
+	}
+
+	return counts, nil
+}
+
+func (r *PostgresChannelRepository) FindMessageByID(ctx context.Context, channelID, messageID uuid.UUID) (*models.Message, error) {
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE id = $1 AND channel_id = $2
+	`
+
+	message, err := r.scanMessage(r.pool.QueryRow(ctx, query, messageID, channelID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("message with ID %s not found in channel %s: %w", messageID, channelID, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error scanning message %s: %w", messageID, err)
+	}
+
+	messages := []models.Message{message}
+	if err := r.loadReactionsForMessages(ctx, messages, []uuid.UUID{messageID}); err != nil {
+		return nil, err
+	}
+	if err := r.loadThreadSummaries(ctx, messages, []uuid.UUID{messageID}); err != nil {
+		return nil, err
+	}
+	if err := r.loadLinkPreviews(ctx, messages, []uuid.UUID{messageID}); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, messages, []uuid.UUID{messageID}); err != nil {
+		return nil, err
+	}
+	if err := r.loadPolls(ctx, messages, []uuid.UUID{messageID}); err != nil {
+		return nil, err
+	}
+	if err := r.loadReferences(ctx, messages, []uuid.UUID{messageID}); err != nil {
+		return nil, err
+	}
+
+	return &messages[0], nil
+}
+
+// FindMessagesByIDs loads the given messages with their reactions and thread summaries; missing IDs are skipped
+func (r *PostgresChannelRepository) FindMessagesByIDs(ctx context.Context, messageIDs []uuid.UUID) ([]models.Message, error) {
+	messages := []models.Message{}
+	if len(messageIDs) == 0 {
+		return messages, nil
+	}
+
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE id = ANY($1)
+	`
+
+	rows, err := r.pool.Query(ctx, query, messageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error querying messages by ID: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		msg, err := r.scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning message: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating messages by ID: %w", err)
+	}
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	foundIDs := make([]uuid.UUID, len(messages))
+	for i, msg := range messages {
+		foundIDs[i] = msg.GetId()
+	}
+	if err := r.loadReactionsForMessages(ctx, messages, foundIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadThreadSummaries(ctx, messages, foundIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadLinkPreviews(ctx, messages, foundIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, messages, foundIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadPolls(ctx, messages, foundIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadReferences(ctx, messages, foundIDs); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *PostgresChannelRepository) FindByInviteCode(ctx context.Context, inviteCode string) (*models.Channel, error) {
+	query := `
+		SELECT ` + channelColumns + `
+		FROM channels c
+		JOIN channel_invites ci ON c.id = ci.channel_id
+		WHERE ci.invite_code = $1
+	`
+
+	row := r.pool.QueryRow(ctx, query, inviteCode)
+	channel, err := r.scanChannelBasic(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("channel with invite code %s not found: %w", inviteCode, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error scanning channel for invite code %s: %w", inviteCode, err)
+	}
+
+	// Load members and invites
+	members, err := r.loadMembers(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	invites, err := r.loadInvites(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bans, err := r.loadBans(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Members = members
+	channel.Invites = invites
+	channel.Bans = bans
+	channel.Messages = []models.Message{}
+
+	return channel, nil
+}
+
+func (r *PostgresChannelRepository) FindByInviteID(ctx context.Context, inviteID uuid.UUID) (*models.Channel, error) {
+	query := `
+		SELECT ` + channelColumns + `
+		FROM channels c
+		JOIN channel_invites ci ON c.id = ci.channel_id
+		WHERE ci.id = $1
+	`
+
+	row := r.pool.QueryRow(ctx, query, inviteID)
+	channel, err := r.scanChannelBasic(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("channel with invite ID %s not found: %w", inviteID, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error scanning channel for invite ID %s: %w", inviteID, err)
+	}
+
+	// Load members and invites
+	members, err := r.loadMembers(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	invites, err := r.loadInvites(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bans, err := r.loadBans(ctx, channel.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.Members = members
+	channel.Invites = invites
+	channel.Bans = bans
+	channel.Messages = []models.Message{}
+
+	return channel, nil
+}
+
+func (r *PostgresChannelRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM channels WHERE id = $1`
+	cmdTag, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("error deleting channel %s: %w", id, err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("channel with ID %s was not found for deletion: %w", id, common.ErrNotFound)
+	}
+	return nil
+}
+
+// SaveMessage inserts a new message and links its attachments to it in one transaction.
+// common.ErrConflict is returned when an attachment was sent or removed in the meantime.
+func (r *PostgresChannelRepository) SaveMessage(ctx context.Context, message *models.Message) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO messages (
+			id, channel_id, sender_user_id, integration_id,
+			content_text, content_mentions, content_link, content_formatted, content_rich_text,
+			created_at, parent_message_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	var senderID, integrationID, parentID interface{}
+	if message.GetSenderUserId() != nil {
+		senderID = *message.GetSenderUserId()
+	}
+	if message.GetIntegrationId() != nil {
+		integrationID = *message.GetIntegrationId()
+	}
+	if message.GetParentMessageId() != nil {
+		parentID = *message.GetParentMessageId()
+	}
+
+	_, err = tx.Exec(ctx, query,
+		message.GetId(),
+		message.GetChannelId(),
+		senderID,
+		integrationID,
+		message.GetContent().GetText(),
+		message.GetContent().GetMentions(),
+		message.GetContent().GetLinks(),
+		message.GetContent().GetIsFormatted(),
+		message.GetContent().GetRichText(),
+		message.GetCreatedAt(),
+		parentID,
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting message %s for channel %s: %w", message.GetId(), message.GetChannelId(), err)
+	}
+
+	attachQuery := `
+		UPDATE attachments SET message_id = $1, position = $2
+		WHERE id = $3 AND channel_id = $4 AND uploader_id = $5 AND message_id IS NULL
+	`
+	for i, attachment := range message.GetAttachments() {
+		cmdTag, err := tx.Exec(ctx, attachQuery, message.GetId(), i, attachment.GetId(), message.GetChannelId(), attachment.GetUploaderId())
+		if err != nil {
+			return fmt.Errorf("error attaching %s to message %s: %w", attachment.GetId(), message.GetId(), err)
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return fmt.Errorf("attachment %s is no longer available: %w", attachment.GetId(), common.ErrConflict)
+		}
+	}
+
+	if reference := message.GetReference(); reference != nil {
+		referenceQuery := `
+			INSERT INTO message_references (message_id, kind, source_message_id, source_channel_id)
+			VALUES ($1, $2, $3, $4)
+		`
+		if _, err := tx.Exec(ctx, referenceQuery, message.GetId(), string(reference.GetKind()), reference.GetSourceMessageId(), reference.GetSourceChannelId()); err != nil {
+			return fmt.Errorf("error inserting reference of message %s: %w", message.GetId(), err)
+		}
+	}
+
+	if poll := message.GetPoll(); poll != nil {
+		pollQuery := `
+			INSERT INTO polls (message_id, channel_id, question, multiple_choice, anonymous, closes_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+		if _, err := tx.Exec(ctx, pollQuery, message.GetId(), message.GetChannelId(), poll.GetQuestion(), poll.IsMultipleChoice(), poll.IsAnonymous(), poll.GetClosesAt()); err != nil {
+			return fmt.Errorf("error inserting poll for message %s: %w", message.GetId(), err)
+		}
+		for i, option := range poll.GetOptions() {
+			if _, err := tx.Exec(ctx, `INSERT INTO poll_options (id, message_id, position, text) VALUES ($1, $2, $3, $4)`, option.GetId(), message.GetId(), i, option.GetText()); err != nil {
+				return fmt.Errorf("error inserting option of poll %s: %w", message.GetId(), err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresChannelRepository) UpdateMessage(ctx context.Context, message *models.Message, revision *models.MessageRevision) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if revision != nil {
+		revisionQuery := `
+			INSERT INTO message_revisions (
+				id, message_id, content_text, content_mentions, content_link, content_formatted,
+				edited_by, edited_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`
+		previous := revision.GetPreviousContent()
+		_, err := tx.Exec(ctx, revisionQuery,
+			revision.GetId(),
+			revision.GetMessageId(),
+			previous.GetText(),
+			previous.GetMentions(),
+			previous.GetLinks(),
+			previous.GetIsFormatted(),
+			revision.GetEditedBy(),
+			revision.GetEditedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting revision for message %s: %w", message.GetId(), err)
+		}
+	}
+
+	updateQuery := `
+		UPDATE messages
+		SET content_text = $1, content_mentions = $2, content_link = $3, content_formatted = $4, content_rich_text = $5,
+		    edited_at = $6
+		WHERE id = $7
+	`
+	cmdTag, err := tx.Exec(ctx, updateQuery,
+		message.GetContent().GetText(),
+		message.GetContent().GetMentions(),
+		message.GetContent().GetLinks(),
+		message.GetContent().GetIsFormatted(),
+		message.GetContent().GetRichText(),
+		message.GetEditedAt(),
+		message.GetId(),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating message %s: %w", message.GetId(), err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("message with ID %s was not found for update: %w", message.GetId(), common.ErrNotFound)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction for message %s: %w", message.GetId(), err)
+	}
+	return nil
+}
+
+func (r *PostgresChannelRepository) MarkMessageDeleted(ctx context.Context, message *models.Message) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The row is kept as a tombstone so replies keep their parent
+	updateQuery := `
+		UPDATE messages
+		SET content_text = '', content_mentions = '{}', content_link = '{}', content_formatted = FALSE,
+		    content_rich_text = NULL, deleted_at = $1, deleted_by = $2, pinned_at = NULL, pinned_by = NULL
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+	cmdTag, err := tx.Exec(ctx, updateQuery, message.GetDeletedAt(), message.GetDeletedBy(), message.GetId())
+	if err != nil {
+		return fmt.Errorf("error marking message %s as deleted: %w", message.GetId(), err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("message with ID %s was not found for deletion: %w", message.GetId(), common.ErrNotFound)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM reactions WHERE message_id = $1`, message.GetId()); err != nil {
+		return fmt.Errorf("error deleting reactions for message %s: %w", message.GetId(), err)
 	}
 
-	if err := r.saveMembers(ctx, tx, channel.ID, channel.Members); err != nil {
-		return err
+	if _, err := tx.Exec(ctx, `DELETE FROM message_revisions WHERE message_id = $1`, message.GetId()); err != nil {
+		return fmt.Errorf("error deleting revisions for message %s: %w", message.GetId(), err)
 	}
 
-	if err := r.saveInvites(ctx, tx, channel.ID, channel.Invites); err != nil {
-		return err
+	if _, err := tx.Exec(ctx, `DELETE FROM message_link_previews WHERE message_id = $1`, message.GetId()); err != nil {
+		return fmt.Errorf("error deleting link previews for message %s: %w", message.GetId(), err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM polls WHERE message_id = $1`, message.GetId()); err != nil {
+		return fmt.Errorf("error deleting poll for message %s: %w", message.GetId(), err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM message_references WHERE message_id = $1`, message.GetId()); err != nil {
+		return fmt.Errorf("error deleting reference of message %s: %w", message.GetId(), err)
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("error committing transaction for channel %s: %w", channel.ID, err)
+		return fmt.Errorf("error committing transaction for message %s: %w", message.GetId(), err)
 	}
 	return nil
 }
 
-func (r *PostgresChannelRepository) FindUserChannels(ctx context.Context, userID uuid.UUID) ([]*models.Channel, error) {
-	query := `
-		SELECT DISTINCT c.id, c.name, c.topic, c.creator_user_id, c.creation_time, c.last_message_time, c.is_archived, c.version
-		FROM channels c
-		LEFT JOIN members m ON c.id = m.channel_id
-		WHERE c.creator_user_id = $1 OR m.user_id = $1
-		ORDER BY c.last_message_time DESC NULLS LAST
-	`
+// IsChannelMember reports whether the user is a member of the channel
+func (r *PostgresChannelRepository) IsChannelMember(ctx context.Context, channelID, userID uuid.UUID) (bool, error) {
+	var isMember bool
+	query := `SELECT EXISTS (SELECT 1 FROM members WHERE channel_id = $1 AND user_id = $2)`
+	if err := r.pool.QueryRow(ctx, query, channelID, userID).Scan(&isMember); err != nil {
+		return false, fmt.Errorf("error checking membership of user %s in channel %s: %w", userID, channelID, err)
+	}
+	return isMember, nil
+}
 
-	rows, err := r.pool.Query(ctx, query, userID)
+// FindMemberIDs returns the IDs of the channel's members without loading the rest of the channel
+func (r *PostgresChannelRepository) FindMemberIDs(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.pool.Query(ctx, `SELECT user_id FROM members WHERE channel_id = $1`, channelID)
 	if err != nil {
-		return nil, fmt.Errorf("error querying for channels for user %s: %w", userID, err)
+		return nil, fmt.Errorf("error querying member IDs for channel %s: %w", channelID, err)
 	}
 	defer rows.Close()
 
-	var channels []*models.Channel
+	memberIDs := []uuid.UUID{}
 	for rows.Next() {
-		channel, err := r.scanChannelBasic(rows)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning channel for user %s: %w", userID, err)
-		}
-
-		// Load members for each channel
-		members, err := r.loadMembers(ctx, channel.ID)
-		if err != nil {
-			return nil, err
+		var memberID uuid.UUID
+		if err := rows.Scan(&memberID); err != nil {
+			return nil, fmt.Errorf("error scanning member ID for channel %s: %w", channelID, err)
 		}
-
-		channel.Members = members
-		channel.Messages = []models.Message{}
-		channel.Invites = []models.ChannelInvite{}
-
-		channels = append(channels, channel)
+		memberIDs = append(memberIDs, memberID)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating channels for user %s: %w", userID, err)
+		return nil, fmt.Errorf("error iterating member IDs for channel %s: %w", channelID, err)
 	}
 
-	return channels, nil
+	return memberIDs, nil
 }
 
-func (r *PostgresChannelRepository) FindById(ctx context.Context, id uuid.UUID) (*models.Channel, error) {
-	query := `
-		SELECT id, name, topic, creator_user_id, creation_time, last_message_time, is_archived, version
-		FROM channels
-		WHERE id = $1
-	`
-
-	row := r.pool.QueryRow(ctx, query, id)
-	channel, err := r.scanChannelBasic(row)
+// ReplacePollVotes stores the user's current votes in a poll, replacing their earlier ones.
+// common.ErrConflict is returned when the poll was closed in the meantime.
+func (r *PostgresChannelRepository) ReplacePollVotes(ctx context.Context, message *models.Message, userID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the poll orders concurrent votes and closing
+	lockQuery := `
+		SELECT 1 FROM polls
+		WHERE message_id = $1 AND closed_at IS NULL AND (closes_at IS NULL OR closes_at > now())
+		FOR UPDATE
+	`
+	var open int
+	if err := tx.QueryRow(ctx, lockQuery, message.GetId()).Scan(&open); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("channel with ID %s not found: %w", id, common.ErrNotFound)
+			return fmt.Errorf("poll %s is closed: %w", message.GetId(), common.ErrConflict)
 		}
-		return nil, fmt.Errorf("error scanning channel %s: %w", id, err)
+		return fmt.Errorf("error locking poll %s: %w", message.GetId(), err)
 	}
 
-	// Load members and invites using helper methods
-	members, err := r.loadMembers(ctx, channel.ID)
-	if err != nil {
-		return nil, err
+	if _, err := tx.Exec(ctx, `DELETE FROM poll_votes WHERE message_id = $1 AND user_id = $2`, message.GetId(), userID); err != nil {
+		return fmt.Errorf("error deleting votes of user %s in poll %s: %w", userID, message.GetId(), err)
 	}
 
-	invites, err := r.loadInvites(ctx, channel.ID)
-	if err != nil {
-		return nil, err
+	for _, vote := range message.GetPoll().GetVotes() {
+		if vote.GetUserId() != userID {
+			continue
+		}
+		insertQuery := `INSERT INTO poll_votes (message_id, option_id, user_id, voted_at) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(ctx, insertQuery, message.GetId(), vote.GetOptionId(), userID, vote.GetVotedAt()); err != nil {
+			return fmt.Errorf("error inserting vote of user %s in poll %s: %w", userID, message.GetId(), err)
+		}
 	}
 
-	channel.Members = members
-	channel.Invites = invites
-	channel.Messages = []models.Message{}
-
-	return channel, nil
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
 }
 
-func (r *PostgresChannelRepository) FindMessages(ctx context.Context, channelID uuid.UUID, limit int, offset int) ([]models.Message, error) {
-	return r.loadMessages(ctx, channelID, limit, offset)
+// ClosePoll records that a poll was closed. common.ErrConflict is returned when it was already closed.
+func (r *PostgresChannelRepository) ClosePoll(ctx context.Context, message *models.Message) error {
+	poll := message.GetPoll()
+	query := `
+		UPDATE polls SET closed_at = $2, closed_by = $3
+		WHERE message_id = $1 AND closed_at IS NULL
+	`
+	cmdTag, err := r.pool.Exec(ctx, query, message.GetId(), poll.GetClosedAt(), poll.GetClosedBy())
+	if err != nil {
+		return fmt.Errorf("error closing poll %s: %w", message.GetId(), err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("poll %s is already closed: %w", message.GetId(), common.ErrConflict)
+	}
+	return nil
 }
 
-func (r *PostgresChannelRepository) FindByInviteCode(ctx context.Context, inviteCode string) (*models.Channel, error) {
+// FindPollsDueToClose returns the message IDs of up to limit open polls whose closing time is before now
+func (r *PostgresChannelRepository) FindPollsDueToClose(ctx context.Context, now time.Time, limit int) ([]uuid.UUID, error) {
 	query := `
-		SELECT c.id, c.name, c.topic, c.creator_user_id, c.creation_time, c.last_message_time, c.is_archived, c.version
-		FROM channels c
-		JOIN channel_invites ci ON c.id = ci.channel_id
-		WHERE ci.invite_code = $1
+		SELECT p.message_id
+		FROM polls p
+		JOIN messages m ON m.id = p.message_id
+		WHERE p.closed_at IS NULL AND p.closes_at <= $1 AND m.deleted_at IS NULL
+		ORDER BY p.closes_at ASC
+		LIMIT $2
 	`
 
-	row := r.pool.QueryRow(ctx, query, inviteCode)
-	channel, err := r.scanChannelBasic(row)
+	rows, err := r.pool.Query(ctx, query, now, limit)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("channel with invite code %s not found: %w", inviteCode, common.ErrNotFound)
+		return nil, fmt.Errorf("error querying polls due to close: %w", err)
+	}
+	defer rows.Close()
+
+	messageIDs := []uuid.UUID{}
+	for rows.Next() {
+		var messageID uuid.UUID
+		if err := rows.Scan(&messageID); err != nil {
+			return nil, fmt.Errorf("error scanning poll: %w", err)
 		}
-		return nil, fmt.Errorf("error scanning channel for invite code %s: %w", inviteCode, err)
+		messageIDs = append(messageIDs, messageID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating polls due to close: %w", err)
 	}
+	return messageIDs, nil
+}
 
-	// Load members and invites
-	members, err := r.loadMembers(ctx, channel.ID)
+// FindUnsentAttachments returns the uploads with the given IDs that were not sent with a message yet,
+// in the order of the IDs. Missing and already sent uploads are left out.
+func (r *PostgresChannelRepository) FindUnsentAttachments(ctx context.Context, channelID uuid.UUID, attachmentIDs []uuid.UUID) ([]models.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments a
+		WHERE a.channel_id = $1 AND a.id = ANY($2) AND a.message_id IS NULL
+	`
+
+	rows, err := r.pool.Query(ctx, query, channelID, attachmentIDs)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error querying attachments for channel %s: %w", channelID, err)
 	}
+	defer rows.Close()
 
-	invites, err := r.loadInvites(ctx, channel.ID)
+	attachmentsByID := make(map[uuid.UUID]models.Attachment)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning attachment: %w", err)
+		}
+		attachmentsByID[attachment.GetId()] = *attachment
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating attachments: %w", err)
+	}
+
+	attachments := []models.Attachment{}
+	for _, id := range attachmentIDs {
+		if attachment, ok := attachmentsByID[id]; ok {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+// ReplaceLinkPreviews stores the previews of a message's links, replacing any earlier ones.
+// Previews are only stored while the message still has the links they were fetched for,
+// so an unfurl that finishes after the message was edited or deleted returns common.ErrConflict.
+func (r *PostgresChannelRepository) ReplaceLinkPreviews(ctx context.Context, message *models.Message) error {
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("error beginning transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	channel.Members = members
-	channel.Invites = invites
-	channel.Messages = []models.Message{}
+	lockQuery := `
+		SELECT id FROM messages
+		WHERE id = $1 AND deleted_at IS NULL AND content_link = $2
+		FOR UPDATE
+	`
+	var lockedID uuid.UUID
+	err = tx.QueryRow(ctx, lockQuery, message.GetId(), message.GetContent().GetLinks()).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("links of message %s changed since they were unfurled: %w", message.GetId(), common.ErrConflict)
+	}
+	if err != nil {
+		return fmt.Errorf("error locking message %s: %w", message.GetId(), err)
+	}
 
-	return channel, nil
-}
+	if _, err := tx.Exec(ctx, `DELETE FROM message_link_previews WHERE message_id = $1`, message.GetId()); err != nil {
+		return fmt.Errorf("error deleting link previews for message %s: %w", message.GetId(), err)
+	}
 
-func (r *PostgresChannelRepository) FindByInviteID(ctx context.Context, inviteID uuid.UUID) (*models.Channel, error) {
-	query := `
-		SELECT c.id, c.name, c.topic, c.creator_user_id, c.creation_time, c.last_message_time, c.is_archived, c.version
-		FROM channels c
-		JOIN channel_invites ci ON c.id = ci.channel_id
-		WHERE ci.id = $1
+	insertQuery := `
+		INSERT INTO message_link_previews (message_id, position, url, title, description, site_name, image_url, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
+	for i, preview := range message.GetLinkPreviews() {
+		_, err := tx.Exec(ctx, insertQuery,
+			message.GetId(),
+			i,
+			preview.GetUrl(),
+			preview.GetTitle(),
+			preview.GetDescription(),
+			preview.GetSiteName(),
+			preview.GetImageUrl(),
+			preview.GetFetchedAt(),
+		)
+		if err != nil {
+			return fmt.Errorf("error inserting link preview for message %s: %w", message.GetId(), err)
+		}
+	}
 
-	row := r.pool.QueryRow(ctx, query, inviteID)
-	channel, err := r.scanChannelBasic(row)
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction for message %s: %w", message.GetId(), err)
+	}
+	return nil
+}
+
+// PinMessageWithinLimit stores a new pin unless the channel already has maxPins pinned messages.
+// common.ErrConflict is returned when the limit was reached in the meantime.
+func (r *PostgresChannelRepository) PinMessageWithinLimit(ctx context.Context, message *models.Message, maxPins int) error {
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the channel orders concurrent pins, so the count below cannot go stale before the update
+	var channelID uuid.UUID
+	lockQuery := `SELECT id FROM channels WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, lockQuery, message.GetChannelId()).Scan(&channelID); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("channel with invite ID %s not found: %w", inviteID, common.ErrNotFound)
+			return fmt.Errorf("channel with ID %s was not found: %w", message.GetChannelId(), common.ErrNotFound)
 		}
-		return nil, fmt.Errorf("error scanning channel for invite ID %s: %w", inviteID, err)
+		return fmt.Errorf("error locking channel %s: %w", message.GetChannelId(), err)
 	}
 
-	// Load members and invites
-	members, err := r.loadMembers(ctx, channel.ID)
-	if err != nil {
-		return nil, err
+	var pinnedCount int
+	countQuery := `SELECT COUNT(*) FROM messages WHERE channel_id = $1 AND pinned_at IS NOT NULL`
+	if err := tx.QueryRow(ctx, countQuery, channelID).Scan(&pinnedCount); err != nil {
+		return fmt.Errorf("error counting pinned messages for channel %s: %w", channelID, err)
+	}
+	if pinnedCount >= maxPins {
+		return fmt.Errorf("channel %s has %d pinned messages: %w", channelID, pinnedCount, common.ErrConflict)
 	}
 
-	invites, err := r.loadInvites(ctx, channel.ID)
+	updateQuery := `
+		UPDATE messages
+		SET pinned_at = $1, pinned_by = $2
+		WHERE id = $3 AND deleted_at IS NULL AND pinned_at IS NULL
+	`
+	cmdTag, err := tx.Exec(ctx, updateQuery, message.GetPinnedAt(), message.GetPinnedBy(), message.GetId())
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("error updating pin for message %s: %w", message.GetId(), err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("message with ID %s was not found for pinning: %w", message.GetId(), common.ErrNotFound)
 	}
 
-	channel.Members = members
-	channel.Invites = invites
-	channel.Messages = []models.Message{}
-
-	return channel, nil
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
 }
 
-func (r *PostgresChannelRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM channels WHERE id = $1`
-	cmdTag, err := r.pool.Exec(ctx, query, id)
+// UpdateMessagePin stores the pin state of a message; tombstones cannot be pinned
+func (r *PostgresChannelRepository) UpdateMessagePin(ctx context.Context, message *models.Message) error {
+	query := `
+		UPDATE messages
+		SET pinned_at = $1, pinned_by = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+	cmdTag, err := r.pool.Exec(ctx, query, message.GetPinnedAt(), message.GetPinnedBy(), message.GetId())
 	if err != nil {
-		return fmt.Errorf("error deleting channel %s: %w", id, err)
+		return fmt.Errorf("error updating pin for message %s: %w", message.GetId(), err)
 	}
-
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("channel with ID %s was not found for deletion: %w", id, common.ErrNotFound)
+		return fmt.Errorf("message with ID %s was not found for pinning: %w", message.GetId(), common.ErrNotFound)
 	}
 	return nil
 }
 
-func (r *PostgresChannelRepository) SaveMessage(ctx context.Context, message *models.Message) error {
+func (r *PostgresChannelRepository) CountPinnedMessages(ctx context.Context, channelID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM messages WHERE channel_id = $1 AND pinned_at IS NOT NULL`
+
+	var count int
+	if err := r.pool.QueryRow(ctx, query, channelID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("error counting pinned messages for channel %s: %w", channelID, err)
+	}
+	return count, nil
+}
+
+// FindPinnedMessages returns the pinned messages of a channel, most recently pinned first
+func (r *PostgresChannelRepository) FindPinnedMessages(ctx context.Context, channelID uuid.UUID) ([]models.Message, error) {
 	query := `
-		INSERT INTO messages (
-			id, channel_id, sender_user_id, integration_id,
-			content_text, content_mentions, content_link, content_formatted,
-			created_at, parent_message_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE channel_id = $1 AND pinned_at IS NOT NULL
+		ORDER BY pinned_at DESC, id DESC
 	`
 
-	var senderID, integrationID, parentID interface{}
-	if message.GetSenderUserId() != nil {
-		senderID = *message.GetSenderUserId()
+	rows, err := r.pool.Query(ctx, query, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying pinned messages for channel %s: %w", channelID, err)
 	}
-	if message.GetIntegrationId() != nil {
-		integrationID = *message.GetIntegrationId()
+	defer rows.Close()
+
+	messages := []models.Message{}
+	for rows.Next() {
+		msg, err := r.scanMessage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning pinned message for channel %s: %w", channelID, err)
+		}
+		messages = append(messages, msg)
 	}
-	if message.GetParentMessageId() != nil {
-		parentID = *message.GetParentMessageId()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pinned messages for channel %s: %w", channelID, err)
+	}
+	if len(messages) == 0 {
+		return messages, nil
 	}
 
-	_, err := r.pool.Exec(ctx, query,
-		message.GetId(),
-		message.GetChannelId(),
-		senderID,
-		integrationID,
-		message.GetContent().GetText(),
-		message.GetContent().GetMentions(),
-		message.GetContent().GetLinks(),
-		message.GetContent().GetIsFormatted(),
-		message.GetCreatedAt(),
-		parentID,
-	)
+	messageIDs := make([]uuid.UUID, len(messages))
+	for i, msg := range messages {
+		messageIDs[i] = msg.GetId()
+	}
+	if err := r.loadReactionsForMessages(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadThreadSummaries(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadLinkPreviews(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadAttachments(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadPolls(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+	if err := r.loadReferences(ctx, messages, messageIDs); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *PostgresChannelRepository) FindMessageRevisions(ctx context.Context, messageID uuid.UUID) ([]models.MessageRevision, error) {
+	query := `
+		SELECT id, message_id, content_text, content_mentions, content_link, content_formatted,
+		       edited_by, edited_at
+		FROM message_revisions
+		WHERE message_id = $1
+		ORDER BY edited_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, messageID)
 	if err != nil {
-		return fmt.Errorf("error inserting message %s for channel %s: %w", message.GetId(), message.GetChannelId(), err)
+		return nil, fmt.Errorf("error querying revisions for message %s: %w", messageID, err)
 	}
-	return nil
+	defer rows.Close()
+
+	var revisions []models.MessageRevision
+	for rows.Next() {
+		var revisionID, msgID, editedBy uuid.UUID
+		var text string
+		var mentions []uuid.UUID
+		var links []string
+		var isFormatted bool
+		var editedAt time.Time
+
+		err := rows.Scan(&revisionID, &msgID, &text, &mentions, &links, &isFormatted, &editedBy, &editedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning revision for message %s: %w", messageID, err)
+		}
+		content := models.RehydrateMessageContent(text, mentions, links, isFormatted, nil)
+		revisions = append(revisions, models.RehydrateMessageRevision(revisionID, msgID, content, editedBy, editedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating revisions for message %s: %w", messageID, err)
+	}
+	return revisions, nil
 }
 
 func (r *PostgresChannelRepository) SaveReaction(ctx context.Context, reaction *models.Reaction) error {
@@ -705,3 +2410,10 @@ func (r *PostgresChannelRepository) FindReactionsByMessageID(ctx context.Context
 	}
 	return reactions, nil
 }
+
+// formatSearchSnippet escapes a ts_headline snippet and turns its match markers into <mark> tags
+func formatSearchSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, searchHighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, searchHighlightStop, "</mark>")
+}
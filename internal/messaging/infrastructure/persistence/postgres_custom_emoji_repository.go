@@ -0,0 +1,134 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/common"
+)
+
+var _ CustomEmojiRepository = (*PostgresCustomEmojiRepository)(nil)
+
+// customEmojiSelect joins aliases to the emoji they point to, to return the name of the original emoji
+const customEmojiSelect = `
+	SELECT e.id, e.name, e.alias_for, COALESCE(t.name, ''), e.creator_id, e.content_type, e.size, e.storage_key, e.created_at
+	FROM custom_emoji e
+	LEFT JOIN custom_emoji t ON t.id = e.alias_for
+`
+
+type PostgresCustomEmojiRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresCustomEmojiRepository(pool *pgxpool.Pool) *PostgresCustomEmojiRepository {
+	return &PostgresCustomEmojiRepository{
+		pool: pool,
+	}
+}
+
+// Save stores a new emoji or alias. It returns common.ErrConflict when the name is already taken.
+func (r *PostgresCustomEmojiRepository) Save(ctx context.Context, emoji *models.CustomEmoji) error {
+	query := `
+		INSERT INTO custom_emoji (id, name, alias_for, creator_id, content_type, size, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		emoji.GetId(),
+		emoji.GetName(),
+		emoji.GetAliasForId(),
+		emoji.GetCreatorId(),
+		emoji.GetContentType(),
+		emoji.GetSize(),
+		emoji.GetStorageKey(),
+		emoji.GetCreatedAt(),
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return fmt.Errorf("emoji :%s: already exists: %w", emoji.GetName(), common.ErrConflict)
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("emoji :%s: no longer exists: %w", emoji.GetAliasForName(), common.ErrNotFound)
+		}
+		return fmt.Errorf("error inserting emoji %s: %w", emoji.GetId(), err)
+	}
+	return nil
+}
+
+func (r *PostgresCustomEmojiRepository) FindByName(ctx context.Context, name string) (*models.CustomEmoji, error) {
+	emoji, err := scanCustomEmoji(r.pool.QueryRow(ctx, customEmojiSelect+`WHERE e.name = $1`, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("emoji :%s: not found: %w", name, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error finding emoji :%s:: %w", name, err)
+	}
+	return emoji, nil
+}
+
+func (r *PostgresCustomEmojiRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.CustomEmoji, error) {
+	emoji, err := scanCustomEmoji(r.pool.QueryRow(ctx, customEmojiSelect+`WHERE e.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("emoji with ID %s not found: %w", id, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error finding emoji %s: %w", id, err)
+	}
+	return emoji, nil
+}
+
+// FindAll returns every emoji and alias of the workspace, ordered by name
+func (r *PostgresCustomEmojiRepository) FindAll(ctx context.Context) ([]models.CustomEmoji, error) {
+	rows, err := r.pool.Query(ctx, customEmojiSelect+`ORDER BY e.name ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying emoji: %w", err)
+	}
+	defer rows.Close()
+
+	emoji := []models.CustomEmoji{}
+	for rows.Next() {
+		e, err := scanCustomEmoji(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning emoji: %w", err)
+		}
+		emoji = append(emoji, *e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating emoji: %w", err)
+	}
+	return emoji, nil
+}
+
+// Delete removes an emoji. The aliases of the emoji are removed with it.
+func (r *PostgresCustomEmojiRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM custom_emoji WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("error deleting emoji %s: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("emoji with ID %s not found: %w", id, common.ErrNotFound)
+	}
+	return nil
+}
+
+func scanCustomEmoji(row pgx.Row) (*models.CustomEmoji, error) {
+	var id, creatorID uuid.UUID
+	var aliasForID *uuid.UUID
+	var name, aliasForName, contentType, storageKey string
+	var size int64
+	var createdAt time.Time
+
+	if err := row.Scan(&id, &name, &aliasForID, &aliasForName, &creatorID, &contentType, &size, &storageKey, &createdAt); err != nil {
+		return nil, err
+	}
+
+	emoji := models.RehydrateCustomEmoji(id, name, aliasForID, aliasForName, creatorID, contentType, size, storageKey, createdAt)
+	return &emoji, nil
+}
@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/common"
+)
+
+var _ ReminderRepository = (*PostgresReminderRepository)(nil)
+
+const reminderColumns = `r.id, r.user_id, r.channel_id, r.message_id, r.remind_at, r.fired_at, r.created_at`
+
+type PostgresReminderRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresReminderRepository(pool *pgxpool.Pool) *PostgresReminderRepository {
+	return &PostgresReminderRepository{
+		pool: pool,
+	}
+}
+
+func (r *PostgresReminderRepository) scanReminder(row pgx.Row) (models.Reminder, error) {
+	var id, userID, channelID, messageID uuid.UUID
+	var remindAt, createdAt time.Time
+	var firedAt *time.Time
+
+	if err := row.Scan(&id, &userID, &channelID, &messageID, &remindAt, &firedAt, &createdAt); err != nil {
+		return models.Reminder{}, err
+	}
+	return models.RehydrateReminder(id, userID, channelID, messageID, remindAt, firedAt, createdAt), nil
+}
+
+func (r *PostgresReminderRepository) queryReminders(ctx context.Context, query string, args ...any) ([]models.Reminder, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []models.Reminder{}
+	for rows.Next() {
+		reminder, err := r.scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, rows.Err()
+}
+
+func (r *PostgresReminderRepository) Save(ctx context.Context, reminder *models.Reminder) error {
+	query := `
+		INSERT INTO reminders (id, user_id, channel_id, message_id, remind_at, fired_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		reminder.GetId(),
+		reminder.GetUserId(),
+		reminder.GetChannelId(),
+		reminder.GetMessageId(),
+		reminder.GetRemindAt(),
+		reminder.GetFiredAt(),
+		reminder.GetCreatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting reminder %s: %w", reminder.GetId(), err)
+	}
+	return nil
+}
+
+func (r *PostgresReminderRepository) UpdateRemindAt(ctx context.Context, reminder *models.Reminder) error {
+	cmdTag, err := r.pool.Exec(ctx,
+		`UPDATE reminders SET remind_at = $3, fired_at = $4 WHERE id = $1 AND user_id = $2`,
+		reminder.GetId(), reminder.GetUserId(), reminder.GetRemindAt(), reminder.GetFiredAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating reminder %s: %w", reminder.GetId(), err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("reminder with ID %s not found: %w", reminder.GetId(), common.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *PostgresReminderRepository) Delete(ctx context.Context, userID, reminderID uuid.UUID) error {
+	cmdTag, err := r.pool.Exec(ctx, `DELETE FROM reminders WHERE id = $1 AND user_id = $2`, reminderID, userID)
+	if err != nil {
+		return fmt.Errorf("error deleting reminder %s: %w", reminderID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("reminder with ID %s not found: %w", reminderID, common.ErrNotFound)
+	}
+	return nil
+}
+
+func (r *PostgresReminderRepository) FindByID(ctx context.Context, userID, reminderID uuid.UUID) (*models.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders r WHERE r.id = $1 AND r.user_id = $2`
+
+	reminder, err := r.scanReminder(r.pool.QueryRow(ctx, query, reminderID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("reminder with ID %s not found: %w", reminderID, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error querying reminder %s: %w", reminderID, err)
+	}
+	return &reminder, nil
+}
+
+// FindByUser returns the user's reminders, soonest first.
+// Like bookmarks, reminders in channels the user has left are hidden until they rejoin.
+func (r *PostgresReminderRepository) FindByUser(ctx context.Context, userID uuid.UUID, status *models.ReminderStatus) ([]models.Reminder, error) {
+	where := "r.user_id = $1"
+	if status != nil {
+		switch *status {
+		case models.ReminderPending:
+			where += " AND r.fired_at IS NULL"
+		case models.ReminderFired:
+			where += " AND r.fired_at IS NOT NULL"
+		}
+	}
+
+	query := `
+		SELECT ` + reminderColumns + `
+		FROM reminders r
+		JOIN members mb ON mb.channel_id = r.channel_id AND mb.user_id = r.user_id
+		WHERE ` + where + `
+		ORDER BY r.remind_at ASC, r.id ASC
+	`
+	reminders, err := r.queryReminders(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying reminders for user %s: %w", userID, err)
+	}
+	return reminders, nil
+}
+
+func (r *PostgresReminderRepository) CountPending(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM reminders WHERE user_id = $1 AND fired_at IS NULL`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting reminders for user %s: %w", userID, err)
+	}
+	return count, nil
+}
+
+// ClaimDue marks up to limit due reminders as fired and returns them.
+// Rows locked by another instance are skipped, so each reminder fires on exactly one instance.
+// Reminders in channels the user has left wait until they rejoin.
+func (r *PostgresReminderRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error) {
+	query := `
+		UPDATE reminders r
+		SET fired_at = $1
+		WHERE r.id IN (
+			SELECT due.id FROM reminders due
+			JOIN members mb ON mb.channel_id = due.channel_id AND mb.user_id = due.user_id
+			WHERE due.fired_at IS NULL AND due.remind_at <= $1
+			ORDER BY due.remind_at ASC
+			LIMIT $2
+			FOR UPDATE OF due SKIP LOCKED
+		)
+		RETURNING ` + reminderColumns
+
+	reminders, err := r.queryReminders(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error claiming due reminders: %w", err)
+	}
+	return reminders, nil
+}
@@ -0,0 +1,248 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"github.com/m1thrandir225/meridian/pkg/common"
+)
+
+var _ ScheduledMessageRepository = (*PostgresScheduledMessageRepository)(nil)
+
+const scheduledMessageColumns = `id, channel_id, sender_user_id, parent_message_id, content_text,
+		       scheduled_for, status, failure_reason, created_at, updated_at`
+
+type PostgresScheduledMessageRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresScheduledMessageRepository(pool *pgxpool.Pool) *PostgresScheduledMessageRepository {
+	return &PostgresScheduledMessageRepository{
+		pool: pool,
+	}
+}
+
+func (r *PostgresScheduledMessageRepository) scanScheduledMessage(row pgx.Row) (models.ScheduledMessage, error) {
+	var id, channelID, senderUserID uuid.UUID
+	var parentMessageID *uuid.UUID
+	var contentText, status, failureReason string
+	var scheduledFor, createdAt, updatedAt time.Time
+
+	err := row.Scan(
+		&id,
+		&channelID,
+		&senderUserID,
+		&parentMessageID,
+		&contentText,
+		&scheduledFor,
+		&status,
+		&failureReason,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return models.ScheduledMessage{}, err
+	}
+
+	return models.RehydrateScheduledMessage(
+		id,
+		channelID,
+		senderUserID,
+		parentMessageID,
+		contentText,
+		scheduledFor,
+		models.ScheduledMessageStatus(status),
+		failureReason,
+		createdAt,
+		updatedAt,
+	), nil
+}
+
+func (r *PostgresScheduledMessageRepository) queryScheduledMessages(ctx context.Context, query string, args ...any) ([]models.ScheduledMessage, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []models.ScheduledMessage{}
+	for rows.Next() {
+		message, err := r.scanScheduledMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+func (r *PostgresScheduledMessageRepository) Save(ctx context.Context, message *models.ScheduledMessage) error {
+	query := `
+		INSERT INTO scheduled_messages (id, channel_id, sender_user_id, parent_message_id, content_text,
+		                                scheduled_for, status, failure_reason, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		message.GetId(),
+		message.GetChannelId(),
+		message.GetSenderUserId(),
+		message.GetParentMessageId(),
+		message.GetContentText(),
+		message.GetScheduledFor(),
+		string(message.GetStatus()),
+		message.GetFailureReason(),
+		message.GetCreatedAt(),
+		message.GetUpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("error inserting scheduled message %s: %w", message.GetId(), err)
+	}
+	return nil
+}
+
+// Update stores the new content and time unless a dispatcher has claimed the message in the meantime
+func (r *PostgresScheduledMessageRepository) Update(ctx context.Context, message *models.ScheduledMessage) error {
+	query := `
+		UPDATE scheduled_messages
+		SET content_text = $3, scheduled_for = $4, status = $5, failure_reason = $6, updated_at = $7
+		WHERE id = $1 AND sender_user_id = $2 AND status <> 'sending'
+	`
+	cmdTag, err := r.pool.Exec(ctx, query,
+		message.GetId(),
+		message.GetSenderUserId(),
+		message.GetContentText(),
+		message.GetScheduledFor(),
+		string(message.GetStatus()),
+		message.GetFailureReason(),
+		message.GetUpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("error updating scheduled message %s: %w", message.GetId(), err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled message %s: %w", message.GetId(), models.ErrScheduledMessageSending)
+	}
+	return nil
+}
+
+// Delete removes a scheduled message unless a dispatcher has claimed it in the meantime
+func (r *PostgresScheduledMessageRepository) Delete(ctx context.Context, userID, scheduledMessageID uuid.UUID) error {
+	cmdTag, err := r.pool.Exec(ctx,
+		`DELETE FROM scheduled_messages WHERE id = $1 AND sender_user_id = $2 AND status <> 'sending'`,
+		scheduledMessageID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("error deleting scheduled message %s: %w", scheduledMessageID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled message %s: %w", scheduledMessageID, models.ErrScheduledMessageSending)
+	}
+	return nil
+}
+
+func (r *PostgresScheduledMessageRepository) FindByID(ctx context.Context, userID, scheduledMessageID uuid.UUID) (*models.ScheduledMessage, error) {
+	query := `SELECT ` + scheduledMessageColumns + ` FROM scheduled_messages WHERE id = $1 AND sender_user_id = $2`
+
+	message, err := r.scanScheduledMessage(r.pool.QueryRow(ctx, query, scheduledMessageID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("scheduled message with ID %s not found: %w", scheduledMessageID, common.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error querying scheduled message %s: %w", scheduledMessageID, err)
+	}
+	return &message, nil
+}
+
+// FindByUser returns the user's unsent scheduled messages, soonest first, optionally limited to one channel
+func (r *PostgresScheduledMessageRepository) FindByUser(ctx context.Context, userID uuid.UUID, channelID *uuid.UUID) ([]models.ScheduledMessage, error) {
+	query := `
+		SELECT ` + scheduledMessageColumns + `
+		FROM scheduled_messages
+		WHERE sender_user_id = $1 AND ($2::uuid IS NULL OR channel_id = $2)
+		ORDER BY scheduled_for ASC, id ASC
+	`
+	messages, err := r.queryScheduledMessages(ctx, query, userID, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying scheduled messages for user %s: %w", userID, err)
+	}
+	return messages, nil
+}
+
+func (r *PostgresScheduledMessageRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM scheduled_messages WHERE sender_user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("error counting scheduled messages for user %s: %w", userID, err)
+	}
+	return count, nil
+}
+
+// ClaimDue marks up to limit due messages as sending and returns them, soonest first.
+// Rows locked by another instance are skipped, so each message is claimed by exactly one dispatcher.
+func (r *PostgresScheduledMessageRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]models.ScheduledMessage, error) {
+	query := `
+		UPDATE scheduled_messages
+		SET status = 'sending', claimed_at = $1, updated_at = $1
+		WHERE id IN (
+			SELECT id FROM scheduled_messages
+			WHERE status = 'pending' AND scheduled_for <= $1
+			ORDER BY scheduled_for ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + scheduledMessageColumns
+
+	messages, err := r.queryScheduledMessages(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error claiming due scheduled messages: %w", err)
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].GetScheduledFor().Before(messages[j].GetScheduledFor())
+	})
+	return messages, nil
+}
+
+// MarkSent removes a claimed message once it has been posted
+func (r *PostgresScheduledMessageRepository) MarkSent(ctx context.Context, scheduledMessageID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM scheduled_messages WHERE id = $1 AND status = 'sending'`, scheduledMessageID)
+	if err != nil {
+		return fmt.Errorf("error removing sent scheduled message %s: %w", scheduledMessageID, err)
+	}
+	return nil
+}
+
+func (r *PostgresScheduledMessageRepository) MarkFailed(ctx context.Context, scheduledMessageID uuid.UUID, reason string) error {
+	query := `
+		UPDATE scheduled_messages
+		SET status = 'failed', failure_reason = $2, claimed_at = NULL, updated_at = $3
+		WHERE id = $1 AND status = 'sending'
+	`
+	_, err := r.pool.Exec(ctx, query, scheduledMessageID, reason, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("error marking scheduled message %s as failed: %w", scheduledMessageID, err)
+	}
+	return nil
+}
+
+// FailStaleClaims fails messages whose dispatcher stopped before reporting back.
+// They are not retried, as the message may already have been posted.
+func (r *PostgresScheduledMessageRepository) FailStaleClaims(ctx context.Context, claimedBefore time.Time, reason string) (int64, error) {
+	query := `
+		UPDATE scheduled_messages
+		SET status = 'failed', failure_reason = $2, claimed_at = NULL, updated_at = $3
+		WHERE status = 'sending' AND claimed_at < $1
+	`
+	cmdTag, err := r.pool.Exec(ctx, query, claimedBefore, reason, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("error failing stale scheduled message claims: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
@@ -0,0 +1,19 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+type ReminderRepository interface {
+	Save(ctx context.Context, reminder *models.Reminder) error
+	UpdateRemindAt(ctx context.Context, reminder *models.Reminder) error
+	Delete(ctx context.Context, userID, reminderID uuid.UUID) error
+	FindByID(ctx context.Context, userID, reminderID uuid.UUID) (*models.Reminder, error)
+	FindByUser(ctx context.Context, userID uuid.UUID, status *models.ReminderStatus) ([]models.Reminder, error)
+	CountPending(ctx context.Context, userID uuid.UUID) (int, error)
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]models.Reminder, error)
+}
@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	models "github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+type ScheduledMessageRepository interface {
+	Save(ctx context.Context, message *models.ScheduledMessage) error
+	Update(ctx context.Context, message *models.ScheduledMessage) error
+	Delete(ctx context.Context, userID, scheduledMessageID uuid.UUID) error
+	FindByID(ctx context.Context, userID, scheduledMessageID uuid.UUID) (*models.ScheduledMessage, error)
+	FindByUser(ctx context.Context, userID uuid.UUID, channelID *uuid.UUID) ([]models.ScheduledMessage, error)
+	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]models.ScheduledMessage, error)
+	MarkSent(ctx context.Context, scheduledMessageID uuid.UUID) error
+	MarkFailed(ctx context.Context, scheduledMessageID uuid.UUID, reason string) error
+	FailStaleClaims(ctx context.Context, claimedBefore time.Time, reason string) (int64, error)
+}
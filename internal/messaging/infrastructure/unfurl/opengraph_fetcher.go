@@ -0,0 +1,280 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+	"golang.org/x/net/html"
+)
+
+const (
+	DefaultTimeout      = 5 * time.Second
+	DefaultMaxBodyBytes = 512 * 1024
+	DefaultMaxRedirects = 3
+	DefaultUserAgent    = "MeridianBot/1.0 (+link preview)"
+)
+
+var (
+	// ErrBlockedAddress is returned when a link resolves to a loopback, private or otherwise internal address
+	ErrBlockedAddress = errors.New("address is not publicly routable")
+	// ErrUnsupportedContent is returned for responses that are not HTML pages
+	ErrUnsupportedContent = errors.New("response is not an HTML page")
+)
+
+// blockedPrefixes are the special-purpose ranges not covered by the net.IP helpers used in isPublicAddress
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+type Config struct {
+	Timeout      time.Duration
+	MaxBodyBytes int64
+	MaxRedirects int
+	UserAgent    string
+	// AllowPrivateNetworks disables the check against internal addresses. Only meant for tests against a local server.
+	AllowPrivateNetworks bool
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:      DefaultTimeout,
+		MaxBodyBytes: DefaultMaxBodyBytes,
+		MaxRedirects: DefaultMaxRedirects,
+		UserAgent:    DefaultUserAgent,
+	}
+}
+
+// OpenGraphFetcher reads the OpenGraph and Twitter card tags of a web page
+type OpenGraphFetcher struct {
+	client *http.Client
+	config Config
+}
+
+func NewOpenGraphFetcher(config Config) *OpenGraphFetcher {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if config.MaxRedirects < 0 {
+		config.MaxRedirects = DefaultMaxRedirects
+	}
+	if config.UserAgent == "" {
+		config.UserAgent = DefaultUserAgent
+	}
+
+	// The address is checked when connecting, after DNS resolution, so redirects and
+	// DNS rebinding cannot reach internal services either
+	dialer := &net.Dialer{
+		Timeout: config.Timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			if config.AllowPrivateNetworks {
+				return nil
+			}
+			return checkAddress(address)
+		},
+	}
+
+	transport := &http.Transport{
+		// Proxies from the environment are not used, they would bypass the address check
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   config.Timeout,
+		ResponseHeaderTimeout: config.Timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   config.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > config.MaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", config.MaxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+
+	return &OpenGraphFetcher{
+		client: client,
+		config: config,
+	}
+}
+
+// Fetch downloads at most MaxBodyBytes of the page and builds a preview from its metadata
+func (f *OpenGraphFetcher) Fetch(ctx context.Context, rawURL string) (*domain.LinkPreview, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q", target.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.config.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %w", rawURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, rawURL)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, ErrUnsupportedContent
+	}
+
+	meta := readPageMetadata(io.LimitReader(resp.Body, f.config.MaxBodyBytes))
+
+	// Relative image URLs are resolved against the page the redirects ended on
+	imageURL := ""
+	if meta.image != "" {
+		if resolved, err := resp.Request.URL.Parse(meta.image); err == nil && (resolved.Scheme == "http" || resolved.Scheme == "https") {
+			imageURL = resolved.String()
+		}
+	}
+
+	siteName := meta.siteName
+	if siteName == "" {
+		siteName = resp.Request.URL.Hostname()
+	}
+
+	return domain.NewLinkPreview(rawURL, meta.title, meta.description, siteName, imageURL)
+}
+
+type pageMetadata struct {
+	title       string
+	description string
+	siteName    string
+	image       string
+}
+
+// readPageMetadata reads the <head> of a page. OpenGraph tags win over Twitter card tags,
+// which win over <title> and <meta name="description">.
+func readPageMetadata(body io.Reader) pageMetadata {
+	tags := make(map[string]string)
+	var title strings.Builder
+	inTitle := false
+
+	tokenizer := html.NewTokenizer(body)
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			return newPageMetadata(tags, title.String())
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "body":
+				return newPageMetadata(tags, title.String())
+			case "title":
+				inTitle = tokenType == html.StartTagToken
+			case "meta":
+				var key, content string
+				for _, attr := range token.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "property", "name":
+						key = strings.ToLower(strings.TrimSpace(attr.Val))
+					case "content":
+						content = strings.TrimSpace(attr.Val)
+					}
+				}
+				if _, seen := tags[key]; key != "" && content != "" && !seen {
+					tags[key] = content
+				}
+			}
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if token.Data == "title" {
+				inTitle = false
+			}
+			if token.Data == "head" {
+				return newPageMetadata(tags, title.String())
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+func newPageMetadata(tags map[string]string, title string) pageMetadata {
+	return pageMetadata{
+		title:       firstNonEmpty(tags["og:title"], tags["twitter:title"], title),
+		description: firstNonEmpty(tags["og:description"], tags["twitter:description"], tags["description"]),
+		siteName:    tags["og:site_name"],
+		image:       firstNonEmpty(tags["og:image:secure_url"], tags["og:image"], tags["twitter:image"], tags["twitter:image:src"]),
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func checkAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+	}
+	if !isPublicAddress(addr.Unmap()) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	return nil
+}
+
+func isPublicAddress(addr netip.Addr) bool {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,245 @@
+package unfurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/m1thrandir225/meridian/internal/messaging/domain"
+)
+
+// newTestFetcher returns a fetcher that may reach the httptest server on the loopback interface
+func newTestFetcher(config Config) *OpenGraphFetcher {
+	config.AllowPrivateNetworks = true
+	return NewOpenGraphFetcher(config)
+}
+
+func servePage(page string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, page)
+	}
+}
+
+func TestFetchReadsOpenGraphTags(t *testing.T) {
+	server := httptest.NewServer(servePage(`<!DOCTYPE html>
+<html>
+<head>
+	<title>Page title</title>
+	<meta name="description" content="Page description">
+	<meta name="twitter:title" content="Twitter title">
+	<meta property="og:title" content="OpenGraph title">
+	<meta property="og:description" content="OpenGraph description">
+	<meta property="og:site_name" content="Meridian">
+	<meta property="og:image" content="/images/cover.png">
+</head>
+<body><meta property="og:title" content="Ignored"></body>
+</html>`))
+	defer server.Close()
+
+	preview, err := newTestFetcher(DefaultConfig()).Fetch(context.Background(), server.URL+"/article")
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+
+	if got := preview.GetUrl(); got != server.URL+"/article" {
+		t.Errorf("url = %q, want %q", got, server.URL+"/article")
+	}
+	if got := preview.GetTitle(); got != "OpenGraph title" {
+		t.Errorf("title = %q, want %q", got, "OpenGraph title")
+	}
+	if got := preview.GetDescription(); got != "OpenGraph description" {
+		t.Errorf("description = %q, want %q", got, "OpenGraph description")
+	}
+	if got := preview.GetSiteName(); got != "Meridian" {
+		t.Errorf("site name = %q, want %q", got, "Meridian")
+	}
+	if got := preview.GetImageUrl(); got != server.URL+"/images/cover.png" {
+		t.Errorf("image url = %q, want %q", got, server.URL+"/images/cover.png")
+	}
+}
+
+func TestFetchFallsBackToTwitterAndPageTags(t *testing.T) {
+	server := httptest.NewServer(servePage(`<html><head>
+	<title>Page title</title>
+	<meta name="description" content="Page description">
+	<meta name="twitter:image" content="https://cdn.example.com/card.png">
+</head><body></body></html>`))
+	defer server.Close()
+
+	preview, err := newTestFetcher(DefaultConfig()).Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+
+	if got := preview.GetTitle(); got != "Page title" {
+		t.Errorf("title = %q, want %q", got, "Page title")
+	}
+	if got := preview.GetDescription(); got != "Page description" {
+		t.Errorf("description = %q, want %q", got, "Page description")
+	}
+	if got := preview.GetSiteName(); got != "127.0.0.1" {
+		t.Errorf("site name = %q, want the host name", got)
+	}
+	if got := preview.GetImageUrl(); got != "https://cdn.example.com/card.png" {
+		t.Errorf("image url = %q, want %q", got, "https://cdn.example.com/card.png")
+	}
+}
+
+func TestFetchRejectsNonHTMLResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"title": "not a page"}`)
+	}))
+	defer server.Close()
+
+	_, err := newTestFetcher(DefaultConfig()).Fetch(context.Background(), server.URL)
+	if !errors.Is(err, ErrUnsupportedContent) {
+		t.Fatalf("Fetch error = %v, want %v", err, ErrUnsupportedContent)
+	}
+}
+
+func TestFetchTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := DefaultConfig()
+	config.Timeout = 100 * time.Millisecond
+
+	start := time.Now()
+	_, err := newTestFetcher(config).Fetch(context.Background(), server.URL)
+	if err == nil {
+		t.Fatal("Fetch succeeded, want a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Fetch took %s, want it to stop after the %s timeout", elapsed, config.Timeout)
+	}
+}
+
+func TestFetchReadsAtMostMaxBodyBytes(t *testing.T) {
+	padding := "<!--" + strings.Repeat("x", 1024) + "-->"
+	server := httptest.NewServer(servePage(`<html><head>` + padding + `<meta property="og:title" content="Beyond the limit"></head></html>`))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxBodyBytes = 512
+
+	_, err := newTestFetcher(config).Fetch(context.Background(), server.URL)
+	if !errors.Is(err, domain.ErrNoLinkPreview) {
+		t.Fatalf("Fetch error = %v, want %v for tags past the size cap", err, domain.ErrNoLinkPreview)
+	}
+
+	config.MaxBodyBytes = 4096
+	preview, err := newTestFetcher(config).Fetch(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if got := preview.GetTitle(); got != "Beyond the limit" {
+		t.Errorf("title = %q, want %q", got, "Beyond the limit")
+	}
+}
+
+func TestFetchLimitsRedirects(t *testing.T) {
+	// /hops/<n> redirects n more times before serving the page
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hops/", func(w http.ResponseWriter, r *http.Request) {
+		hops, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hops/"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if hops > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/hops/%d", hops-1), http.StatusFound)
+			return
+		}
+		servePage(`<html><head><title>Destination</title></head></html>`)(w, r)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxRedirects = 2
+	fetcher := newTestFetcher(config)
+
+	preview, err := fetcher.Fetch(context.Background(), server.URL+"/hops/2")
+	if err != nil {
+		t.Fatalf("Fetch with %d redirects returned error: %v", config.MaxRedirects, err)
+	}
+	if got := preview.GetTitle(); got != "Destination" {
+		t.Errorf("title = %q, want %q", got, "Destination")
+	}
+
+	if _, err := fetcher.Fetch(context.Background(), server.URL+"/hops/3"); err == nil {
+		t.Fatalf("Fetch with %d redirects succeeded, want an error", config.MaxRedirects+1)
+	}
+}
+
+func TestFetchRejectsPrivateAddressesWhenDialing(t *testing.T) {
+	var requested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested.Store(true)
+		servePage(`<html><head><title>Internal</title></head></html>`)(w, r)
+	}))
+	defer server.Close()
+
+	_, err := NewOpenGraphFetcher(DefaultConfig()).Fetch(context.Background(), server.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Fetch error = %v, want %v", err, ErrBlockedAddress)
+	}
+	if requested.Load() {
+		t.Error("request reached the loopback server")
+	}
+
+	// A host name is resolved first, the check applies to the address it resolves to
+	_, err = NewOpenGraphFetcher(DefaultConfig()).Fetch(context.Background(), strings.Replace(server.URL, "127.0.0.1", "localhost", 1))
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Fetch error = %v, want %v", err, ErrBlockedAddress)
+	}
+}
+
+func TestCheckAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		allowed bool
+	}{
+		{"93.184.216.34:443", true},
+		{"[2606:2800:220:1:248:1893:25c8:1946]:443", true},
+		{"127.0.0.1:80", false},
+		{"10.1.2.3:80", false},
+		{"172.16.0.1:80", false},
+		{"192.168.1.1:80", false},
+		{"169.254.169.254:80", false},
+		{"100.64.0.1:80", false},
+		{"0.0.0.0:80", false},
+		{"198.18.0.1:80", false},
+		{"[::1]:80", false},
+		{"[fc00::1]:80", false},
+		{"[fe80::1]:80", false},
+		{"[::ffff:127.0.0.1]:80", false},
+		{"[64:ff9b::a00:1]:80", false},
+	}
+
+	for _, tt := range tests {
+		err := checkAddress(tt.address)
+		if tt.allowed && err != nil {
+			t.Errorf("checkAddress(%q) = %v, want it allowed", tt.address, err)
+		}
+		if !tt.allowed && !errors.Is(err, ErrBlockedAddress) {
+			t.Errorf("checkAddress(%q) = %v, want %v", tt.address, err, ErrBlockedAddress)
+		}
+	}
+}